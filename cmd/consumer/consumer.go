@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/jailtonjunior94/order/configs"
+	"github.com/jailtonjunior94/order/internal/order"
 	"github.com/jailtonjunior94/order/pkg/bundle"
 	kafkaConsumer "github.com/jailtonjunior94/order/pkg/messaging/kafka"
 	"github.com/segmentio/kafka-go"
@@ -38,6 +39,10 @@ func (c *consumer) Run() {
 	}()
 	ioc := bundle.NewContainer(ctx)
 
+	if err := validateTopics(ioc.Config); err != nil {
+		log.Fatal(err)
+	}
+
 	/* Observability */
 	tracerProvider := ioc.Observability.TracerProvider()
 	defer func() {
@@ -65,20 +70,44 @@ func (c *consumer) Run() {
 
 	c.declareTopics(ioc.Config)
 
+	/* Order */
+	paymentHandler := order.RegisterPaymentConsumer(ioc)
+
 	consumer := kafkaConsumer.NewConsumer(
 		ioc.Observability,
 		kafkaConsumer.WithBrokers(ioc.Config.KafkaConfig.Brokers),
 		kafkaConsumer.WithGroupID(ioc.Config.KafkaConfig.OrderGroupID),
-		kafkaConsumer.WithTopic(ioc.Config.KafkaConfig.Order),
+		kafkaConsumer.WithTopic(ioc.Config.KafkaConfig.Payment),
+		kafkaConsumer.WithMaxRetries(3),
+		kafkaConsumer.WithRetryChan(1000),
+		kafkaConsumer.WithBackoff(backoff),
+		kafkaConsumer.WithReader(),
+		kafkaConsumer.WithHandler(paymentHandler.Handle),
+	)
+
+	go func() {
+		if err := consumer.Consume(ctx, paymentHandler.Handle); err != nil {
+			log.Printf("Error consuming messages: %v", err)
+			cancel()
+		}
+	}()
+
+	inventoryHandler := order.RegisterInventoryConsumer(ioc)
+
+	inventoryConsumer := kafkaConsumer.NewConsumer(
+		ioc.Observability,
+		kafkaConsumer.WithBrokers(ioc.Config.KafkaConfig.Brokers),
+		kafkaConsumer.WithGroupID(ioc.Config.KafkaConfig.OrderGroupID),
+		kafkaConsumer.WithTopic(ioc.Config.KafkaConfig.Inventory),
 		kafkaConsumer.WithMaxRetries(3),
 		kafkaConsumer.WithRetryChan(1000),
 		kafkaConsumer.WithBackoff(backoff),
 		kafkaConsumer.WithReader(),
-		kafkaConsumer.WithHandler(handlerMessage),
+		kafkaConsumer.WithHandler(inventoryHandler.Handle),
 	)
 
 	go func() {
-		if err := consumer.Consume(ctx, handlerMessage); err != nil {
+		if err := inventoryConsumer.Consume(ctx, inventoryHandler.Handle); err != nil {
 			log.Printf("Error consuming messages: %v", err)
 			cancel()
 		}
@@ -94,6 +123,19 @@ func (c *consumer) Run() {
 	log.Println("Consumer has been shut down.")
 }
 
+// validateTopics fails startup instead of letting the reader subscribe to an
+// empty topic and silently consume nothing.
+func validateTopics(config *configs.Config) error {
+	if config.KafkaConfig.Payment == "" {
+		return errors.New("KAFKA_PAYMENT_TOPIC is required")
+	}
+
+	if config.KafkaConfig.Inventory == "" {
+		return errors.New("KAFKA_INVENTORY_TOPIC is required")
+	}
+	return nil
+}
+
 func (c *consumer) declareTopics(config *configs.Config) {
 	conn, err := kafka.Dial("tcp", config.KafkaConfig.Brokers[0])
 	if err != nil {
@@ -112,13 +154,15 @@ func (c *consumer) declareTopics(config *configs.Config) {
 			config.KafkaConfig.OrderPartitions,
 			config.KafkaConfig.OrderReplicationFactor,
 		),
+		kafkaConsumer.NewTopicConfig(
+			config.KafkaConfig.Payment,
+			config.KafkaConfig.OrderPartitions,
+			config.KafkaConfig.OrderReplicationFactor,
+		),
+		kafkaConsumer.NewTopicConfig(
+			config.KafkaConfig.Inventory,
+			config.KafkaConfig.OrderPartitions,
+			config.KafkaConfig.OrderReplicationFactor,
+		),
 	).Build()
 }
-
-func handlerMessage(ctx context.Context, body []byte) error {
-	log.Println("Message received: ", string(body))
-	if string(body) == "error" {
-		return errors.New("deu ruim")
-	}
-	return nil
-}
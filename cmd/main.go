@@ -3,12 +3,15 @@ package main
 import (
 	"context"
 	"log"
+	"strings"
 
 	"github.com/jailtonjunior94/order/cmd/consumer"
+	"github.com/jailtonjunior94/order/cmd/outbox"
 	"github.com/jailtonjunior94/order/cmd/server"
 	"github.com/jailtonjunior94/order/cmd/worker"
 	"github.com/jailtonjunior94/order/pkg/bundle"
 	migration "github.com/jailtonjunior94/order/pkg/database/migrate"
+	"github.com/jailtonjunior94/order/pkg/database/postgres"
 
 	"github.com/spf13/cobra"
 )
@@ -31,6 +34,18 @@ func main() {
 			if err = migrate.Execute(); err != nil {
 				log.Fatal(err)
 			}
+
+			if container.Config.DBConfig.Engine != postgres.EnginePostgres {
+				return
+			}
+
+			postgresMigrate, err := migration.NewMigratePostgres(container.DB, strings.TrimRight(container.Config.DBConfig.MigratePath, "/")+"/postgres", container.Config.DBConfig.Name)
+			if err != nil {
+				log.Fatal(err)
+			}
+			if err = postgresMigrate.Execute(); err != nil {
+				log.Fatal(err)
+			}
 		},
 	}
 
@@ -58,6 +73,6 @@ func main() {
 		},
 	}
 
-	root.AddCommand(migrate, server, consumers, workers)
+	root.AddCommand(migrate, server, consumers, workers, outbox.NewOutboxCommand())
 	root.Execute()
 }
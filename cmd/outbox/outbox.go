@@ -0,0 +1,214 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jailtonjunior94/order/internal/order"
+	"github.com/jailtonjunior94/order/internal/order/domain/dtos"
+	"github.com/jailtonjunior94/order/internal/order/usecase"
+	"github.com/jailtonjunior94/order/pkg/bundle"
+	"github.com/jailtonjunior94/order/pkg/vos"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+type outboxCommand struct {
+	output string
+	writer io.Writer
+}
+
+func NewOutboxCommand() *cobra.Command {
+	c := &outboxCommand{writer: os.Stdout}
+
+	root := &cobra.Command{
+		Use:   "outbox",
+		Short: "Outbox Administration",
+	}
+	root.PersistentFlags().StringVarP(&c.output, "output", "o", outputTable, "output format (table|json)")
+
+	root.AddCommand(c.list(), c.show(), c.replay(), c.requeue())
+	return root
+}
+
+func (c *outboxCommand) list() *cobra.Command {
+	input := &dtos.OutboxFilterInput{}
+	var since string
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List outbox rows",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := parseSince(since, input); err != nil {
+				return err
+			}
+
+			return c.run(cmd.Context(), func(admin usecase.OutboxAdminUseCase) error {
+				outboxes, err := admin.List(cmd.Context(), input)
+				if err != nil {
+					return err
+				}
+				return c.print(outboxes)
+			})
+		},
+	}
+	cmd.Flags().StringVar(&input.Status, "status", usecase.OutboxStatusAll, "row status (all|pending|published|skipped)")
+	cmd.Flags().StringVar(&input.EventName, "event-name", "", "filter by event name")
+	cmd.Flags().StringVar(&since, "since", "", "rows created at or after (RFC3339 or duration, e.g. 1h)")
+	cmd.Flags().IntVar(&input.Limit, "limit", 100, "maximum number of rows")
+	return cmd
+}
+
+func (c *outboxCommand) show() *cobra.Command {
+	return &cobra.Command{
+		Use:   "show <id>",
+		Short: "Show an outbox row",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := vos.NewUUIDFromString(args[0])
+			if err != nil {
+				return err
+			}
+
+			return c.run(cmd.Context(), func(admin usecase.OutboxAdminUseCase) error {
+				outbox, err := admin.Show(cmd.Context(), id)
+				if err != nil {
+					return err
+				}
+				return c.printOne(outbox)
+			})
+		},
+	}
+}
+
+func (c *outboxCommand) replay() *cobra.Command {
+	input := &dtos.OutboxFilterInput{Status: usecase.OutboxStatusAll}
+	var since string
+
+	cmd := &cobra.Command{
+		Use:   "replay",
+		Short: "Publish matching outbox rows again",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := parseSince(since, input); err != nil {
+				return err
+			}
+
+			return c.run(cmd.Context(), func(admin usecase.OutboxAdminUseCase) error {
+				output, err := admin.Replay(cmd.Context(), input)
+				if err != nil {
+					return err
+				}
+
+				if c.output == outputJSON {
+					return json.NewEncoder(c.writer).Encode(output)
+				}
+				_, err = fmt.Fprintf(c.writer, "replayed %d events\n", output.Replayed)
+				return err
+			})
+		},
+	}
+	cmd.Flags().StringVar(&input.Status, "status", usecase.OutboxStatusAll, "row status (all|pending|published|skipped), all leaves skipped rows out")
+	cmd.Flags().StringVar(&input.EventName, "event-name", "", "replay only this event name")
+	cmd.Flags().StringVar(&since, "since", "", "replay rows created at or after (RFC3339 or duration, e.g. 1h)")
+	cmd.Flags().IntVar(&input.Limit, "limit", 0, "maximum number of rows (0 for no limit)")
+	return cmd
+}
+
+func (c *outboxCommand) requeue() *cobra.Command {
+	return &cobra.Command{
+		Use:   "requeue <id>",
+		Short: "Mark an outbox row as pending so the relay publishes it again",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := vos.NewUUIDFromString(args[0])
+			if err != nil {
+				return err
+			}
+
+			return c.run(cmd.Context(), func(admin usecase.OutboxAdminUseCase) error {
+				outbox, err := admin.Requeue(cmd.Context(), id)
+				if err != nil {
+					return err
+				}
+				return c.printOne(outbox)
+			})
+		},
+	}
+}
+
+func (c *outboxCommand) run(ctx context.Context, fn func(admin usecase.OutboxAdminUseCase) error) error {
+	ioc := bundle.NewContainer(ctx)
+	defer ioc.DB.Close()
+
+	return fn(order.RegisterOutboxAdmin(ioc))
+}
+
+// printOne writes a single outbox as a JSON object, where print always
+// writes an array so list output keeps one shape whatever the row count.
+func (c *outboxCommand) printOne(outbox *dtos.OutboxOutput) error {
+	if c.output == outputJSON {
+		return c.encode(outbox)
+	}
+	return c.print([]*dtos.OutboxOutput{outbox})
+}
+
+func (c *outboxCommand) print(outboxes []*dtos.OutboxOutput) error {
+	if c.output == outputJSON {
+		if outboxes == nil {
+			outboxes = []*dtos.OutboxOutput{}
+		}
+		return c.encode(outboxes)
+	}
+
+	writer := tabwriter.NewWriter(c.writer, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "ID\tAGGREGATE ID\tEVENT\tPUBLISHED\tPUBLISHED AT\tCREATED AT")
+	for _, outbox := range outboxes {
+		publishedAt := "-"
+		if outbox.PublishedAt != nil {
+			publishedAt = outbox.PublishedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%t\t%s\t%s\n",
+			outbox.ID,
+			outbox.AggregateID,
+			outbox.EventName,
+			outbox.WasPublished,
+			publishedAt,
+			outbox.CreatedAt.Format(time.RFC3339),
+		)
+	}
+	return writer.Flush()
+}
+
+func (c *outboxCommand) encode(value any) error {
+	encoder := json.NewEncoder(c.writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+func parseSince(value string, input *dtos.OutboxFilterInput) error {
+	if value == "" {
+		return nil
+	}
+
+	if duration, err := time.ParseDuration(value); err == nil {
+		input.Since = time.Now().UTC().Add(-duration)
+		return nil
+	}
+
+	since, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return fmt.Errorf("invalid --since %q: %w", value, err)
+	}
+	input.Since = since
+	return nil
+}
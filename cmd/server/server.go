@@ -13,6 +13,7 @@ import (
 
 	"github.com/jailtonjunior94/order/internal/order"
 	"github.com/jailtonjunior94/order/pkg/bundle"
+	"github.com/jailtonjunior94/order/pkg/middlewares"
 	"github.com/jailtonjunior94/order/pkg/responses"
 
 	"github.com/go-chi/chi/v5"
@@ -56,6 +57,8 @@ func (s *apiServer) Run() {
 	router.Use(
 		middleware.RealIP,
 		middleware.RequestID,
+		middlewares.Audit("api"),
+		middlewares.Customer(ioc.Config.HTTPConfig.GatewayToken),
 		middleware.SetHeader("Content-Type", "application/json"),
 		middleware.AllowContentType("application/json", "application/x-www-form-urlencoded"),
 	)
@@ -69,7 +72,35 @@ func (s *apiServer) Run() {
 	})
 
 	/* Order */
-	order.RegisterOrderModule(ioc, router)
+	if err := order.RegisterOrderModule(ioc, router); err != nil {
+		log.Fatal(err)
+	}
+
+	/* Admin */
+	if ioc.Config.HTTPConfig.AdminToken != "" {
+		adminRouter := chi.NewRouter()
+		adminRouter.Use(
+			middlewares.BearerToken(ioc.Config.HTTPConfig.AdminToken),
+			middlewares.TrustedAudit("admin"),
+		)
+		order.RegisterOutboxAdminModule(ioc, adminRouter)
+		order.RegisterCouponAdminModule(ioc, adminRouter)
+		order.RegisterReturnAdminModule(ioc, adminRouter)
+		order.RegisterShipmentAdminModule(ioc, adminRouter)
+		order.RegisterOrderAdminModule(ioc, adminRouter)
+		router.Mount("/admin", adminRouter)
+	}
+
+	/* Carrier webhooks */
+	if ioc.Config.ShipmentConfig.WebhookToken != "" {
+		webhookRouter := chi.NewRouter()
+		webhookRouter.Use(
+			middlewares.BearerToken(ioc.Config.ShipmentConfig.WebhookToken),
+			middlewares.TrustedAudit("carrier"),
+		)
+		order.RegisterCarrierWebhookModule(ioc, webhookRouter)
+		router.Mount("/webhooks", webhookRouter)
+	}
 
 	/* Graceful shutdown */
 	server := http.Server{
@@ -3,11 +3,13 @@ package worker
 import (
 	"context"
 	"log"
+	"os/signal"
+	"syscall"
 
 	"github.com/jailtonjunior94/order/internal/order"
 	"github.com/jailtonjunior94/order/pkg/bundle"
-
-	"github.com/robfig/cron/v3"
+	"github.com/jailtonjunior94/order/pkg/database/lease"
+	"github.com/jailtonjunior94/order/pkg/jobs"
 )
 
 type worker struct {
@@ -18,20 +20,22 @@ func NewWorkers() *worker {
 }
 
 func (w *worker) Run() {
-	ctx := context.Background()
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
 	ioc := bundle.NewContainer(ctx)
 
 	/* Observability */
 	tracerProvider := ioc.Observability.TracerProvider()
 	defer func() {
-		if err := tracerProvider.Shutdown(ctx); err != nil {
+		if err := tracerProvider.Shutdown(context.Background()); err != nil {
 			log.Fatal(err)
 		}
 	}()
 
 	meterProvider := ioc.Observability.MeterProvider()
 	defer func() {
-		if err := meterProvider.Shutdown(ctx); err != nil {
+		if err := meterProvider.Shutdown(context.Background()); err != nil {
 			log.Fatal(err)
 		}
 	}()
@@ -43,15 +47,19 @@ func (w *worker) Run() {
 		}
 	}()
 
-	/* Order */
-	publishEventHandler := order.RegisterPublishEventHandler(ioc)
+	/* Jobs */
+	scheduler := jobs.NewScheduler(
+		ioc.Observability,
+		jobs.WithLocker(lease.NewLocker(ioc.DB)),
+		jobs.WithLeaseTTL(ioc.Config.WorkerConfig.LeaseTTL),
+		jobs.WithShutdownTimeout(ioc.Config.WorkerConfig.ShutdownTimeout),
+	)
 
-	jobs := cron.New()
-
-	_, err := jobs.AddFunc(ioc.Config.WorkerConfig.CronExpression, publishEventHandler.Handle)
-	if err != nil {
+	/* Order */
+	if err := order.RegisterWorkerModule(ctx, ioc, scheduler); err != nil {
 		log.Fatal(err)
 	}
 
-	jobs.Run()
+	scheduler.Run(ctx)
+	log.Println("Workers have been shut down.")
 }
@@ -2,21 +2,31 @@ package configs
 
 import (
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
 type (
 	Config struct {
-		DBConfig     DBConfig     `mapstructure:",squash"`
-		HTTPConfig   HTTPConfig   `mapstructure:",squash"`
-		O11yConfig   O11yConfig   `mapstructure:",squash"`
-		KafkaConfig  KafkaConfig  `mapstructure:",squash"`
-		WorkerConfig WorkerConfig `mapstructure:",squash"`
+		DBConfig        DBConfig        `mapstructure:",squash"`
+		HTTPConfig      HTTPConfig      `mapstructure:",squash"`
+		O11yConfig      O11yConfig      `mapstructure:",squash"`
+		KafkaConfig     KafkaConfig     `mapstructure:",squash"`
+		WorkerConfig    WorkerConfig    `mapstructure:",squash"`
+		OutboxConfig    OutboxConfig    `mapstructure:",squash"`
+		OrderConfig     OrderConfig     `mapstructure:",squash"`
+		InventoryConfig InventoryConfig `mapstructure:",squash"`
+		CatalogConfig   CatalogConfig   `mapstructure:",squash"`
+		TaxConfig       TaxConfig       `mapstructure:",squash"`
+		FXConfig        FXConfig        `mapstructure:",squash"`
+		ShipmentConfig  ShipmentConfig  `mapstructure:",squash"`
+		ShippingConfig  ShippingConfig  `mapstructure:",squash"`
 	}
 
 	DBConfig struct {
 		Driver         string `mapstructure:"DB_DRIVER"`
+		Engine         string `mapstructure:"DB_ENGINE"`
 		Host           string `mapstructure:"DB_HOST"`
 		Port           string `mapstructure:"DB_PORT"`
 		User           string `mapstructure:"DB_USER"`
@@ -27,7 +37,9 @@ type (
 	}
 
 	HTTPConfig struct {
-		Port string `mapstructure:"HTTP_PORT"`
+		Port         string `mapstructure:"HTTP_PORT"`
+		AdminToken   string `mapstructure:"HTTP_ADMIN_TOKEN"`
+		GatewayToken string `mapstructure:"HTTP_GATEWAY_TOKEN"`
 	}
 
 	O11yConfig struct {
@@ -43,10 +55,68 @@ type (
 		OrderReplicationFactor int      `mapstructure:"KAFKA_ORDER_REPLICATION_FACTOR"`
 		OrderDLQ               string   `mapstructure:"KAFKA_ORDER_DLQ_TOPIC"`
 		OrderGroupID           string   `mapstructure:"KAFKA_ORDER_GROUP_ID"`
+		Payment                string   `mapstructure:"KAFKA_PAYMENT_TOPIC"`
+		Inventory              string   `mapstructure:"KAFKA_INVENTORY_TOPIC"`
 	}
 
 	WorkerConfig struct {
-		CronExpression string `mapstructure:"WORKER_CRON"`
+		CronExpression  string        `mapstructure:"WORKER_CRON"`
+		RelayMode       string        `mapstructure:"WORKER_RELAY_MODE"`
+		PurgeCron       string        `mapstructure:"WORKER_PURGE_CRON"`
+		ExpireCron      string        `mapstructure:"WORKER_EXPIRE_ORDERS_CRON"`
+		SagaTimeoutCron string        `mapstructure:"WORKER_SAGA_TIMEOUT_CRON"`
+		LeaseTTL        time.Duration `mapstructure:"WORKER_LEASE_TTL"`
+		ShutdownTimeout time.Duration `mapstructure:"WORKER_SHUTDOWN_TIMEOUT"`
+	}
+
+	OutboxConfig struct {
+		Retention      time.Duration `mapstructure:"OUTBOX_RETENTION"`
+		PurgeBatchSize int           `mapstructure:"OUTBOX_PURGE_BATCH_SIZE"`
+		Archive        bool          `mapstructure:"OUTBOX_ARCHIVE"`
+	}
+
+	OrderConfig struct {
+		PendingTTL          time.Duration `mapstructure:"ORDER_PENDING_TTL"`
+		ExpirationBatchSize int           `mapstructure:"ORDER_EXPIRATION_BATCH_SIZE"`
+		SagaStepTimeout     time.Duration `mapstructure:"ORDER_SAGA_STEP_TIMEOUT"`
+		DefaultCurrency     string        `mapstructure:"ORDER_DEFAULT_CURRENCY"`
+	}
+
+	InventoryConfig struct {
+		Mode    string        `mapstructure:"INVENTORY_MODE"`
+		URL     string        `mapstructure:"INVENTORY_URL"`
+		Timeout time.Duration `mapstructure:"INVENTORY_TIMEOUT"`
+	}
+
+	CatalogConfig struct {
+		URL      string        `mapstructure:"CATALOG_URL"`
+		Timeout  time.Duration `mapstructure:"CATALOG_TIMEOUT"`
+		CacheTTL time.Duration `mapstructure:"CATALOG_CACHE_TTL"`
+	}
+
+	TaxConfig struct {
+		PricesIncludeTax bool          `mapstructure:"TAX_PRICES_INCLUDE_TAX"`
+		Rounding         string        `mapstructure:"TAX_ROUNDING"`
+		DefaultRegion    string        `mapstructure:"TAX_DEFAULT_REGION"`
+		RulesCacheTTL    time.Duration `mapstructure:"TAX_RULES_CACHE_TTL"`
+	}
+
+	FXConfig struct {
+		Source            string        `mapstructure:"FX_SOURCE"`
+		RatesFile         string        `mapstructure:"FX_RATES_FILE"`
+		ReportingCurrency string        `mapstructure:"FX_REPORTING_CURRENCY"`
+		CacheTTL          time.Duration `mapstructure:"FX_RATES_CACHE_TTL"`
+	}
+
+	ShipmentConfig struct {
+		WebhookToken string `mapstructure:"SHIPMENT_WEBHOOK_TOKEN"`
+	}
+
+	ShippingConfig struct {
+		Source    string        `mapstructure:"SHIPPING_RATES_SOURCE"`
+		RatesFile string        `mapstructure:"SHIPPING_RATES_FILE"`
+		URL       string        `mapstructure:"SHIPPING_RATES_URL"`
+		Timeout   time.Duration `mapstructure:"SHIPPING_RATES_TIMEOUT"`
 	}
 )
 
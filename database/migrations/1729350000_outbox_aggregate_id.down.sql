@@ -0,0 +1 @@
+ALTER TABLE outbox DROP COLUMN IF EXISTS aggregate_id;
\ No newline at end of file
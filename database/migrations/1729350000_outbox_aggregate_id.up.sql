@@ -0,0 +1 @@
+ALTER TABLE outbox ADD COLUMN aggregate_id UUID NULL;
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS relay_checkpoints;
\ No newline at end of file
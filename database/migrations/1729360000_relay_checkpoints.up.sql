@@ -0,0 +1,7 @@
+CREATE TABLE relay_checkpoints (
+    name VARCHAR(100) NOT NULL,
+    position VARCHAR(100) NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT pk_relay_checkpoints PRIMARY KEY (name)
+);
\ No newline at end of file
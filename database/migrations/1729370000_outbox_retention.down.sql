@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS outbox_archive;
+DROP INDEX IF EXISTS idx_outbox_published_at;
+DROP INDEX IF EXISTS idx_outbox_unpublished;
\ No newline at end of file
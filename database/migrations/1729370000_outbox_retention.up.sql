@@ -0,0 +1,15 @@
+CREATE INDEX idx_outbox_unpublished ON outbox (created_at) WHERE was_published = FALSE;
+
+CREATE INDEX idx_outbox_published_at ON outbox (published_at) WHERE was_published = TRUE;
+
+CREATE TABLE outbox_archive (
+    id UUID NOT NULL,
+    aggregate_id UUID NULL,
+    event_name VARCHAR(50) NOT NULL,
+    published_at TIMESTAMP NULL DEFAULT NULL,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE,
+    archived_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT pk_outbox_archive PRIMARY KEY (id)
+);
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE outbox DROP COLUMN IF EXISTS skipped_at;
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS leases;
\ No newline at end of file
@@ -0,0 +1,8 @@
+CREATE TABLE leases (
+    name VARCHAR(100) NOT NULL,
+    holder VARCHAR(255) NOT NULL,
+    token INT8 NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+    CONSTRAINT pk_leases PRIMARY KEY (name)
+);
\ No newline at end of file
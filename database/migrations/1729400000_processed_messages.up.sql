@@ -0,0 +1,7 @@
+CREATE TABLE processed_messages (
+    consumer VARCHAR(100) NOT NULL,
+    message_id VARCHAR(100) NOT NULL,
+    processed_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT pk_processed_messages PRIMARY KEY (consumer, message_id)
+);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS order_sagas;
\ No newline at end of file
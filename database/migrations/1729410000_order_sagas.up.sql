@@ -0,0 +1,18 @@
+CREATE TABLE order_sagas (
+    id UUID NOT NULL,
+    order_id UUID NOT NULL,
+    status VARCHAR(50) NOT NULL,
+    reservation_id VARCHAR(100) NULL,
+    payment_id VARCHAR(100) NULL,
+    failure_reason VARCHAR(255) NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    deadline_at TIMESTAMP WITH TIME ZONE NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT pk_order_sagas PRIMARY KEY (id),
+    CONSTRAINT uq_order_sagas_order_id UNIQUE (order_id),
+    CONSTRAINT fk_order_sagas_orders FOREIGN KEY (order_id) REFERENCES orders(id)
+);
+
+CREATE INDEX idx_order_sagas_deadline_at ON order_sagas (deadline_at) WHERE deadline_at IS NOT NULL;
\ No newline at end of file
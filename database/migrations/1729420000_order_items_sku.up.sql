@@ -0,0 +1,3 @@
+ALTER TABLE order_items ADD COLUMN sku VARCHAR(100) NULL;
+
+ALTER TABLE order_items ALTER COLUMN product_name TYPE VARCHAR(255);
\ No newline at end of file
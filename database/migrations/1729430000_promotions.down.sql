@@ -0,0 +1,8 @@
+ALTER TABLE orders DROP COLUMN IF EXISTS total;
+ALTER TABLE orders DROP COLUMN IF EXISTS discount_total;
+ALTER TABLE orders DROP COLUMN IF EXISTS subtotal;
+ALTER TABLE orders DROP COLUMN IF EXISTS customer_id;
+ALTER TABLE order_items DROP COLUMN IF EXISTS discount;
+DROP TABLE IF EXISTS order_discounts;
+DROP TABLE IF EXISTS coupon_redemptions;
+DROP TABLE IF EXISTS coupons;
\ No newline at end of file
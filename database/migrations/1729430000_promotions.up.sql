@@ -0,0 +1,59 @@
+CREATE TABLE coupons (
+    id UUID NOT NULL,
+    code VARCHAR(50) NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    value NUMERIC(10, 2) NOT NULL DEFAULT 0,
+    sku VARCHAR(100) NULL,
+    buy_quantity INT NOT NULL DEFAULT 0,
+    get_quantity INT NOT NULL DEFAULT 0,
+    minimum_spend NUMERIC(10, 2) NOT NULL DEFAULT 0,
+    max_uses INT NOT NULL DEFAULT 0,
+    max_uses_per_customer INT NOT NULL DEFAULT 0,
+    uses INT NOT NULL DEFAULT 0,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ends_at TIMESTAMP WITH TIME ZONE NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT pk_coupons PRIMARY KEY (id),
+    CONSTRAINT uq_coupons_code UNIQUE (code)
+);
+
+CREATE TABLE coupon_redemptions (
+    coupon_id UUID NOT NULL,
+    order_id UUID NOT NULL,
+    customer_id VARCHAR(100) NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT pk_coupon_redemptions PRIMARY KEY (coupon_id, order_id),
+    CONSTRAINT fk_coupon_redemptions_coupons FOREIGN KEY (coupon_id) REFERENCES coupons(id),
+    CONSTRAINT fk_coupon_redemptions_orders FOREIGN KEY (order_id) REFERENCES orders(id)
+);
+
+CREATE INDEX idx_coupon_redemptions_customer ON coupon_redemptions (coupon_id, customer_id);
+
+CREATE TABLE order_discounts (
+    id UUID NOT NULL,
+    order_id UUID NOT NULL,
+    order_item_id UUID NOT NULL,
+    coupon_id UUID NOT NULL,
+    code VARCHAR(50) NOT NULL,
+    amount NUMERIC(10, 2) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT pk_order_discounts PRIMARY KEY (id),
+    CONSTRAINT fk_order_discounts_orders FOREIGN KEY (order_id) REFERENCES orders(id),
+    CONSTRAINT fk_order_discounts_order_items FOREIGN KEY (order_item_id) REFERENCES order_items(id),
+    CONSTRAINT fk_order_discounts_coupons FOREIGN KEY (coupon_id) REFERENCES coupons(id)
+);
+
+ALTER TABLE order_items ADD COLUMN discount NUMERIC(10, 2) NOT NULL DEFAULT 0;
+
+ALTER TABLE orders ADD COLUMN customer_id VARCHAR(100) NULL;
+
+ALTER TABLE orders ADD COLUMN subtotal NUMERIC(10, 2) NOT NULL DEFAULT 0;
+
+ALTER TABLE orders ADD COLUMN discount_total NUMERIC(10, 2) NOT NULL DEFAULT 0;
+
+ALTER TABLE orders ADD COLUMN total NUMERIC(10, 2) NOT NULL DEFAULT 0;
\ No newline at end of file
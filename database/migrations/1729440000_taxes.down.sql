@@ -0,0 +1,7 @@
+ALTER TABLE orders DROP COLUMN IF EXISTS tax_total;
+ALTER TABLE orders DROP COLUMN IF EXISTS tax_inclusive;
+ALTER TABLE orders DROP COLUMN IF EXISTS tax_region;
+ALTER TABLE order_items DROP COLUMN IF EXISTS tax;
+ALTER TABLE order_items DROP COLUMN IF EXISTS tax_category;
+DROP TABLE IF EXISTS order_item_taxes;
+DROP TABLE IF EXISTS tax_rules;
\ No newline at end of file
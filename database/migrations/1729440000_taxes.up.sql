@@ -0,0 +1,37 @@
+CREATE TABLE tax_rules (
+    id UUID NOT NULL DEFAULT gen_random_uuid(),
+    region VARCHAR(20) NOT NULL,
+    jurisdiction VARCHAR(50) NOT NULL,
+    category VARCHAR(50) NOT NULL DEFAULT '',
+    rate NUMERIC(7, 4) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT pk_tax_rules PRIMARY KEY (id),
+    CONSTRAINT uq_tax_rules UNIQUE (region, jurisdiction, category)
+);
+
+CREATE TABLE order_item_taxes (
+    id UUID NOT NULL,
+    order_id UUID NOT NULL,
+    order_item_id UUID NOT NULL,
+    jurisdiction VARCHAR(50) NOT NULL,
+    rate NUMERIC(7, 4) NOT NULL,
+    amount NUMERIC(10, 2) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT pk_order_item_taxes PRIMARY KEY (id),
+    CONSTRAINT fk_order_item_taxes_orders FOREIGN KEY (order_id) REFERENCES orders(id),
+    CONSTRAINT fk_order_item_taxes_order_items FOREIGN KEY (order_item_id) REFERENCES order_items(id)
+);
+
+CREATE INDEX idx_order_item_taxes_order_id ON order_item_taxes (order_id);
+
+ALTER TABLE order_items ADD COLUMN tax_category VARCHAR(50) NULL;
+
+ALTER TABLE order_items ADD COLUMN tax NUMERIC(10, 2) NOT NULL DEFAULT 0;
+
+ALTER TABLE orders ADD COLUMN tax_region VARCHAR(20) NULL;
+
+ALTER TABLE orders ADD COLUMN tax_inclusive BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE orders ADD COLUMN tax_total NUMERIC(10, 2) NOT NULL DEFAULT 0;
\ No newline at end of file
@@ -0,0 +1,18 @@
+CREATE TABLE order_addresses (
+    order_id UUID NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    street VARCHAR(200) NOT NULL,
+    number VARCHAR(20) NULL,
+    complement VARCHAR(100) NULL,
+    district VARCHAR(100) NULL,
+    city VARCHAR(100) NOT NULL,
+    state VARCHAR(50) NULL,
+    postal_code VARCHAR(20) NOT NULL,
+    country CHAR(2) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT pk_order_addresses PRIMARY KEY (order_id, kind),
+    CONSTRAINT fk_order_addresses_orders FOREIGN KEY (order_id) REFERENCES orders(id)
+);
+
+CREATE INDEX idx_orders_customer_id ON orders (customer_id, created_at DESC);
\ No newline at end of file
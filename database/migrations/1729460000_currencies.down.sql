@@ -0,0 +1,6 @@
+ALTER TABLE orders DROP COLUMN IF EXISTS reporting_total;
+ALTER TABLE orders DROP COLUMN IF EXISTS exchange_rate_at;
+ALTER TABLE orders DROP COLUMN IF EXISTS exchange_rate;
+ALTER TABLE orders DROP COLUMN IF EXISTS reporting_currency;
+ALTER TABLE orders DROP COLUMN IF EXISTS currency;
+DROP TABLE IF EXISTS exchange_rates;
\ No newline at end of file
@@ -0,0 +1,23 @@
+CREATE TABLE exchange_rates (
+    id UUID NOT NULL DEFAULT gen_random_uuid(),
+    base CHAR(3) NOT NULL,
+    quote CHAR(3) NOT NULL,
+    rate NUMERIC(18, 8) NOT NULL,
+    effective_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT pk_exchange_rates PRIMARY KEY (id),
+    CONSTRAINT uq_exchange_rates UNIQUE (base, quote, effective_at)
+);
+
+ALTER TABLE orders ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'BRL';
+
+ALTER TABLE orders ADD COLUMN reporting_currency CHAR(3) NULL;
+
+ALTER TABLE orders ADD COLUMN exchange_rate NUMERIC(18, 8) NOT NULL DEFAULT 1;
+
+ALTER TABLE orders ADD COLUMN exchange_rate_at TIMESTAMP WITH TIME ZONE NULL;
+
+ALTER TABLE orders ADD COLUMN reporting_total NUMERIC(10, 2) NOT NULL DEFAULT 0;
+
+UPDATE orders SET reporting_currency = currency, reporting_total = total WHERE reporting_currency IS NULL;
\ No newline at end of file
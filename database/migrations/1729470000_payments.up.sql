@@ -0,0 +1,17 @@
+CREATE TABLE payments (
+    id UUID NOT NULL,
+    order_id UUID NOT NULL,
+    amount NUMERIC(10, 2) NOT NULL,
+    currency CHAR(3) NOT NULL,
+    method VARCHAR(30) NOT NULL,
+    provider_reference VARCHAR(100) NULL,
+    status VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NULL,
+
+    CONSTRAINT pk_payments PRIMARY KEY (id),
+    CONSTRAINT fk_payments_orders FOREIGN KEY (order_id) REFERENCES orders(id),
+    CONSTRAINT uq_payments_provider_reference UNIQUE (order_id, provider_reference)
+);
+
+CREATE INDEX idx_payments_order_id ON payments (order_id, created_at);
\ No newline at end of file
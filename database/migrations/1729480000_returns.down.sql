@@ -0,0 +1,4 @@
+ALTER TABLE payments DROP COLUMN IF EXISTS refunded_amount;
+DROP TABLE IF EXISTS refunds;
+DROP TABLE IF EXISTS order_return_lines;
+DROP TABLE IF EXISTS order_returns;
\ No newline at end of file
@@ -0,0 +1,48 @@
+CREATE TABLE order_returns (
+    id UUID NOT NULL,
+    order_id UUID NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    reason VARCHAR(255) NULL,
+    rejection_reason VARCHAR(255) NULL,
+    amount NUMERIC(10, 2) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NULL,
+
+    CONSTRAINT pk_order_returns PRIMARY KEY (id),
+    CONSTRAINT fk_order_returns_orders FOREIGN KEY (order_id) REFERENCES orders(id)
+);
+
+CREATE INDEX idx_order_returns_order_id ON order_returns (order_id, created_at);
+
+CREATE TABLE order_return_lines (
+    id UUID NOT NULL,
+    return_id UUID NOT NULL,
+    order_item_id UUID NOT NULL,
+    quantity INT NOT NULL,
+    amount NUMERIC(10, 2) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT pk_order_return_lines PRIMARY KEY (id),
+    CONSTRAINT fk_order_return_lines_order_returns FOREIGN KEY (return_id) REFERENCES order_returns(id),
+    CONSTRAINT fk_order_return_lines_order_items FOREIGN KEY (order_item_id) REFERENCES order_items(id)
+);
+
+CREATE INDEX idx_order_return_lines_return_id ON order_return_lines (return_id);
+
+CREATE TABLE refunds (
+    id UUID NOT NULL,
+    order_id UUID NOT NULL,
+    payment_id UUID NOT NULL,
+    return_id UUID NULL,
+    amount NUMERIC(10, 2) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT pk_refunds PRIMARY KEY (id),
+    CONSTRAINT fk_refunds_orders FOREIGN KEY (order_id) REFERENCES orders(id),
+    CONSTRAINT fk_refunds_payments FOREIGN KEY (payment_id) REFERENCES payments(id),
+    CONSTRAINT fk_refunds_order_returns FOREIGN KEY (return_id) REFERENCES order_returns(id)
+);
+
+CREATE INDEX idx_refunds_return_id ON refunds (return_id);
+
+ALTER TABLE payments ADD COLUMN refunded_amount NUMERIC(10, 2) NOT NULL DEFAULT 0;
\ No newline at end of file
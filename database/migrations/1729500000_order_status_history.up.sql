@@ -0,0 +1,18 @@
+CREATE TABLE order_status_history (
+    id UUID NOT NULL DEFAULT gen_random_uuid(),
+    order_id UUID NOT NULL,
+    from_status VARCHAR(50) NULL,
+    to_status VARCHAR(50) NOT NULL,
+    actor VARCHAR(100) NOT NULL,
+    reason VARCHAR(255) NULL,
+    request_id VARCHAR(100) NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT pk_order_status_history PRIMARY KEY (id),
+    CONSTRAINT fk_order_status_history_orders FOREIGN KEY (order_id) REFERENCES orders(id)
+);
+
+CREATE INDEX idx_order_status_history_order_id ON order_status_history (order_id, created_at);
+
+INSERT INTO order_status_history (order_id, to_status, actor, reason, created_at)
+SELECT id, status, 'system', 'history backfill', COALESCE(updated_at, created_at) FROM orders;
\ No newline at end of file
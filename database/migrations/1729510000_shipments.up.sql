@@ -0,0 +1,31 @@
+CREATE TABLE shipments (
+    id UUID NOT NULL,
+    order_id UUID NOT NULL,
+    carrier VARCHAR(50) NOT NULL,
+    tracking_number VARCHAR(100) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    status_description VARCHAR(255) NULL,
+    delivered_at TIMESTAMP WITH TIME ZONE NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NULL,
+
+    CONSTRAINT pk_shipments PRIMARY KEY (id),
+    CONSTRAINT fk_shipments_orders FOREIGN KEY (order_id) REFERENCES orders(id),
+    CONSTRAINT uq_shipments_tracking UNIQUE (carrier, tracking_number)
+);
+
+CREATE INDEX idx_shipments_order_id ON shipments (order_id, created_at);
+
+CREATE TABLE shipment_lines (
+    id UUID NOT NULL,
+    shipment_id UUID NOT NULL,
+    order_item_id UUID NOT NULL,
+    quantity INT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT pk_shipment_lines PRIMARY KEY (id),
+    CONSTRAINT fk_shipment_lines_shipments FOREIGN KEY (shipment_id) REFERENCES shipments(id),
+    CONSTRAINT fk_shipment_lines_order_items FOREIGN KEY (order_item_id) REFERENCES order_items(id)
+);
+
+CREATE INDEX idx_shipment_lines_shipment_id ON shipment_lines (shipment_id);
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE orders DROP COLUMN IF EXISTS shipping_amount;
+ALTER TABLE orders DROP COLUMN IF EXISTS shipping_carrier;
+ALTER TABLE orders DROP COLUMN IF EXISTS shipping_method;
\ No newline at end of file
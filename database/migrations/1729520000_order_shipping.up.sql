@@ -0,0 +1,5 @@
+ALTER TABLE orders ADD COLUMN shipping_method VARCHAR(50) NULL;
+
+ALTER TABLE orders ADD COLUMN shipping_carrier VARCHAR(100) NULL;
+
+ALTER TABLE orders ADD COLUMN shipping_amount NUMERIC(10, 2) NOT NULL DEFAULT 0;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE outbox_archive DROP COLUMN IF EXISTS skipped_at;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE outbox_archive ADD COLUMN skipped_at TIMESTAMP NULL DEFAULT NULL;
\ No newline at end of file
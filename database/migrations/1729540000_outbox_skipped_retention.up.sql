@@ -0,0 +1 @@
+CREATE INDEX idx_outbox_skipped_at ON outbox (skipped_at) WHERE skipped_at IS NOT NULL;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE order_sagas DROP COLUMN IF EXISTS step;
\ No newline at end of file
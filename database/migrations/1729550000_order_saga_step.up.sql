@@ -0,0 +1 @@
+ALTER TABLE order_sagas ADD COLUMN step INT NOT NULL DEFAULT 0;
\ No newline at end of file
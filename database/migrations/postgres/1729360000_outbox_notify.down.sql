@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS trg_outbox_notify ON outbox;
+DROP FUNCTION IF EXISTS notify_outbox_insert();
\ No newline at end of file
@@ -0,0 +1,10 @@
+CREATE OR REPLACE FUNCTION notify_outbox_insert() RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM pg_notify('outbox_inserted', NEW.id::TEXT);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_outbox_notify
+    AFTER INSERT ON outbox
+    FOR EACH ROW EXECUTE FUNCTION notify_outbox_insert();
\ No newline at end of file
@@ -0,0 +1,36 @@
+package dtos
+
+import "time"
+
+type (
+	CouponInput struct {
+		Code               string     `json:"code"`
+		Kind               string     `json:"kind"`
+		Value              float64    `json:"value"`
+		SKU                string     `json:"sku"`
+		BuyQuantity        uint       `json:"buy_quantity"`
+		GetQuantity        uint       `json:"get_quantity"`
+		MinimumSpend       float64    `json:"minimum_spend"`
+		MaxUses            int        `json:"max_uses"`
+		MaxUsesPerCustomer int        `json:"max_uses_per_customer"`
+		StartsAt           *time.Time `json:"starts_at"`
+		EndsAt             *time.Time `json:"ends_at"`
+	}
+
+	CouponOutput struct {
+		ID                 string     `json:"id"`
+		Code               string     `json:"code"`
+		Kind               string     `json:"kind"`
+		Value              float64    `json:"value"`
+		SKU                string     `json:"sku,omitempty"`
+		BuyQuantity        uint       `json:"buy_quantity,omitempty"`
+		GetQuantity        uint       `json:"get_quantity,omitempty"`
+		MinimumSpend       float64    `json:"minimum_spend"`
+		MaxUses            int        `json:"max_uses"`
+		MaxUsesPerCustomer int        `json:"max_uses_per_customer"`
+		Uses               int        `json:"uses"`
+		Active             bool       `json:"active"`
+		StartsAt           time.Time  `json:"starts_at"`
+		EndsAt             *time.Time `json:"ends_at,omitempty"`
+	}
+)
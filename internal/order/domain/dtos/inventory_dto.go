@@ -0,0 +1,9 @@
+package dtos
+
+type InventoryEventInput struct {
+	EventName     string `json:"event_name"`
+	OrderID       string `json:"order_id"`
+	Step          int    `json:"step"`
+	ReservationID string `json:"reservation_id"`
+	Reason        string `json:"reason"`
+}
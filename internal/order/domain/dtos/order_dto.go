@@ -1,25 +1,130 @@
 package dtos
 
+import "time"
+
 type (
 	OrderInput struct {
-		Items []*OrderItemInput `json:"items"`
+		CouponCode      string            `json:"coupon_code"`
+		Currency        string            `json:"currency"`
+		Region          string            `json:"region"`
+		ShippingAddress *Address          `json:"shipping_address"`
+		BillingAddress  *Address          `json:"billing_address"`
+		ShippingOption  string            `json:"shipping_option"`
+		Items           []*OrderItemInput `json:"items"`
+	}
+
+	Address struct {
+		Street     string `json:"street"`
+		Number     string `json:"number,omitempty"`
+		Complement string `json:"complement,omitempty"`
+		District   string `json:"district,omitempty"`
+		City       string `json:"city"`
+		State      string `json:"state,omitempty"`
+		PostalCode string `json:"postal_code"`
+		Country    string `json:"country"`
+	}
+
+	OrderFilterInput struct {
+		CustomerID string `json:"customer_id"`
+		Status     string `json:"status"`
+		Limit      int    `json:"limit"`
 	}
 
 	OrderItemInput struct {
-		ProductName string  `json:"product_name"`
-		Price       float64 `json:"price"`
-		Quantity    uint    `json:"quantity"`
+		SKU      string `json:"sku"`
+		Quantity uint   `json:"quantity"`
+	}
+
+	OrderItemQuantityInput struct {
+		Quantity uint `json:"quantity"`
 	}
 
 	OrderOutput struct {
-		ID     string `json:"id"`
-		Status string `json:"status"`
+		ID       string  `json:"id"`
+		Status   string  `json:"status"`
+		Currency string  `json:"currency,omitempty"`
+		Subtotal float64 `json:"subtotal,omitempty"`
+		Discount float64 `json:"discount,omitempty"`
+		Tax      float64 `json:"tax,omitempty"`
+		Shipping float64 `json:"shipping,omitempty"`
+		Total    float64 `json:"total,omitempty"`
+		Version  int     `json:"version"`
+	}
+
+	OrderDetailOutput struct {
+		ID              string             `json:"id"`
+		CustomerID      string             `json:"customer_id,omitempty"`
+		Status          string             `json:"status"`
+		Currency        string             `json:"currency"`
+		ShippingAddress *Address           `json:"shipping_address,omitempty"`
+		BillingAddress  *Address           `json:"billing_address,omitempty"`
+		TaxRegion       string             `json:"tax_region,omitempty"`
+		TaxInclusive    bool               `json:"tax_inclusive"`
+		Subtotal        float64            `json:"subtotal"`
+		Discount        float64            `json:"discount"`
+		Tax             float64            `json:"tax"`
+		ShippingMethod  string             `json:"shipping_method,omitempty"`
+		ShippingCarrier string             `json:"shipping_carrier,omitempty"`
+		Shipping        float64            `json:"shipping"`
+		Total           float64            `json:"total"`
+		Reporting       *ReportingOutput   `json:"reporting,omitempty"`
+		PaidAmount      float64            `json:"paid_amount"`
+		Balance         float64            `json:"balance"`
+		Payments        []*PaymentOutput   `json:"payments,omitempty"`
+		Items           []*OrderItemOutput `json:"items"`
+		Version         int                `json:"version"`
+		CreatedAt       time.Time          `json:"created_at"`
+	}
+
+	OrderStatusChangeOutput struct {
+		From      string    `json:"from,omitempty"`
+		To        string    `json:"to"`
+		Actor     string    `json:"actor"`
+		Reason    string    `json:"reason,omitempty"`
+		RequestID string    `json:"request_id,omitempty"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+
+	ReportingOutput struct {
+		Currency       string    `json:"currency"`
+		ExchangeRate   float64   `json:"exchange_rate"`
+		ExchangeRateAt time.Time `json:"exchange_rate_at"`
+		Total          float64   `json:"total"`
+	}
+
+	OrderItemOutput struct {
+		ID          string                `json:"id"`
+		SKU         string                `json:"sku,omitempty"`
+		ProductName string                `json:"product_name"`
+		Price       float64               `json:"price"`
+		Quantity    uint                  `json:"quantity"`
+		Discount    float64               `json:"discount"`
+		TaxCategory string                `json:"tax_category,omitempty"`
+		Tax         float64               `json:"tax"`
+		Taxes       []*OrderItemTaxOutput `json:"taxes,omitempty"`
+	}
+
+	OrderItemTaxOutput struct {
+		Jurisdiction string  `json:"jurisdiction"`
+		Rate         float64 `json:"rate"`
+		Amount       float64 `json:"amount"`
 	}
 )
 
-func NewOrderOutput(id string, status string) *OrderOutput {
+func NewOrderOutput(id string, status string, version int) *OrderOutput {
 	return &OrderOutput{
-		ID:     id,
-		Status: status,
+		ID:      id,
+		Status:  status,
+		Version: version,
 	}
 }
+
+func (o *OrderOutput) WithTotals(currency string, subtotal, discount, tax, shipping, total float64) *OrderOutput {
+	o.Currency = currency
+	o.Subtotal = subtotal
+	o.Discount = discount
+	o.Tax = tax
+	o.Shipping = shipping
+	o.Total = total
+	return o
+}
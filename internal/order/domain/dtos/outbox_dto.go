@@ -0,0 +1,36 @@
+package dtos
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type (
+	OutboxFilterInput struct {
+		Status    string    `json:"status"`
+		EventName string    `json:"event_name"`
+		Since     time.Time `json:"since"`
+		Limit     int       `json:"limit"`
+	}
+
+	OutboxOutput struct {
+		ID           string          `json:"id"`
+		AggregateID  string          `json:"aggregate_id,omitempty"`
+		EventName    string          `json:"event_name"`
+		WasPublished bool            `json:"was_published"`
+		PublishedAt  *time.Time      `json:"published_at,omitempty"`
+		SkippedAt    *time.Time      `json:"skipped_at,omitempty"`
+		Payload      json.RawMessage `json:"payload"`
+		CreatedAt    time.Time       `json:"created_at"`
+	}
+
+	OutboxReplayOutput struct {
+		Replayed int `json:"replayed"`
+	}
+
+	OutboxLagOutput struct {
+		Pending       int64      `json:"pending"`
+		OldestPending *time.Time `json:"oldest_pending,omitempty"`
+		LagSeconds    float64    `json:"lag_seconds"`
+	}
+)
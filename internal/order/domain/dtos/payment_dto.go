@@ -0,0 +1,40 @@
+package dtos
+
+import "time"
+
+type (
+	PaymentEventInput struct {
+		EventName string  `json:"event_name"`
+		PaymentID string  `json:"payment_id"`
+		OrderID   string  `json:"order_id"`
+		Step      int     `json:"step"`
+		Amount    float64 `json:"amount"`
+		Reason    string  `json:"reason"`
+	}
+
+	PaymentInput struct {
+		Amount            float64 `json:"amount"`
+		Currency          string  `json:"currency"`
+		Method            string  `json:"method"`
+		ProviderReference string  `json:"provider_reference"`
+		Status            string  `json:"status"`
+	}
+
+	PaymentOutput struct {
+		ID                string    `json:"id"`
+		Amount            float64   `json:"amount"`
+		Currency          string    `json:"currency"`
+		Method            string    `json:"method"`
+		ProviderReference string    `json:"provider_reference,omitempty"`
+		Status            string    `json:"status"`
+		CreatedAt         time.Time `json:"created_at"`
+	}
+
+	OrderPaymentOutput struct {
+		OrderID     string         `json:"order_id"`
+		OrderStatus string         `json:"order_status"`
+		PaidAmount  float64        `json:"paid_amount"`
+		Balance     float64        `json:"balance"`
+		Payment     *PaymentOutput `json:"payment"`
+	}
+)
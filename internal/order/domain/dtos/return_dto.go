@@ -0,0 +1,44 @@
+package dtos
+
+import "time"
+
+type (
+	ReturnInput struct {
+		Reason string             `json:"reason"`
+		Lines  []*ReturnLineInput `json:"lines"`
+	}
+
+	ReturnLineInput struct {
+		OrderItemID string `json:"order_item_id"`
+		Quantity    uint   `json:"quantity"`
+	}
+
+	RejectReturnInput struct {
+		Reason string `json:"reason"`
+	}
+
+	ReturnOutput struct {
+		ID              string              `json:"id"`
+		OrderID         string              `json:"order_id"`
+		Status          string              `json:"status"`
+		Reason          string              `json:"reason,omitempty"`
+		RejectionReason string              `json:"rejection_reason,omitempty"`
+		Amount          float64             `json:"amount"`
+		Lines           []*ReturnLineOutput `json:"lines"`
+		Refunds         []*RefundOutput     `json:"refunds,omitempty"`
+		CreatedAt       time.Time           `json:"created_at"`
+	}
+
+	ReturnLineOutput struct {
+		OrderItemID string  `json:"order_item_id"`
+		Quantity    uint    `json:"quantity"`
+		Amount      float64 `json:"amount"`
+	}
+
+	RefundOutput struct {
+		ID        string    `json:"id"`
+		PaymentID string    `json:"payment_id"`
+		Amount    float64   `json:"amount"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+)
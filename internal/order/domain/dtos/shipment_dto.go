@@ -0,0 +1,41 @@
+package dtos
+
+import "time"
+
+type (
+	ShipmentInput struct {
+		Carrier        string               `json:"carrier"`
+		TrackingNumber string               `json:"tracking_number"`
+		Lines          []*ShipmentLineInput `json:"lines"`
+	}
+
+	ShipmentLineInput struct {
+		OrderItemID string `json:"order_item_id"`
+		Quantity    uint   `json:"quantity"`
+	}
+
+	CarrierUpdateInput struct {
+		Carrier        string     `json:"carrier"`
+		TrackingNumber string     `json:"tracking_number"`
+		Status         string     `json:"status"`
+		Description    string     `json:"description"`
+		OccurredAt     *time.Time `json:"occurred_at"`
+	}
+
+	ShipmentOutput struct {
+		ID                string                `json:"id"`
+		OrderID           string                `json:"order_id"`
+		Carrier           string                `json:"carrier"`
+		TrackingNumber    string                `json:"tracking_number"`
+		Status            string                `json:"status"`
+		StatusDescription string                `json:"status_description,omitempty"`
+		Lines             []*ShipmentLineOutput `json:"lines"`
+		DeliveredAt       *time.Time            `json:"delivered_at,omitempty"`
+		CreatedAt         time.Time             `json:"created_at"`
+	}
+
+	ShipmentLineOutput struct {
+		OrderItemID string `json:"order_item_id"`
+		Quantity    uint   `json:"quantity"`
+	}
+)
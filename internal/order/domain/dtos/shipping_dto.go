@@ -0,0 +1,18 @@
+package dtos
+
+type (
+	ShippingQuoteInput struct {
+		Currency        string            `json:"currency"`
+		ShippingAddress *Address          `json:"shipping_address"`
+		Items           []*OrderItemInput `json:"items"`
+	}
+
+	ShippingOptionOutput struct {
+		Code          string  `json:"code"`
+		Carrier       string  `json:"carrier"`
+		Service       string  `json:"service,omitempty"`
+		Amount        float64 `json:"amount"`
+		Currency      string  `json:"currency"`
+		EstimatedDays int     `json:"estimated_days,omitempty"`
+	}
+)
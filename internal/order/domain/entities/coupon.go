@@ -0,0 +1,204 @@
+package entities
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/vos"
+	"github.com/jailtonjunior94/order/pkg/entity"
+	sharedVos "github.com/jailtonjunior94/order/pkg/vos"
+)
+
+var (
+	ErrInvalidCoupon              = errors.New("invalid coupon")
+	ErrCouponInactive             = errors.New("coupon is not active")
+	ErrCouponNotStarted           = errors.New("coupon is not valid yet")
+	ErrCouponExpired              = errors.New("coupon has expired")
+	ErrCouponUsageLimitReached    = errors.New("coupon usage limit reached")
+	ErrCouponCustomerRequired     = errors.New("coupon requires a customer")
+	ErrCouponCustomerLimitReached = errors.New("coupon usage limit reached for customer")
+	ErrCouponMinimumSpendNotMet   = errors.New("order does not reach the coupon minimum spend")
+	ErrCouponNotApplicable        = errors.New("coupon does not apply to any order item")
+)
+
+type Coupon struct {
+	entity.Base
+	Code               string
+	Kind               vos.CouponKind
+	Value              float64
+	SKU                string
+	BuyQuantity        uint
+	GetQuantity        uint
+	MinimumSpend       float64
+	MaxUses            int
+	MaxUsesPerCustomer int
+	Uses               int
+	Active             bool
+	StartsAt           time.Time
+	EndsAt             sharedVos.NullableTime
+}
+
+func NewCoupon(code string, kind vos.CouponKind, value float64, startsAt time.Time) *Coupon {
+	return &Coupon{
+		Code:     NormalizeCouponCode(code),
+		Kind:     kind,
+		Value:    value,
+		Active:   true,
+		StartsAt: startsAt,
+		Base: entity.Base{
+			CreatedAt: time.Now().UTC(),
+		},
+	}
+}
+
+func NormalizeCouponCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func (c *Coupon) Validate() error {
+	if c.Code == "" || !c.Kind.IsValid() || c.MinimumSpend < 0 || c.MaxUses < 0 || c.MaxUsesPerCustomer < 0 {
+		return ErrInvalidCoupon
+	}
+
+	if c.EndsAt.Time != nil && !c.EndsAt.Time.After(c.StartsAt) {
+		return ErrInvalidCoupon
+	}
+
+	switch c.Kind {
+	case vos.CouponPercentage:
+		if c.Value <= 0 || c.Value > 100 {
+			return ErrInvalidCoupon
+		}
+	case vos.CouponFixed:
+		if c.Value <= 0 {
+			return ErrInvalidCoupon
+		}
+	case vos.CouponBuyXGetY:
+		if c.SKU == "" || c.BuyQuantity == 0 || c.GetQuantity == 0 {
+			return ErrInvalidCoupon
+		}
+	}
+	return nil
+}
+
+func (c *Coupon) Deactivate() {
+	c.Active = false
+	c.UpdatedAt = sharedVos.NewNullableTime(time.Now().UTC())
+}
+
+// Discounts checks every rule against order and returns one discount per
+// affected line, without ids. customerID is the authenticated customer placing
+// the order and customerUses how many times they already redeemed the coupon.
+func (c *Coupon) Discounts(order *Order, customerID string, customerUses int, now time.Time) ([]*OrderDiscount, error) {
+	if err := c.checkAvailability(order, customerID, customerUses, now); err != nil {
+		return nil, err
+	}
+
+	discounts := c.discounts(order)
+	if len(discounts) == 0 {
+		return nil, ErrCouponNotApplicable
+	}
+	return discounts, nil
+}
+
+// Rediscount prices an amended order that already redeemed the coupon. Usage
+// limits and validity were settled at redemption; only the minimum spend is
+// checked again, and an order that no longer qualifies gets no discount.
+func (c *Coupon) Rediscount(order *Order) []*OrderDiscount {
+	if order.Subtotal() < c.MinimumSpend {
+		return nil
+	}
+	return c.discounts(order)
+}
+
+func (c *Coupon) Redeem() {
+	c.Uses++
+	c.UpdatedAt = sharedVos.NewNullableTime(time.Now().UTC())
+}
+
+// Return gives back the use taken by an order that was canceled.
+func (c *Coupon) Return() {
+	if c.Uses > 0 {
+		c.Uses--
+	}
+	c.UpdatedAt = sharedVos.NewNullableTime(time.Now().UTC())
+}
+
+func (c *Coupon) checkAvailability(order *Order, customerID string, customerUses int, now time.Time) error {
+	switch {
+	case !c.Active:
+		return ErrCouponInactive
+	case now.Before(c.StartsAt):
+		return ErrCouponNotStarted
+	case c.EndsAt.Time != nil && !now.Before(*c.EndsAt.Time):
+		return ErrCouponExpired
+	case c.MaxUses > 0 && c.Uses >= c.MaxUses:
+		return ErrCouponUsageLimitReached
+	case c.MaxUsesPerCustomer > 0 && customerID == "":
+		return ErrCouponCustomerRequired
+	case c.MaxUsesPerCustomer > 0 && customerUses >= c.MaxUsesPerCustomer:
+		return ErrCouponCustomerLimitReached
+	case order.Subtotal() < c.MinimumSpend:
+		return ErrCouponMinimumSpendNotMet
+	}
+	return nil
+}
+
+func (c *Coupon) discounts(order *Order) []*OrderDiscount {
+	var eligible []*OrderItem
+	for _, item := range order.Items {
+		if c.SKU == "" || item.SKU == c.SKU {
+			eligible = append(eligible, item)
+		}
+	}
+
+	amounts := c.lineAmounts(eligible)
+
+	var discounts []*OrderDiscount
+	for i, item := range eligible {
+		if amounts[i] <= 0 {
+			continue
+		}
+		discounts = append(discounts, NewOrderDiscount(order.ID, item.ID, c.ID, c.Code, amounts[i]))
+	}
+	return discounts
+}
+
+func (c *Coupon) lineAmounts(items []*OrderItem) []float64 {
+	amounts := make([]float64, len(items))
+	switch c.Kind {
+	case vos.CouponPercentage:
+		for i, item := range items {
+			amounts[i] = roundMoney(item.Total() * c.Value / 100)
+		}
+	case vos.CouponFixed:
+		var eligibleTotal float64
+		for _, item := range items {
+			eligibleTotal += item.Total()
+		}
+
+		if eligibleTotal <= 0 {
+			return amounts
+		}
+
+		// Spread the fixed amount proportionally and give the rounding
+		// remainder to the last line so the parts add up exactly.
+		remaining := roundMoney(min(c.Value, eligibleTotal))
+		for i, item := range items {
+			if i == len(items)-1 {
+				amounts[i] = remaining
+				break
+			}
+			amounts[i] = roundMoney(remaining * item.Total() / eligibleTotal)
+			eligibleTotal -= item.Total()
+			remaining = roundMoney(remaining - amounts[i])
+		}
+	case vos.CouponBuyXGetY:
+		for i, item := range items {
+			free := item.Quantity / (c.BuyQuantity + c.GetQuantity) * c.GetQuantity
+			amounts[i] = roundMoney(min(float64(free)*item.Price, item.Total()))
+		}
+	}
+	return amounts
+}
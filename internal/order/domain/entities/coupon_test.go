@@ -0,0 +1,129 @@
+package entities
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/vos"
+	sharedVos "github.com/jailtonjunior94/order/pkg/vos"
+)
+
+func TestCouponDiscounts(t *testing.T) {
+	now := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+	ended := sharedVos.NewNullableTime(now.Add(-time.Hour))
+
+	tests := []struct {
+		name         string
+		coupon       *Coupon
+		customerID   string
+		customerUses int
+		expected     []float64
+		expectedErr  error
+	}{
+		{
+			name:     "percentage on every line",
+			coupon:   &Coupon{Code: "TEN", Kind: vos.CouponPercentage, Value: 10, Active: true},
+			expected: []float64{20, 5},
+		},
+		{
+			name:     "percentage on one sku",
+			coupon:   &Coupon{Code: "MOUSE", Kind: vos.CouponPercentage, Value: 50, SKU: "SKU-2", Active: true},
+			expected: []float64{25},
+		},
+		{
+			name:     "fixed amount spread over lines",
+			coupon:   &Coupon{Code: "FIXED", Kind: vos.CouponFixed, Value: 25, Active: true},
+			expected: []float64{20, 5},
+		},
+		{
+			name:     "buy one get one",
+			coupon:   &Coupon{Code: "BOGO", Kind: vos.CouponBuyXGetY, SKU: "SKU-1", BuyQuantity: 1, GetQuantity: 1, Active: true},
+			expected: []float64{100},
+		},
+		{
+			name:        "sku not on the order",
+			coupon:      &Coupon{Code: "OTHER", Kind: vos.CouponPercentage, Value: 10, SKU: "SKU-9", Active: true},
+			expectedErr: ErrCouponNotApplicable,
+		},
+		{
+			name:        "inactive",
+			coupon:      &Coupon{Code: "OFF", Kind: vos.CouponPercentage, Value: 10},
+			expectedErr: ErrCouponInactive,
+		},
+		{
+			name:        "expired",
+			coupon:      &Coupon{Code: "OLD", Kind: vos.CouponPercentage, Value: 10, Active: true, EndsAt: ended},
+			expectedErr: ErrCouponExpired,
+		},
+		{
+			name:        "usage limit reached",
+			coupon:      &Coupon{Code: "USED", Kind: vos.CouponPercentage, Value: 10, Active: true, MaxUses: 5, Uses: 5},
+			expectedErr: ErrCouponUsageLimitReached,
+		},
+		{
+			name:        "customer limit without a customer",
+			coupon:      &Coupon{Code: "ONCE", Kind: vos.CouponPercentage, Value: 10, Active: true, MaxUsesPerCustomer: 1},
+			expectedErr: ErrCouponCustomerRequired,
+		},
+		{
+			name:         "customer limit reached",
+			coupon:       &Coupon{Code: "ONCE", Kind: vos.CouponPercentage, Value: 10, Active: true, MaxUsesPerCustomer: 1},
+			customerID:   "customer-1",
+			customerUses: 1,
+			expectedErr:  ErrCouponCustomerLimitReached,
+		},
+		{
+			name:        "minimum spend not met",
+			coupon:      &Coupon{Code: "BIG", Kind: vos.CouponPercentage, Value: 10, Active: true, MinimumSpend: 250.01},
+			expectedErr: ErrCouponMinimumSpendNotMet,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := newOrderFixture(t)
+			tt.coupon.ID = newUUID(t)
+
+			discounts, err := tt.coupon.Discounts(order, tt.customerID, tt.customerUses, now)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("Discounts() error = %v, want %v", err, tt.expectedErr)
+			}
+
+			if len(discounts) != len(tt.expected) {
+				t.Fatalf("discounts = %d, want %d", len(discounts), len(tt.expected))
+			}
+
+			for i, discount := range discounts {
+				if discount.Amount != tt.expected[i] {
+					t.Errorf("discount %d = %v, want %v", i, discount.Amount, tt.expected[i])
+				}
+
+				if discount.CouponID != tt.coupon.ID || discount.OrderID != order.ID {
+					t.Errorf("discount %d is not linked to the coupon and order", i)
+				}
+			}
+		})
+	}
+}
+
+func TestCouponFixedDiscountAddsUp(t *testing.T) {
+	order := newOrderFixture(t)
+	order.AddItems(append(order.Items, NewOrderItem(order.ID, "SKU-3", "Cable", 33.33, 1)))
+	order.Items[2].ID = newUUID(t)
+
+	coupon := &Coupon{Code: "ODD", Kind: vos.CouponFixed, Value: 10, Active: true}
+	discounts, err := coupon.Discounts(order, "", 0, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var total float64
+	for _, discount := range discounts {
+		total += discount.Amount
+	}
+
+	if roundMoney(total) != 10 {
+		t.Errorf("discounts add up to %v, want 10", total)
+	}
+}
@@ -0,0 +1,34 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/vos"
+)
+
+// ExchangeRate converts one unit of Base into Rate units of Quote.
+type ExchangeRate struct {
+	Base        vos.Currency
+	Quote       vos.Currency
+	Rate        float64
+	EffectiveAt time.Time
+}
+
+func NewIdentityRate(currency vos.Currency, at time.Time) *ExchangeRate {
+	return &ExchangeRate{
+		Base:        currency,
+		Quote:       currency,
+		Rate:        1,
+		EffectiveAt: at,
+	}
+}
+
+// Inverse returns the rate converting Quote back into Base.
+func (r *ExchangeRate) Inverse() *ExchangeRate {
+	return &ExchangeRate{
+		Base:        r.Quote,
+		Quote:       r.Base,
+		Rate:        1 / r.Rate,
+		EffectiveAt: r.EffectiveAt,
+	}
+}
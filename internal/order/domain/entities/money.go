@@ -0,0 +1,7 @@
+package entities
+
+import "math"
+
+func roundMoney(value float64) float64 {
+	return math.Round(value*100) / 100
+}
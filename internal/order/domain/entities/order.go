@@ -1,42 +1,560 @@
 package entities
 
 import (
+	"errors"
+	"fmt"
+	"math"
 	"time"
 
+	"github.com/jailtonjunior94/order/internal/order/domain/events"
 	"github.com/jailtonjunior94/order/internal/order/domain/vos"
 	"github.com/jailtonjunior94/order/pkg/entity"
 	sharedVos "github.com/jailtonjunior94/order/pkg/vos"
 )
 
+var (
+	ErrOrderNotPending       = errors.New("order is not pending")
+	ErrOrderNotPayable       = errors.New("order does not accept payments")
+	ErrPaymentExceedsBalance = errors.New("payment exceeds the order balance")
+	ErrOrderNotRefundable    = errors.New("order does not accept returns or refunds")
+	ErrRefundExceedsPaid     = errors.New("refund exceeds the amount paid")
+	ErrOrderItemNotFound     = errors.New("order item not found")
+	ErrOrderItemRequired     = errors.New("order must keep at least one item")
+	ErrOrderVersionMismatch  = errors.New("order has changed since the given version")
+	ErrOrderNotShippable     = errors.New("order is not awaiting shipment")
+)
+
 type Order struct {
 	entity.Base
-	Status vos.Status
-	Items  []*OrderItem
+	entity.AggregateRoot
+	CustomerID        string
+	Currency          vos.Currency
+	ReportingCurrency vos.Currency
+	ExchangeRate      float64
+	ExchangeRateAt    time.Time
+	ShippingAddress   *vos.Address
+	BillingAddress    *vos.Address
+	TaxRegion         string
+	TaxInclusive      bool
+	ShippingMethod    string
+	ShippingCarrier   string
+	ShippingAmount    float64
+	Status            vos.Status
+	Items             []*OrderItem
+	Discounts         []*OrderDiscount
+	Payments          []*Payment
+	Version           int
+	statusChanges     []*OrderStatusChange
 }
 
 func NewOrder() *Order {
+	now := time.Now().UTC()
 	return &Order{
-		Status: vos.StatusPending,
+		Status:        vos.StatusPending,
+		Version:       1,
+		statusChanges: []*OrderStatusChange{NewOrderStatusChange("", vos.StatusPending, "", now)},
 		Base: entity.Base{
-			CreatedAt: time.Now().UTC(),
+			CreatedAt: now,
 		},
 	}
 }
 
-func (o *Order) MarkAsPaid() *Order {
-	o.Status = vos.StatusPaid
-	o.UpdatedAt = sharedVos.NewNullableTime(time.Now().UTC())
-	return o
+// StatusChanges returns the transitions not yet persisted to the status
+// history.
+func (o *Order) StatusChanges() []*OrderStatusChange {
+	return o.statusChanges
+}
+
+func (o *Order) ClearStatusChanges() {
+	o.statusChanges = nil
+}
+
+func (o *Order) changeStatus(status vos.Status, reason string) {
+	now := time.Now().UTC()
+	if status != o.Status {
+		o.statusChanges = append(o.statusChanges, NewOrderStatusChange(o.Status, status, reason, now))
+		o.Status = status
+	}
+	o.UpdatedAt = sharedVos.NewNullableTime(now)
+}
+
+// CheckVersion guards a change requested against version of the order; zero
+// means the caller did not ask for the check.
+func (o *Order) CheckVersion(version int) error {
+	if version != 0 && version != o.Version {
+		return ErrOrderVersionMismatch
+	}
+	return nil
+}
+
+// RegisterPayment records payment against the order; approved payments move
+// the order to PARTIALLY_PAID or, once the balance is covered, to PAID.
+// Declined payments are kept for the record without changing the status.
+func (o *Order) RegisterPayment(payment *Payment) error {
+	if o.Status != vos.StatusPending && o.Status != vos.StatusPartiallyPaid {
+		return ErrOrderNotPayable
+	}
+
+	if err := payment.Validate(); err != nil {
+		return err
+	}
+
+	if payment.Currency != o.Currency {
+		return ErrPaymentCurrencyMismatch
+	}
+
+	if payment.IsApproved() && payment.Amount > o.Balance() {
+		return ErrPaymentExceedsBalance
+	}
+
+	o.Payments = append(o.Payments, payment)
+	if payment.IsApproved() {
+		status := vos.StatusPartiallyPaid
+		if o.Balance() <= 0 {
+			status = vos.StatusPaid
+		}
+		o.changeStatus(status, fmt.Sprintf("payment %s approved", payment.ID.String()))
+	}
+
+	o.AddEvent(events.NewOrderPaymentRegisteredEvent(o.ID, &events.OrderPaymentRegistered{
+		PaymentID:         payment.ID.String(),
+		Amount:            payment.Amount,
+		Currency:          payment.Currency.String(),
+		Method:            payment.Method.String(),
+		ProviderReference: payment.ProviderReference,
+		PaymentStatus:     payment.Status.String(),
+		PaidAmount:        o.PaidAmount(),
+		Balance:           o.Balance(),
+		Status:            o.Status.String(),
+	}))
+
+	if payment.IsApproved() && o.Status == vos.StatusPaid {
+		o.addPaidEvent()
+	}
+	return nil
+}
+
+// FindPayment returns the payment with the given provider reference, used to
+// ignore redelivered payments.
+func (o *Order) FindPayment(providerReference string) *Payment {
+	if providerReference == "" {
+		return nil
+	}
+
+	for _, payment := range o.Payments {
+		if payment.ProviderReference == providerReference {
+			return payment
+		}
+	}
+	return nil
+}
+
+func (o *Order) PaidAmount() float64 {
+	var paid float64
+	for _, payment := range o.Payments {
+		if payment.IsApproved() {
+			paid += payment.Amount
+		}
+	}
+	return roundMoney(paid)
+}
+
+func (o *Order) Balance() float64 {
+	return roundMoney(o.Total() - o.PaidAmount())
+}
+
+func (o *Order) CanRefund() bool {
+	switch o.Status {
+	case vos.StatusPaid, vos.StatusPartiallyShipped, vos.StatusShipped, vos.StatusDelivered, vos.StatusPartiallyRefunded:
+		return true
+	}
+	return false
+}
+
+func (o *Order) CanShip() bool {
+	return o.Status == vos.StatusPaid || o.Status == vos.StatusPartiallyShipped
+}
+
+// UpdateFulfillment moves a paid order to PARTIALLY_SHIPPED or SHIPPED as its
+// units go out, and to DELIVERED once every shipment arrived. Orders already
+// being refunded keep their status.
+func (o *Order) UpdateFulfillment(shipments []*Shipment) {
+	if o.Status != vos.StatusPaid && o.Status != vos.StatusPartiallyShipped && o.Status != vos.StatusShipped {
+		return
+	}
+
+	shipped := ShippedQuantities(shipments)
+	complete, started := true, false
+	for _, item := range o.Items {
+		if shipped[item.ID] > 0 {
+			started = true
+		}
+		if shipped[item.ID] < item.Quantity {
+			complete = false
+		}
+	}
+
+	delivered := len(shipments) > 0
+	for _, shipment := range shipments {
+		if !shipment.IsDelivered() {
+			delivered = false
+			break
+		}
+	}
+
+	status := o.Status
+	switch {
+	case complete && delivered:
+		status = vos.StatusDelivered
+	case complete:
+		status = vos.StatusShipped
+	case started:
+		status = vos.StatusPartiallyShipped
+	}
+
+	if status == o.Status {
+		return
+	}
+
+	o.changeStatus(status, "shipment progress")
+	o.AddEvent(events.NewOrderFulfillmentChangedEvent(o.ID, &events.OrderFulfillmentChanged{
+		Status:    status.String(),
+		Shipments: len(shipments),
+	}))
+}
+
+func (o *Order) FindItem(orderItemID sharedVos.UUID) *OrderItem {
+	for _, item := range o.Items {
+		if item.ID == orderItemID {
+			return item
+		}
+	}
+	return nil
+}
+
+// ReturnAmount is what the customer paid for quantity units of item, net of
+// discounts and with exclusive tax, given returned units already claimed.
+// Amounts are taken as differences of cumulative shares so returning every
+// unit refunds the whole line without rounding leftovers.
+func (o *Order) ReturnAmount(item *OrderItem, returned, quantity uint) float64 {
+	gross := item.Total()
+	if !o.TaxInclusive {
+		gross += item.Tax
+	}
+
+	share := func(units uint) float64 {
+		return roundMoney(gross * float64(units) / float64(item.Quantity))
+	}
+	return roundMoney(share(returned+quantity) - share(returned))
+}
+
+func (o *Order) RefundedAmount() float64 {
+	var refunded float64
+	for _, payment := range o.Payments {
+		refunded += payment.RefundedAmount
+	}
+	return roundMoney(refunded)
+}
+
+// Refund spreads amount over the approved payments, newest first, and moves
+// the order to PARTIALLY_REFUNDED or, once everything paid is returned, to
+// REFUNDED. The caller assigns ids to the returned refunds.
+func (o *Order) Refund(returnID sharedVos.UUID, amount float64) ([]*Refund, error) {
+	if !o.CanRefund() {
+		return nil, ErrOrderNotRefundable
+	}
+
+	amount = roundMoney(amount)
+	if amount <= 0 || amount > roundMoney(o.PaidAmount()-o.RefundedAmount()) {
+		return nil, ErrRefundExceedsPaid
+	}
+
+	var (
+		refunds   []*Refund
+		remaining = amount
+		now       = time.Now().UTC()
+	)
+
+	for i := len(o.Payments) - 1; i >= 0 && remaining > 0; i-- {
+		payment := o.Payments[i]
+		part := math.Min(remaining, payment.Refundable())
+		if part <= 0 {
+			continue
+		}
+
+		payment.RefundedAmount = roundMoney(payment.RefundedAmount + part)
+		payment.UpdatedAt = sharedVos.NewNullableTime(now)
+		refunds = append(refunds, NewRefund(o.ID, payment.ID, returnID, part))
+		remaining = roundMoney(remaining - part)
+	}
+
+	status := vos.StatusPartiallyRefunded
+	if o.RefundedAmount() >= o.PaidAmount() {
+		status = vos.StatusRefunded
+	}
+	o.changeStatus(status, fmt.Sprintf("return %s refunded", returnID.String()))
+
+	refunded := make([]*events.OrderRefundedRefund, 0, len(refunds))
+	for _, refund := range refunds {
+		refunded = append(refunded, &events.OrderRefundedRefund{
+			PaymentID: refund.PaymentID.String(),
+			Amount:    refund.Amount,
+		})
+	}
+
+	o.AddEvent(events.NewOrderRefundedEvent(o.ID, &events.OrderRefunded{
+		ReturnID:       returnID.String(),
+		Currency:       o.Currency.String(),
+		Amount:         amount,
+		RefundedAmount: o.RefundedAmount(),
+		Refunds:        refunded,
+		Status:         o.Status.String(),
+	}))
+	return refunds, nil
+}
+
+func (o *Order) addPaidEvent() {
+	o.AddEvent(events.NewOrderPaidEvent(
+		o.ID,
+		o.Currency.String(),
+		o.Subtotal(),
+		o.DiscountTotal(),
+		o.TaxTotal(),
+		o.ShippingAmount,
+		o.Total(),
+		o.taxesByJurisdiction(),
+		events.NewReportingAmount(o.ReportingCurrency.String(), o.ExchangeRate, o.ReportingTotal()),
+	))
+}
+
+func (o *Order) Cancel(reason string) error {
+	if o.Status != vos.StatusPending {
+		return ErrOrderNotPending
+	}
+
+	o.changeStatus(vos.StatusCanceled, reason)
+	o.AddEvent(events.NewOrderCanceledEvent(o.ID, reason))
+	return nil
+}
+
+// DeclinePayment keeps the order pending so the customer can retry payment
+// before the order expires.
+func (o *Order) DeclinePayment(paymentID, reason string) error {
+	if o.Status != vos.StatusPending {
+		return ErrOrderNotPending
+	}
+
+	o.AddEvent(events.NewOrderPaymentDeclinedEvent(o.ID, paymentID, reason))
+	return nil
+}
+
+// SetAddresses bills to the shipping address when no billing address is
+// given.
+func (o *Order) SetAddresses(shipping, billing *vos.Address) {
+	if billing == nil {
+		billing = shipping
+	}
+	o.ShippingAddress = shipping
+	o.BillingAddress = billing
 }
 
 func (o *Order) AddItems(items []*OrderItem) {
 	o.Items = items
 }
 
-func (o *Order) Total() float64 {
-	var total float64
+// AddItem appends item to a pending order; adding a sku already on the order
+// increases that line instead, keeping the price it was ordered at.
+func (o *Order) AddItem(item *OrderItem) error {
+	if o.Status != vos.StatusPending {
+		return ErrOrderNotPending
+	}
+
+	for _, existing := range o.Items {
+		if existing.SKU == item.SKU {
+			existing.Quantity += item.Quantity
+			existing.UpdatedAt = sharedVos.NewNullableTime(time.Now().UTC())
+			return nil
+		}
+	}
+
+	o.Items = append(o.Items, item)
+	return nil
+}
+
+func (o *Order) ChangeItemQuantity(orderItemID sharedVos.UUID, quantity uint) error {
+	if o.Status != vos.StatusPending {
+		return ErrOrderNotPending
+	}
+
+	item := o.FindItem(orderItemID)
+	if item == nil {
+		return ErrOrderItemNotFound
+	}
+
+	item.Quantity = quantity
+	item.UpdatedAt = sharedVos.NewNullableTime(time.Now().UTC())
+	return nil
+}
+
+func (o *Order) RemoveItem(orderItemID sharedVos.UUID) error {
+	if o.Status != vos.StatusPending {
+		return ErrOrderNotPending
+	}
+
+	for i, item := range o.Items {
+		if item.ID != orderItemID {
+			continue
+		}
+
+		if len(o.Items) == 1 {
+			return ErrOrderItemRequired
+		}
+		o.Items = append(o.Items[:i], o.Items[i+1:]...)
+		return nil
+	}
+	return ErrOrderItemNotFound
+}
+
+// ItemsChanged records the amended lines once the order has been repriced,
+// so the event carries the totals the customer now owes.
+func (o *Order) ItemsChanged() {
+	o.UpdatedAt = sharedVos.NewNullableTime(time.Now().UTC())
+
+	items := make([]*events.OrderItemsChangedItem, 0, len(o.Items))
 	for _, item := range o.Items {
-		total += item.Price * float64(item.Quantity)
+		items = append(items, &events.OrderItemsChangedItem{
+			OrderItemID: item.ID.String(),
+			SKU:         item.SKU,
+			Quantity:    item.Quantity,
+			Price:       item.Price,
+			Discount:    item.Discount,
+			Tax:         item.Tax,
+		})
+	}
+
+	o.AddEvent(events.NewOrderItemsChangedEvent(o.ID, &events.OrderItemsChanged{
+		Currency: o.Currency.String(),
+		Items:    items,
+		Subtotal: o.Subtotal(),
+		Discount: o.DiscountTotal(),
+		Tax:      o.TaxTotal(),
+		Shipping: o.ShippingAmount,
+		Total:    o.Total(),
+	}))
+}
+
+// ClearDiscounts drops every line discount so the order can be priced again.
+func (o *Order) ClearDiscounts() {
+	for _, item := range o.Items {
+		item.Discount = 0
+	}
+	o.Discounts = nil
+}
+
+// ApplyDiscounts attaches line discounts to their items; a discount for an
+// item that is not part of the order is ignored.
+func (o *Order) ApplyDiscounts(discounts []*OrderDiscount) {
+	for _, discount := range discounts {
+		for _, item := range o.Items {
+			if item.ID == discount.OrderItemID {
+				item.Discount = roundMoney(item.Discount + discount.Amount)
+				o.Discounts = append(o.Discounts, discount)
+				break
+			}
+		}
+	}
+}
+
+func (o *Order) Subtotal() float64 {
+	var subtotal float64
+	for _, item := range o.Items {
+		subtotal += item.Subtotal()
+	}
+	return roundMoney(subtotal)
+}
+
+func (o *Order) DiscountTotal() float64 {
+	var discount float64
+	for _, item := range o.Items {
+		discount += item.Discount
+	}
+	return roundMoney(discount)
+}
+
+// ApplyTaxes replaces the tax lines of every item with the assessment.
+func (o *Order) ApplyTaxes(assessment *TaxAssessment) {
+	o.TaxInclusive = assessment.Inclusive
+	for _, item := range o.Items {
+		item.Tax = 0
+		item.Taxes = nil
+		for _, line := range assessment.Lines {
+			if line.OrderItemID == item.ID {
+				item.Tax = roundMoney(item.Tax + line.Amount)
+				item.Taxes = append(item.Taxes, line)
+			}
+		}
+	}
+}
+
+func (o *Order) TaxTotal() float64 {
+	var tax float64
+	for _, item := range o.Items {
+		tax += item.Tax
+	}
+	return roundMoney(tax)
+}
+
+func (o *Order) TaxLines() []*OrderItemTax {
+	var lines []*OrderItemTax
+	for _, item := range o.Items {
+		lines = append(lines, item.Taxes...)
+	}
+	return lines
+}
+
+// SetShipping charges the option the customer chose; shipping is not taxed.
+func (o *Order) SetShipping(option *ShippingOption) {
+	o.ShippingMethod = option.Code
+	o.ShippingCarrier = option.Carrier
+	o.ShippingAmount = roundMoney(option.Amount)
+}
+
+// Total is what the customer pays; inclusive taxes are already part of the
+// line prices.
+func (o *Order) Total() float64 {
+	total := o.Subtotal() - o.DiscountTotal() + o.ShippingAmount
+	if !o.TaxInclusive {
+		total += o.TaxTotal()
+	}
+	return roundMoney(total)
+}
+
+// SnapshotExchangeRate freezes the conversion into the reporting currency so
+// later rate changes do not alter the reported value of the order.
+func (o *Order) SnapshotExchangeRate(rate *ExchangeRate) {
+	o.ReportingCurrency = rate.Quote
+	o.ExchangeRate = rate.Rate
+	o.ExchangeRateAt = rate.EffectiveAt
+}
+
+func (o *Order) ReportingTotal() float64 {
+	if o.ExchangeRate == 0 {
+		return o.Total()
+	}
+	return roundMoney(o.Total() * o.ExchangeRate)
+}
+
+func (o *Order) taxesByJurisdiction() []*events.OrderPaidTax {
+	var taxes []*events.OrderPaidTax
+	index := make(map[string]*events.OrderPaidTax)
+	for _, line := range o.TaxLines() {
+		tax, ok := index[line.Jurisdiction]
+		if !ok {
+			tax = &events.OrderPaidTax{Jurisdiction: line.Jurisdiction}
+			index[line.Jurisdiction] = tax
+			taxes = append(taxes, tax)
+		}
+		tax.Amount = roundMoney(tax.Amount + line.Amount)
 	}
-	return total
+	return taxes
 }
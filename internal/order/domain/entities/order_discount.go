@@ -0,0 +1,30 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/jailtonjunior94/order/pkg/entity"
+	"github.com/jailtonjunior94/order/pkg/vos"
+)
+
+type OrderDiscount struct {
+	entity.Base
+	OrderID     vos.UUID
+	OrderItemID vos.UUID
+	CouponID    vos.UUID
+	Code        string
+	Amount      float64
+}
+
+func NewOrderDiscount(orderID, orderItemID, couponID vos.UUID, code string, amount float64) *OrderDiscount {
+	return &OrderDiscount{
+		OrderID:     orderID,
+		OrderItemID: orderItemID,
+		CouponID:    couponID,
+		Code:        code,
+		Amount:      amount,
+		Base: entity.Base{
+			CreatedAt: time.Now().UTC(),
+		},
+	}
+}
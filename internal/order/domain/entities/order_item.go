@@ -10,14 +10,20 @@ import (
 type OrderItem struct {
 	entity.Base
 	OrderID     vos.UUID
+	SKU         string
 	ProductName string
 	Price       float64
 	Quantity    uint
+	Discount    float64
+	TaxCategory string
+	Tax         float64
+	Taxes       []*OrderItemTax
 }
 
-func NewOrderItem(orderID vos.UUID, productName string, price float64, quantity uint) *OrderItem {
+func NewOrderItem(orderID vos.UUID, sku, productName string, price float64, quantity uint) *OrderItem {
 	return &OrderItem{
 		OrderID:     orderID,
+		SKU:         sku,
 		ProductName: productName,
 		Price:       price,
 		Quantity:    quantity,
@@ -26,3 +32,13 @@ func NewOrderItem(orderID vos.UUID, productName string, price float64, quantity
 		},
 	}
 }
+
+func (i *OrderItem) Subtotal() float64 {
+	return roundMoney(i.Price * float64(i.Quantity))
+}
+
+// Total is the line amount after discounts already applied to it, before any
+// exclusive tax.
+func (i *OrderItem) Total() float64 {
+	return roundMoney(i.Subtotal() - i.Discount)
+}
@@ -0,0 +1,133 @@
+package entities
+
+import (
+	"errors"
+	"time"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/events"
+	"github.com/jailtonjunior94/order/internal/order/domain/vos"
+	"github.com/jailtonjunior94/order/pkg/entity"
+	sharedVos "github.com/jailtonjunior94/order/pkg/vos"
+)
+
+var (
+	ErrReturnWithoutLines     = errors.New("return must have at least one line")
+	ErrInvalidReturnLine      = errors.New("return line requires an order item and a positive quantity")
+	ErrReturnQuantityExceeded = errors.New("return quantity exceeds the quantity left to return")
+	ErrReturnStepMismatch     = errors.New("return is not awaiting this step")
+)
+
+type (
+	// OrderReturn is a request to send back some order lines; once approved
+	// its amount is refunded against the order payments.
+	OrderReturn struct {
+		entity.Base
+		entity.AggregateRoot
+		OrderID         sharedVos.UUID
+		Status          vos.ReturnStatus
+		Reason          string
+		RejectionReason string
+		Lines           []*ReturnLine
+		Refunds         []*Refund
+	}
+
+	ReturnLine struct {
+		entity.Base
+		ReturnID    sharedVos.UUID
+		OrderItemID sharedVos.UUID
+		Quantity    uint
+		Amount      float64
+	}
+)
+
+func NewOrderReturn(orderID sharedVos.UUID, reason string) *OrderReturn {
+	return &OrderReturn{
+		OrderID: orderID,
+		Status:  vos.ReturnRequested,
+		Reason:  reason,
+		Base: entity.Base{
+			CreatedAt: time.Now().UTC(),
+		},
+	}
+}
+
+func NewReturnLine(returnID, orderItemID sharedVos.UUID, quantity uint, amount float64) *ReturnLine {
+	return &ReturnLine{
+		ReturnID:    returnID,
+		OrderItemID: orderItemID,
+		Quantity:    quantity,
+		Amount:      amount,
+		Base: entity.Base{
+			CreatedAt: time.Now().UTC(),
+		},
+	}
+}
+
+func (r *OrderReturn) Amount() float64 {
+	var amount float64
+	for _, line := range r.Lines {
+		amount += line.Amount
+	}
+	return roundMoney(amount)
+}
+
+// IsOpen reports whether the return still holds its quantities; rejected
+// returns free them for a new request.
+func (r *OrderReturn) IsOpen() bool {
+	return r.Status != vos.ReturnRejected
+}
+
+func (r *OrderReturn) Request() error {
+	if len(r.Lines) == 0 {
+		return ErrReturnWithoutLines
+	}
+
+	lines := make([]*events.ReturnLine, 0, len(r.Lines))
+	for _, line := range r.Lines {
+		lines = append(lines, &events.ReturnLine{
+			OrderItemID: line.OrderItemID.String(),
+			Quantity:    line.Quantity,
+			Amount:      line.Amount,
+		})
+	}
+
+	r.AddEvent(events.NewReturnRequestedEvent(r.ID, r.OrderID, r.Reason, r.Amount(), lines))
+	return nil
+}
+
+func (r *OrderReturn) Approve() error {
+	if r.Status != vos.ReturnRequested {
+		return ErrReturnStepMismatch
+	}
+
+	r.advance(vos.ReturnApproved)
+	r.AddEvent(events.NewReturnApprovedEvent(r.ID, r.OrderID, r.Amount()))
+	return nil
+}
+
+func (r *OrderReturn) Reject(reason string) error {
+	if r.Status != vos.ReturnRequested {
+		return ErrReturnStepMismatch
+	}
+
+	r.RejectionReason = reason
+	r.advance(vos.ReturnRejected)
+	r.AddEvent(events.NewReturnRejectedEvent(r.ID, r.OrderID, reason))
+	return nil
+}
+
+func (r *OrderReturn) Refunded(refunds []*Refund) error {
+	if r.Status != vos.ReturnApproved {
+		return ErrReturnStepMismatch
+	}
+
+	r.Refunds = refunds
+	r.advance(vos.ReturnRefunded)
+	r.AddEvent(events.NewReturnRefundedEvent(r.ID, r.OrderID, r.Amount()))
+	return nil
+}
+
+func (r *OrderReturn) advance(status vos.ReturnStatus) {
+	r.Status = status
+	r.UpdatedAt = sharedVos.NewNullableTime(time.Now().UTC())
+}
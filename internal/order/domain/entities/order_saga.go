@@ -0,0 +1,241 @@
+package entities
+
+import (
+	"errors"
+	"time"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/events"
+	"github.com/jailtonjunior94/order/internal/order/domain/vos"
+	"github.com/jailtonjunior94/order/pkg/entity"
+	sharedVos "github.com/jailtonjunior94/order/pkg/vos"
+)
+
+const (
+	SagaTimeoutReason       = "saga_timeout"
+	SagaAmendedReason       = "order_amended"
+	SagaStaleReplyReason    = "stale_reply"
+	maxReleaseStockAttempts = 5
+)
+
+var ErrSagaStepMismatch = errors.New("saga is not awaiting this step")
+
+// OrderSaga coordinates stock reservation and payment authorization for an
+// order, emitting commands as domain events so they leave through the outbox.
+// Step numbers every ReserveStock and AuthorizePayment command; a reply is
+// only accepted for the latest one.
+type OrderSaga struct {
+	entity.Base
+	entity.AggregateRoot
+	OrderID       sharedVos.UUID
+	Status        vos.SagaStatus
+	ReservationID string
+	PaymentID     string
+	FailureReason string
+	Attempts      int
+	Step          int
+	DeadlineAt    time.Time
+}
+
+func NewOrderSaga(orderID sharedVos.UUID) *OrderSaga {
+	return &OrderSaga{
+		OrderID: orderID,
+		Status:  vos.SagaReservingStock,
+		Base: entity.Base{
+			CreatedAt: time.Now().UTC(),
+		},
+	}
+}
+
+func (s *OrderSaga) Start(items []*OrderItem, deadline time.Time) {
+	stockItems := make([]*events.ReserveStockItem, 0, len(items))
+	for _, item := range items {
+		stockItems = append(stockItems, &events.ReserveStockItem{
+			SKU:      item.SKU,
+			Quantity: item.Quantity,
+		})
+	}
+
+	s.Status = vos.SagaReservingStock
+	s.Attempts = 1
+	s.Step++
+	s.DeadlineAt = deadline
+	s.AddEvent(events.NewReserveStockCommand(s.ID, s.OrderID, s.Step, stockItems))
+}
+
+func (s *OrderSaga) StartReserved(reservationID string, amount float64, currency vos.Currency, deadline time.Time) {
+	s.ReservationID = reservationID
+	s.Status = vos.SagaAuthorizingPayment
+	s.Attempts = 1
+	s.Step++
+	s.DeadlineAt = deadline
+	s.AddEvent(events.NewAuthorizePaymentCommand(s.ID, s.OrderID, s.Step, amount, currency.String()))
+}
+
+// Awaits reports whether the saga waits in status for the reply to the
+// command sent as step.
+func (s *OrderSaga) Awaits(status vos.SagaStatus, step int) bool {
+	return s.Status == status && s.Step == step
+}
+
+func (s *OrderSaga) StockReserved(step int, reservationID string, amount float64, currency vos.Currency, deadline time.Time) error {
+	if !s.Awaits(vos.SagaReservingStock, step) {
+		return ErrSagaStepMismatch
+	}
+
+	s.ReservationID = reservationID
+	s.advance(vos.SagaAuthorizingPayment, deadline)
+	s.Step++
+	s.AddEvent(events.NewAuthorizePaymentCommand(s.ID, s.OrderID, s.Step, amount, currency.String()))
+	return nil
+}
+
+// DiscardReservation releases stock reserved for a command the saga no longer
+// waits for; the reservation the saga holds is never released this way, so a
+// redelivered reply is harmless.
+func (s *OrderSaga) DiscardReservation(reservationID string) error {
+	if reservationID == "" || reservationID == s.ReservationID {
+		return ErrSagaStepMismatch
+	}
+
+	s.touch()
+	s.AddEvent(events.NewReleaseStockCommand(s.ID, s.OrderID, reservationID, SagaStaleReplyReason))
+	return nil
+}
+
+// Amend restarts an active saga for an order whose items changed before it
+// was paid. A reservationID means stock was already reserved again through
+// the inventory port, so only the payment is authorized again for amount;
+// otherwise the previous reservation is released and stock is reserved again
+// through the outbox.
+func (s *OrderSaga) Amend(items []*OrderItem, reservationID string, amount float64, currency vos.Currency, deadline time.Time) error {
+	if s.Status != vos.SagaReservingStock && s.Status != vos.SagaAuthorizingPayment {
+		return ErrSagaStepMismatch
+	}
+
+	if reservationID != "" {
+		s.StartReserved(reservationID, amount, currency, deadline)
+	} else {
+		if s.ReservationID != "" {
+			s.AddEvent(events.NewReleaseStockCommand(s.ID, s.OrderID, s.ReservationID, SagaAmendedReason))
+			s.ReservationID = ""
+		}
+		s.Start(items, deadline)
+	}
+	s.touch()
+	return nil
+}
+
+// StockRejected aborts the saga without compensation since nothing was reserved.
+func (s *OrderSaga) StockRejected(reason string) error {
+	if s.Status != vos.SagaReservingStock {
+		return ErrSagaStepMismatch
+	}
+
+	s.FailureReason = reason
+	s.advance(vos.SagaAborted, time.Time{})
+	return nil
+}
+
+func (s *OrderSaga) PaymentAuthorized(step int, paymentID string) error {
+	if !s.Awaits(vos.SagaAuthorizingPayment, step) {
+		return ErrSagaStepMismatch
+	}
+
+	s.PaymentID = paymentID
+	s.advance(vos.SagaCompleted, time.Time{})
+	return nil
+}
+
+// PaymentDeclined records the declined payment; the caller then compensates
+// with either Compensate or Abort depending on how stock is released.
+func (s *OrderSaga) PaymentDeclined(step int, paymentID string) error {
+	if !s.Awaits(vos.SagaAuthorizingPayment, step) {
+		return ErrSagaStepMismatch
+	}
+
+	s.PaymentID = paymentID
+	return nil
+}
+
+// VoidPayment asks the payment provider to void an authorization the saga can
+// no longer use; the authorization that completed the saga is never voided.
+func (s *OrderSaga) VoidPayment(paymentID, reason string) error {
+	if s.Status == vos.SagaCompleted && s.PaymentID == paymentID {
+		return ErrSagaStepMismatch
+	}
+
+	s.touch()
+	s.AddEvent(events.NewVoidPaymentCommand(s.ID, s.OrderID, paymentID, reason))
+	return nil
+}
+
+// StockReleased ends the compensation once the saga reservation is released;
+// replies naming another reservation, e.g. one DiscardReservation released,
+// are ignored.
+func (s *OrderSaga) StockReleased(reservationID string) error {
+	if s.Status != vos.SagaCompensating || (reservationID != "" && reservationID != s.ReservationID) {
+		return ErrSagaStepMismatch
+	}
+
+	s.advance(vos.SagaAborted, time.Time{})
+	return nil
+}
+
+// Compensate releases whatever stock may have been reserved for the order;
+// the release is safe to send even when the reservation never completed.
+func (s *OrderSaga) Compensate(reason string, deadline time.Time) error {
+	if s.Status.IsTerminal() || s.Status == vos.SagaCompensating {
+		return ErrSagaStepMismatch
+	}
+
+	s.FailureReason = reason
+	s.advance(vos.SagaCompensating, deadline)
+	s.AddEvent(events.NewReleaseStockCommand(s.ID, s.OrderID, s.ReservationID, reason))
+	return nil
+}
+
+// Abort ends the saga once its reservation was released outside of it, so no
+// release command is emitted.
+func (s *OrderSaga) Abort(reason string) error {
+	if s.Status.IsTerminal() {
+		return ErrSagaStepMismatch
+	}
+
+	s.FailureReason = reason
+	s.advance(vos.SagaAborted, time.Time{})
+	return nil
+}
+
+// TimeOut compensates a stalled forward step and retries a stalled release
+// until its attempts run out, leaving the saga FAILED for manual follow-up.
+func (s *OrderSaga) TimeOut(deadline time.Time) error {
+	switch s.Status {
+	case vos.SagaReservingStock, vos.SagaAuthorizingPayment:
+		return s.Compensate(SagaTimeoutReason, deadline)
+	case vos.SagaCompensating:
+		if s.Attempts >= maxReleaseStockAttempts {
+			s.Status = vos.SagaFailed
+			s.touch()
+			return nil
+		}
+
+		s.Attempts++
+		s.DeadlineAt = deadline
+		s.touch()
+		s.AddEvent(events.NewReleaseStockCommand(s.ID, s.OrderID, s.ReservationID, s.FailureReason))
+		return nil
+	default:
+		return ErrSagaStepMismatch
+	}
+}
+
+func (s *OrderSaga) advance(status vos.SagaStatus, deadline time.Time) {
+	s.Status = status
+	s.Attempts = 1
+	s.DeadlineAt = deadline
+	s.touch()
+}
+
+func (s *OrderSaga) touch() {
+	s.UpdatedAt = sharedVos.NewNullableTime(time.Now().UTC())
+}
@@ -0,0 +1,120 @@
+package entities
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/events"
+	"github.com/jailtonjunior94/order/internal/order/domain/vos"
+	sharedVos "github.com/jailtonjunior94/order/pkg/vos"
+)
+
+func TestOrderSagaAmend(t *testing.T) {
+	deadline := time.Now().Add(time.Minute)
+
+	tests := []struct {
+		name           string
+		prepare        func(saga *OrderSaga, order *Order)
+		reservationID  string
+		expectedStatus vos.SagaStatus
+		expectedEvents []string
+		expectedErr    error
+	}{
+		{
+			name: "reserved through the inventory port",
+			prepare: func(saga *OrderSaga, order *Order) {
+				saga.StartReserved("reservation-1", order.Total(), order.Currency, deadline)
+			},
+			reservationID:  "reservation-2",
+			expectedStatus: vos.SagaAuthorizingPayment,
+			expectedEvents: []string{events.AuthorizePaymentCommand},
+		},
+		{
+			name: "reserved through the outbox",
+			prepare: func(saga *OrderSaga, order *Order) {
+				saga.Start(order.Items, deadline)
+				_ = saga.StockReserved(saga.Step, "reservation-1", order.Total(), order.Currency, deadline)
+			},
+			expectedStatus: vos.SagaReservingStock,
+			expectedEvents: []string{events.ReleaseStockCommand, events.ReserveStockCommand},
+		},
+		{
+			name: "completed saga",
+			prepare: func(saga *OrderSaga, order *Order) {
+				saga.StartReserved("reservation-1", order.Total(), order.Currency, deadline)
+				_ = saga.PaymentAuthorized(saga.Step, "payment-1")
+			},
+			reservationID:  "reservation-2",
+			expectedStatus: vos.SagaCompleted,
+			expectedErr:    ErrSagaStepMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := newOrderFixture(t)
+			saga := NewOrderSaga(order.ID)
+			tt.prepare(saga, order)
+			saga.ClearEvents()
+			step := saga.Step
+
+			err := saga.Amend(order.Items, tt.reservationID, order.Total(), order.Currency, deadline)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("Amend() error = %v, want %v", err, tt.expectedErr)
+			}
+
+			var types []string
+			for _, event := range saga.Events() {
+				types = append(types, event.GetEventType())
+			}
+
+			if saga.Status != tt.expectedStatus || len(types) != len(tt.expectedEvents) {
+				t.Fatalf("saga = %s with events %v, want %s with %v", saga.Status, types, tt.expectedStatus, tt.expectedEvents)
+			}
+
+			for i := range types {
+				if types[i] != tt.expectedEvents[i] {
+					t.Errorf("events = %v, want %v", types, tt.expectedEvents)
+				}
+			}
+
+			if err == nil && saga.Step != step+1 {
+				t.Errorf("step = %d, want %d so replies to the previous command are rejected", saga.Step, step+1)
+			}
+		})
+	}
+}
+
+func TestOrderSagaTimeOut(t *testing.T) {
+	tests := []struct {
+		name           string
+		status         vos.SagaStatus
+		attempts       int
+		expectedStatus vos.SagaStatus
+		expectedEvents int
+		expectedErr    error
+	}{
+		{name: "stalled reservation", status: vos.SagaReservingStock, attempts: 1, expectedStatus: vos.SagaCompensating, expectedEvents: 1},
+		{name: "stalled authorization", status: vos.SagaAuthorizingPayment, attempts: 1, expectedStatus: vos.SagaCompensating, expectedEvents: 1},
+		{name: "stalled release", status: vos.SagaCompensating, attempts: 1, expectedStatus: vos.SagaCompensating, expectedEvents: 1},
+		{name: "release attempts exhausted", status: vos.SagaCompensating, attempts: maxReleaseStockAttempts, expectedStatus: vos.SagaFailed},
+		{name: "completed saga", status: vos.SagaCompleted, attempts: 1, expectedStatus: vos.SagaCompleted, expectedErr: ErrSagaStepMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			saga := NewOrderSaga(sharedVos.UUID{})
+			saga.Status = tt.status
+			saga.Attempts = tt.attempts
+
+			if err := saga.TimeOut(time.Now().Add(time.Minute)); !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("TimeOut() error = %v, want %v", err, tt.expectedErr)
+			}
+
+			if saga.Status != tt.expectedStatus || len(saga.Events()) != tt.expectedEvents {
+				t.Errorf("saga = %s with %d events, want %s with %d", saga.Status, len(saga.Events()), tt.expectedStatus, tt.expectedEvents)
+			}
+		})
+	}
+}
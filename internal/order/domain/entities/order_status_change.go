@@ -0,0 +1,31 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/vos"
+	sharedVos "github.com/jailtonjunior94/order/pkg/vos"
+)
+
+// OrderStatusChange is one entry of the order status history. From is empty
+// for the status an order is created with; the actor and request id are
+// taken from the context the change is persisted in.
+type OrderStatusChange struct {
+	ID        sharedVos.UUID
+	OrderID   sharedVos.UUID
+	From      vos.Status
+	To        vos.Status
+	Actor     string
+	Reason    string
+	RequestID string
+	CreatedAt time.Time
+}
+
+func NewOrderStatusChange(from, to vos.Status, reason string, at time.Time) *OrderStatusChange {
+	return &OrderStatusChange{
+		From:      from,
+		To:        to,
+		Reason:    reason,
+		CreatedAt: at,
+	}
+}
@@ -0,0 +1,227 @@
+package entities
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/vos"
+	sharedVos "github.com/jailtonjunior94/order/pkg/vos"
+)
+
+func newUUID(t *testing.T) sharedVos.UUID {
+	t.Helper()
+
+	id, err := sharedVos.NewUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return id
+}
+
+// newOrderFixture builds a pending BRL order of 2 x 100 and 1 x 50, a total
+// of 250.
+func newOrderFixture(t *testing.T) *Order {
+	t.Helper()
+
+	order := NewOrder()
+	order.ID = newUUID(t)
+	order.Currency = vos.CurrencyBRL
+	order.AddItems([]*OrderItem{
+		NewOrderItem(order.ID, "SKU-1", "Keyboard", 100, 2),
+		NewOrderItem(order.ID, "SKU-2", "Mouse", 50, 1),
+	})
+
+	for _, item := range order.Items {
+		item.ID = newUUID(t)
+	}
+	return order
+}
+
+func newPaymentFixture(t *testing.T, order *Order, amount float64, currency vos.Currency, status vos.PaymentStatus) *Payment {
+	t.Helper()
+
+	payment := NewPayment(order.ID, amount, currency, vos.PaymentPix, "", status)
+	payment.ID = newUUID(t)
+	return payment
+}
+
+func TestOrderApplyDiscounts(t *testing.T) {
+	order := newOrderFixture(t)
+	order.ApplyDiscounts([]*OrderDiscount{
+		NewOrderDiscount(order.ID, order.Items[0].ID, newUUID(t), "TEN", 20),
+		NewOrderDiscount(order.ID, newUUID(t), newUUID(t), "TEN", 5),
+	})
+
+	if order.DiscountTotal() != 20 || order.Total() != 230 || len(order.Discounts) != 1 {
+		t.Errorf("discount = %v, total = %v, discounts = %d; want 20, 230, 1", order.DiscountTotal(), order.Total(), len(order.Discounts))
+	}
+
+	order.ClearDiscounts()
+	if order.DiscountTotal() != 0 || order.Total() != 250 {
+		t.Errorf("after clearing, discount = %v, total = %v; want 0, 250", order.DiscountTotal(), order.Total())
+	}
+}
+
+func TestOrderRegisterPayment(t *testing.T) {
+	tests := []struct {
+		name            string
+		status          vos.Status
+		amounts         []float64
+		currency        vos.Currency
+		paymentStatus   vos.PaymentStatus
+		expectedStatus  vos.Status
+		expectedBalance float64
+		expectedErr     error
+	}{
+		{name: "full payment", amounts: []float64{250}, expectedStatus: vos.StatusPaid},
+		{name: "partial payment", amounts: []float64{100}, expectedStatus: vos.StatusPartiallyPaid, expectedBalance: 150},
+		{name: "split payments", amounts: []float64{100, 150}, expectedStatus: vos.StatusPaid},
+		{name: "declined payment", amounts: []float64{250}, paymentStatus: vos.PaymentDeclined, expectedStatus: vos.StatusPending, expectedBalance: 250},
+		{name: "payment above balance", amounts: []float64{250.01}, expectedStatus: vos.StatusPending, expectedBalance: 250, expectedErr: ErrPaymentExceedsBalance},
+		{name: "payment in another currency", amounts: []float64{250}, currency: vos.CurrencyUSD, expectedStatus: vos.StatusPending, expectedBalance: 250, expectedErr: ErrPaymentCurrencyMismatch},
+		{name: "canceled order", status: vos.StatusCanceled, amounts: []float64{250}, expectedStatus: vos.StatusCanceled, expectedBalance: 250, expectedErr: ErrOrderNotPayable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := newOrderFixture(t)
+			if tt.status != "" {
+				order.Status = tt.status
+			}
+
+			currency, paymentStatus := vos.CurrencyBRL, vos.PaymentApproved
+			if tt.currency != "" {
+				currency = tt.currency
+			}
+			if tt.paymentStatus != "" {
+				paymentStatus = tt.paymentStatus
+			}
+
+			var err error
+			for _, amount := range tt.amounts {
+				if err = order.RegisterPayment(newPaymentFixture(t, order, amount, currency, paymentStatus)); err != nil {
+					break
+				}
+			}
+
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("RegisterPayment() error = %v, want %v", err, tt.expectedErr)
+			}
+
+			if order.Status != tt.expectedStatus || order.Balance() != tt.expectedBalance {
+				t.Errorf("order = %s with balance %v, want %s with %v", order.Status, order.Balance(), tt.expectedStatus, tt.expectedBalance)
+			}
+		})
+	}
+}
+
+func TestOrderRefund(t *testing.T) {
+	tests := []struct {
+		name             string
+		payments         []float64
+		refunds          []float64
+		expectedStatus   vos.Status
+		expectedRefunded []float64
+		expectedErr      error
+	}{
+		{name: "full refund", payments: []float64{250}, refunds: []float64{250}, expectedStatus: vos.StatusRefunded, expectedRefunded: []float64{250}},
+		{name: "partial refund", payments: []float64{250}, refunds: []float64{100}, expectedStatus: vos.StatusPartiallyRefunded, expectedRefunded: []float64{100}},
+		{name: "newest payment refunded first", payments: []float64{100, 150}, refunds: []float64{200}, expectedStatus: vos.StatusPartiallyRefunded, expectedRefunded: []float64{50, 150}},
+		{name: "refund above paid", payments: []float64{250}, refunds: []float64{250.01}, expectedStatus: vos.StatusPaid, expectedRefunded: []float64{0}, expectedErr: ErrRefundExceedsPaid},
+		{name: "unpaid order", refunds: []float64{10}, expectedStatus: vos.StatusPending, expectedErr: ErrOrderNotRefundable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := newOrderFixture(t)
+			for _, amount := range tt.payments {
+				if err := order.RegisterPayment(newPaymentFixture(t, order, amount, vos.CurrencyBRL, vos.PaymentApproved)); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var err error
+			for _, amount := range tt.refunds {
+				if _, err = order.Refund(newUUID(t), amount); err != nil {
+					break
+				}
+			}
+
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("Refund() error = %v, want %v", err, tt.expectedErr)
+			}
+
+			if order.Status != tt.expectedStatus {
+				t.Errorf("status = %s, want %s", order.Status, tt.expectedStatus)
+			}
+
+			for i, payment := range order.Payments {
+				if payment.RefundedAmount != tt.expectedRefunded[i] {
+					t.Errorf("payment %d refunded = %v, want %v", i, payment.RefundedAmount, tt.expectedRefunded[i])
+				}
+			}
+		})
+	}
+}
+
+func TestOrderStatusChanges(t *testing.T) {
+	order := NewOrder()
+	if changes := order.StatusChanges(); len(changes) != 1 || changes[0].From != "" || changes[0].To != vos.StatusPending {
+		t.Fatalf("status changes = %+v, want the initial PENDING entry", changes)
+	}
+	order.ClearStatusChanges()
+
+	order.changeStatus(vos.StatusPaid, "reason")
+	order.changeStatus(vos.StatusPaid, "reason")
+
+	changes := order.StatusChanges()
+	if len(changes) != 1 || changes[0].From != vos.StatusPending || changes[0].To != vos.StatusPaid || changes[0].Reason != "reason" {
+		t.Errorf("status changes = %+v, want one change from PENDING to PAID", changes)
+	}
+}
+
+func TestOrderUpdateFulfillment(t *testing.T) {
+	tests := []struct {
+		name           string
+		status         vos.Status
+		shipped        [][]uint
+		shipmentStatus vos.ShipmentStatus
+		expectedStatus vos.Status
+	}{
+		{name: "some units shipped", status: vos.StatusPaid, shipped: [][]uint{{1, 0}}, shipmentStatus: vos.ShipmentShipped, expectedStatus: vos.StatusPartiallyShipped},
+		{name: "every unit shipped across shipments", status: vos.StatusPartiallyShipped, shipped: [][]uint{{1, 1}, {1, 0}}, shipmentStatus: vos.ShipmentInTransit, expectedStatus: vos.StatusShipped},
+		{name: "every shipment delivered", status: vos.StatusShipped, shipped: [][]uint{{2, 0}, {0, 1}}, shipmentStatus: vos.ShipmentDelivered, expectedStatus: vos.StatusDelivered},
+		{name: "refunded order keeps its status", status: vos.StatusPartiallyRefunded, shipped: [][]uint{{2, 1}}, shipmentStatus: vos.ShipmentDelivered, expectedStatus: vos.StatusPartiallyRefunded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := newOrderFixture(t)
+			order.Status = tt.status
+			order.ClearStatusChanges()
+
+			var shipments []*Shipment
+			for _, quantities := range tt.shipped {
+				shipment := NewShipment(order.ID, "carrier", "tracking")
+				shipment.ID = newUUID(t)
+				shipment.Status = tt.shipmentStatus
+				for i, quantity := range quantities {
+					if quantity > 0 {
+						shipment.Lines = append(shipment.Lines, NewShipmentLine(shipment.ID, order.Items[i].ID, quantity))
+					}
+				}
+				shipments = append(shipments, shipment)
+			}
+
+			order.UpdateFulfillment(shipments)
+			if order.Status != tt.expectedStatus {
+				t.Errorf("status = %s, want %s", order.Status, tt.expectedStatus)
+			}
+
+			changed := tt.status != tt.expectedStatus
+			if changed != (len(order.StatusChanges()) == 1) || changed != (len(order.Events()) == 1) {
+				t.Errorf("status changes = %d, events = %d; want one of each only when the status changed", len(order.StatusChanges()), len(order.Events()))
+			}
+		})
+	}
+}
@@ -10,21 +10,29 @@ import (
 
 type Outbox struct {
 	entity.Base
+	AggregateID  vos.UUID
 	EventName    string
 	WasPublished bool
 	PublishedAt  vos.NullableTime
+	SkippedAt    vos.NullableTime
 	Payload      string
 }
 
-func NewOutbox(id vos.UUID, eventName string, payload any) (*Outbox, error) {
+func NewOutbox(aggregateID vos.UUID, eventName string, payload any) (*Outbox, error) {
+	id, err := vos.NewUUID()
+	if err != nil {
+		return nil, err
+	}
+
 	jsonPayload, err := json.Marshal(payload)
 	if err != nil {
 		return nil, err
 	}
 
 	return &Outbox{
-		EventName: eventName,
-		Payload:   string(jsonPayload),
+		AggregateID: aggregateID,
+		EventName:   eventName,
+		Payload:     string(jsonPayload),
 		Base: entity.Base{
 			ID:        id,
 			CreatedAt: time.Now().UTC(),
@@ -37,3 +45,22 @@ func (o *Outbox) MarkAsPublished() *Outbox {
 	o.PublishedAt = vos.NewNullableTime(time.Now().UTC())
 	return o
 }
+
+func (o *Outbox) Requeue() *Outbox {
+	o.WasPublished = false
+	o.PublishedAt = vos.NullableTime{}
+	o.SkippedAt = vos.NullableTime{}
+	return o
+}
+
+func (o *Outbox) Skip() *Outbox {
+	o.SkippedAt = vos.NewNullableTime(time.Now().UTC())
+	return o
+}
+
+func (o *Outbox) Key() []byte {
+	if o.AggregateID.IsEmpty() {
+		return []byte(o.ID.String())
+	}
+	return []byte(o.AggregateID.String())
+}
@@ -0,0 +1,65 @@
+package entities
+
+import (
+	"errors"
+	"time"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/vos"
+	"github.com/jailtonjunior94/order/pkg/entity"
+	sharedVos "github.com/jailtonjunior94/order/pkg/vos"
+)
+
+var (
+	ErrInvalidPaymentAmount    = errors.New("payment amount must be positive")
+	ErrInvalidPaymentMethod    = errors.New("invalid payment method")
+	ErrInvalidPaymentStatus    = errors.New("invalid payment status")
+	ErrPaymentCurrencyMismatch = errors.New("payment currency does not match the order currency")
+)
+
+type Payment struct {
+	entity.Base
+	OrderID           sharedVos.UUID
+	Amount            float64
+	Currency          vos.Currency
+	Method            vos.PaymentMethod
+	ProviderReference string
+	Status            vos.PaymentStatus
+	RefundedAmount    float64
+}
+
+func NewPayment(orderID sharedVos.UUID, amount float64, currency vos.Currency, method vos.PaymentMethod, providerReference string, status vos.PaymentStatus) *Payment {
+	return &Payment{
+		OrderID:           orderID,
+		Amount:            roundMoney(amount),
+		Currency:          currency,
+		Method:            method,
+		ProviderReference: providerReference,
+		Status:            status,
+		Base: entity.Base{
+			CreatedAt: time.Now().UTC(),
+		},
+	}
+}
+
+func (p *Payment) Validate() error {
+	switch {
+	case p.Amount <= 0:
+		return ErrInvalidPaymentAmount
+	case !p.Method.IsValid():
+		return ErrInvalidPaymentMethod
+	case !p.Status.IsValid():
+		return ErrInvalidPaymentStatus
+	}
+	return nil
+}
+
+func (p *Payment) IsApproved() bool {
+	return p.Status == vos.PaymentApproved
+}
+
+func (p *Payment) Refundable() float64 {
+	if !p.IsApproved() {
+		return 0
+	}
+	return roundMoney(p.Amount - p.RefundedAmount)
+}
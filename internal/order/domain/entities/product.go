@@ -0,0 +1,17 @@
+package entities
+
+import "github.com/jailtonjunior94/order/internal/order/domain/vos"
+
+// Product is the catalog snapshot of a sku; weight is in kilograms and
+// dimensions in centimeters, zero when the catalog does not report them.
+type Product struct {
+	SKU         string
+	Name        string
+	Price       float64
+	Currency    vos.Currency
+	TaxCategory string
+	Weight      float64
+	Length      float64
+	Width       float64
+	Height      float64
+}
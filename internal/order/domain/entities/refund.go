@@ -0,0 +1,28 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/jailtonjunior94/order/pkg/entity"
+	sharedVos "github.com/jailtonjunior94/order/pkg/vos"
+)
+
+type Refund struct {
+	entity.Base
+	OrderID   sharedVos.UUID
+	PaymentID sharedVos.UUID
+	ReturnID  sharedVos.UUID
+	Amount    float64
+}
+
+func NewRefund(orderID, paymentID, returnID sharedVos.UUID, amount float64) *Refund {
+	return &Refund{
+		OrderID:   orderID,
+		PaymentID: paymentID,
+		ReturnID:  returnID,
+		Amount:    roundMoney(amount),
+		Base: entity.Base{
+			CreatedAt: time.Now().UTC(),
+		},
+	}
+}
@@ -0,0 +1,137 @@
+package entities
+
+import (
+	"errors"
+	"time"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/events"
+	"github.com/jailtonjunior94/order/internal/order/domain/vos"
+	"github.com/jailtonjunior94/order/pkg/entity"
+	sharedVos "github.com/jailtonjunior94/order/pkg/vos"
+)
+
+var (
+	ErrInvalidShipment          = errors.New("shipment requires a carrier and a tracking number")
+	ErrShipmentWithoutLines     = errors.New("shipment must have at least one line")
+	ErrInvalidShipmentLine      = errors.New("shipment line requires an order item and a positive quantity")
+	ErrShipmentQuantityExceeded = errors.New("shipment quantity exceeds the quantity left to ship")
+	ErrInvalidShipmentStatus    = errors.New("shipment status must be one of SHIPPED, IN_TRANSIT, EXCEPTION or DELIVERED")
+)
+
+type (
+	// Shipment is a parcel handed to a carrier with some of the order lines;
+	// an order may be split across several shipments.
+	Shipment struct {
+		entity.Base
+		entity.AggregateRoot
+		OrderID           sharedVos.UUID
+		Carrier           string
+		TrackingNumber    string
+		Status            vos.ShipmentStatus
+		StatusDescription string
+		DeliveredAt       sharedVos.NullableTime
+		Lines             []*ShipmentLine
+	}
+
+	ShipmentLine struct {
+		entity.Base
+		ShipmentID  sharedVos.UUID
+		OrderItemID sharedVos.UUID
+		Quantity    uint
+	}
+)
+
+func NewShipment(orderID sharedVos.UUID, carrier, trackingNumber string) *Shipment {
+	return &Shipment{
+		OrderID:        orderID,
+		Carrier:        carrier,
+		TrackingNumber: trackingNumber,
+		Status:         vos.ShipmentShipped,
+		Base: entity.Base{
+			CreatedAt: time.Now().UTC(),
+		},
+	}
+}
+
+func NewShipmentLine(shipmentID, orderItemID sharedVos.UUID, quantity uint) *ShipmentLine {
+	return &ShipmentLine{
+		ShipmentID:  shipmentID,
+		OrderItemID: orderItemID,
+		Quantity:    quantity,
+		Base: entity.Base{
+			CreatedAt: time.Now().UTC(),
+		},
+	}
+}
+
+func (s *Shipment) IsDelivered() bool {
+	return s.Status == vos.ShipmentDelivered
+}
+
+func (s *Shipment) Ship() error {
+	if s.Carrier == "" || s.TrackingNumber == "" {
+		return ErrInvalidShipment
+	}
+
+	if len(s.Lines) == 0 {
+		return ErrShipmentWithoutLines
+	}
+
+	lines := make([]*events.ShipmentLine, 0, len(s.Lines))
+	for _, line := range s.Lines {
+		lines = append(lines, &events.ShipmentLine{
+			OrderItemID: line.OrderItemID.String(),
+			Quantity:    line.Quantity,
+		})
+	}
+
+	s.AddEvent(events.NewShipmentCreatedEvent(s.OrderID, &events.ShipmentCreated{
+		ShipmentID:     s.ID.String(),
+		Carrier:        s.Carrier,
+		TrackingNumber: s.TrackingNumber,
+		Lines:          lines,
+	}))
+	return nil
+}
+
+// UpdateStatus applies a carrier update and reports whether it changed the
+// shipment; repeated or stale updates are ignored.
+func (s *Shipment) UpdateStatus(status vos.ShipmentStatus, description string, occurredAt time.Time) (bool, error) {
+	if !status.IsValid() {
+		return false, ErrInvalidShipmentStatus
+	}
+
+	if !status.Follows(s.Status) {
+		return false, nil
+	}
+
+	from := s.Status
+	s.Status = status
+	s.StatusDescription = description
+	s.UpdatedAt = sharedVos.NewNullableTime(time.Now().UTC())
+	if status == vos.ShipmentDelivered {
+		s.DeliveredAt = sharedVos.NewNullableTime(occurredAt)
+	}
+
+	s.AddEvent(events.NewShipmentStatusChangedEvent(s.OrderID, &events.ShipmentStatusChanged{
+		ShipmentID:     s.ID.String(),
+		Carrier:        s.Carrier,
+		TrackingNumber: s.TrackingNumber,
+		From:           from.String(),
+		Status:         status.String(),
+		Description:    description,
+		OccurredAt:     occurredAt,
+	}))
+	return true, nil
+}
+
+// ShippedQuantities sums the units of every order item across shipments.
+func ShippedQuantities(shipments []*Shipment) map[sharedVos.UUID]uint {
+	shipped := make(map[sharedVos.UUID]uint)
+	for _, shipment := range shipments {
+		for _, line := range shipment.Lines {
+			shipped[line.OrderItemID] += line.Quantity
+		}
+	}
+	return shipped
+}
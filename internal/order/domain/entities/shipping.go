@@ -0,0 +1,56 @@
+package entities
+
+import (
+	"errors"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/vos"
+)
+
+var (
+	ErrShippingAddressRequired = errors.New("shipping address is required to quote shipping")
+	ErrShippingOptionNotFound  = errors.New("shipping option is not available for this order")
+	ErrShippingOptionRequired  = errors.New("shipping option is required")
+)
+
+type (
+	// ShippingPackage is one order line as the carrier sees it; weight is per
+	// unit in kilograms and dimensions are per unit in centimeters.
+	ShippingPackage struct {
+		SKU      string
+		Quantity uint
+		Weight   float64
+		Length   float64
+		Width    float64
+		Height   float64
+	}
+
+	// ShippingRequest asks for the options that deliver packages to
+	// destination; Subtotal is the merchandise value before discounts, so
+	// the quote shown before the order exists holds once a coupon applies.
+	ShippingRequest struct {
+		Destination *vos.Address
+		Currency    vos.Currency
+		Subtotal    float64
+		Packages    []*ShippingPackage
+	}
+
+	ShippingOption struct {
+		Code          string
+		Carrier       string
+		Service       string
+		Amount        float64
+		Currency      vos.Currency
+		EstimatedDays int
+	}
+)
+
+// FindShippingOption returns the option quoted under code in currency, or nil
+// when none was.
+func FindShippingOption(options []*ShippingOption, code string, currency vos.Currency) *ShippingOption {
+	for _, option := range options {
+		if option.Code == code && option.Currency == currency {
+			return option
+		}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/jailtonjunior94/order/pkg/entity"
+	"github.com/jailtonjunior94/order/pkg/vos"
+)
+
+type (
+	TaxRule struct {
+		Region       string
+		Jurisdiction string
+		Category     string
+		Rate         float64
+	}
+
+	OrderItemTax struct {
+		entity.Base
+		OrderID      vos.UUID
+		OrderItemID  vos.UUID
+		Jurisdiction string
+		Rate         float64
+		Amount       float64
+	}
+
+	// TaxAssessment is the outcome of a tax calculation; Inclusive reports
+	// whether the line amounts already contain the tax.
+	TaxAssessment struct {
+		Inclusive bool
+		Lines     []*OrderItemTax
+	}
+)
+
+func NewOrderItemTax(orderID, orderItemID vos.UUID, jurisdiction string, rate, amount float64) *OrderItemTax {
+	return &OrderItemTax{
+		OrderID:      orderID,
+		OrderItemID:  orderItemID,
+		Jurisdiction: jurisdiction,
+		Rate:         rate,
+		Amount:       amount,
+		Base: entity.Base{
+			CreatedAt: time.Now().UTC(),
+		},
+	}
+}
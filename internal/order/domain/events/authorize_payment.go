@@ -0,0 +1,32 @@
+package events
+
+import (
+	sharedEvents "github.com/jailtonjunior94/order/pkg/events"
+	sharedVos "github.com/jailtonjunior94/order/pkg/vos"
+)
+
+const AuthorizePaymentCommand = "authorize_payment"
+
+// AuthorizePayment is answered with the Step it was sent with, so the saga
+// can tell replies to a superseded command apart.
+type AuthorizePayment struct {
+	SagaID   string  `json:"saga_id"`
+	OrderID  string  `json:"order_id"`
+	Step     int     `json:"step"`
+	Amount   float64 `json:"amount"`
+	Currency string  `json:"currency"`
+}
+
+func NewAuthorizePayment(sagaID, orderID string, step int, amount float64, currency string) *AuthorizePayment {
+	return &AuthorizePayment{
+		SagaID:   sagaID,
+		OrderID:  orderID,
+		Step:     step,
+		Amount:   amount,
+		Currency: currency,
+	}
+}
+
+func NewAuthorizePaymentCommand(sagaID, orderID sharedVos.UUID, step int, amount float64, currency string) sharedEvents.Event {
+	return sharedEvents.NewEvent(AuthorizePaymentCommand, orderID, NewAuthorizePayment(sagaID.String(), orderID.String(), step, amount, currency))
+}
@@ -0,0 +1,27 @@
+package events
+
+import (
+	"github.com/jailtonjunior94/order/internal/order/domain/vos"
+	sharedEvents "github.com/jailtonjunior94/order/pkg/events"
+	sharedVos "github.com/jailtonjunior94/order/pkg/vos"
+)
+
+const OrderCanceledEvent = "order_canceled"
+
+type OrderCanceled struct {
+	OrderID string `json:"order_id"`
+	Reason  string `json:"reason"`
+	Status  string `json:"status"`
+}
+
+func NewOrderCanceled(orderID string, reason string) *OrderCanceled {
+	return &OrderCanceled{
+		OrderID: orderID,
+		Reason:  reason,
+		Status:  vos.StatusCanceled.String(),
+	}
+}
+
+func NewOrderCanceledEvent(orderID sharedVos.UUID, reason string) sharedEvents.Event {
+	return sharedEvents.NewEvent(OrderCanceledEvent, orderID, NewOrderCanceled(orderID.String(), reason))
+}
@@ -0,0 +1,19 @@
+package events
+
+import (
+	sharedEvents "github.com/jailtonjunior94/order/pkg/events"
+	sharedVos "github.com/jailtonjunior94/order/pkg/vos"
+)
+
+const OrderFulfillmentChangedEvent = "order_fulfillment_changed"
+
+type OrderFulfillmentChanged struct {
+	OrderID   string `json:"order_id"`
+	Status    string `json:"status"`
+	Shipments int    `json:"shipments"`
+}
+
+func NewOrderFulfillmentChangedEvent(orderID sharedVos.UUID, payload *OrderFulfillmentChanged) sharedEvents.Event {
+	payload.OrderID = orderID.String()
+	return sharedEvents.NewEvent(OrderFulfillmentChangedEvent, orderID, payload)
+}
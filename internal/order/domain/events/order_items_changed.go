@@ -0,0 +1,35 @@
+package events
+
+import (
+	sharedEvents "github.com/jailtonjunior94/order/pkg/events"
+	sharedVos "github.com/jailtonjunior94/order/pkg/vos"
+)
+
+const OrderItemsChangedEvent = "order_items_changed"
+
+type (
+	OrderItemsChanged struct {
+		OrderID  string                   `json:"order_id"`
+		Currency string                   `json:"currency"`
+		Items    []*OrderItemsChangedItem `json:"items"`
+		Subtotal float64                  `json:"subtotal"`
+		Discount float64                  `json:"discount"`
+		Tax      float64                  `json:"tax"`
+		Shipping float64                  `json:"shipping"`
+		Total    float64                  `json:"total"`
+	}
+
+	OrderItemsChangedItem struct {
+		OrderItemID string  `json:"order_item_id"`
+		SKU         string  `json:"sku"`
+		Quantity    uint    `json:"quantity"`
+		Price       float64 `json:"price"`
+		Discount    float64 `json:"discount"`
+		Tax         float64 `json:"tax"`
+	}
+)
+
+func NewOrderItemsChangedEvent(orderID sharedVos.UUID, payload *OrderItemsChanged) sharedEvents.Event {
+	payload.OrderID = orderID.String()
+	return sharedEvents.NewEvent(OrderItemsChangedEvent, orderID, payload)
+}
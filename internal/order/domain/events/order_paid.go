@@ -1,17 +1,75 @@
 package events
 
-import "github.com/jailtonjunior94/order/internal/order/domain/vos"
+import (
+	"github.com/jailtonjunior94/order/internal/order/domain/vos"
+	sharedEvents "github.com/jailtonjunior94/order/pkg/events"
+	sharedVos "github.com/jailtonjunior94/order/pkg/vos"
+)
 
-type OrderPaid struct {
-	OrderID string  `json:"order_id"`
-	Amount  float64 `json:"amount"`
-	Status  string  `json:"status"`
-}
+const OrderPaidEvent = "order_paid"
+
+type (
+	OrderPaid struct {
+		OrderID   string           `json:"order_id"`
+		Currency  string           `json:"currency"`
+		Subtotal  float64          `json:"subtotal"`
+		Discount  float64          `json:"discount"`
+		Tax       float64          `json:"tax"`
+		Shipping  float64          `json:"shipping"`
+		Taxes     []*OrderPaidTax  `json:"taxes,omitempty"`
+		Amount    float64          `json:"amount"`
+		Reporting *ReportingAmount `json:"reporting"`
+		Status    string           `json:"status"`
+	}
+
+	// ReportingAmount is the order amount converted with the exchange rate
+	// snapshotted when the order was created.
+	ReportingAmount struct {
+		Currency     string  `json:"currency"`
+		ExchangeRate float64 `json:"exchange_rate"`
+		Amount       float64 `json:"amount"`
+	}
+
+	OrderPaidTax struct {
+		Jurisdiction string  `json:"jurisdiction"`
+		Amount       float64 `json:"amount"`
+	}
+)
 
-func NewOrderPaid(orderID string, amount float64) *OrderPaid {
+func NewOrderPaid(
+	orderID, currency string,
+	subtotal, discount, tax, shipping, amount float64,
+	taxes []*OrderPaidTax,
+	reporting *ReportingAmount,
+) *OrderPaid {
 	return &OrderPaid{
-		OrderID: orderID,
-		Amount:  amount,
-		Status:  vos.StatusPaid.String(),
+		OrderID:   orderID,
+		Currency:  currency,
+		Subtotal:  subtotal,
+		Discount:  discount,
+		Tax:       tax,
+		Shipping:  shipping,
+		Taxes:     taxes,
+		Amount:    amount,
+		Reporting: reporting,
+		Status:    vos.StatusPaid.String(),
+	}
+}
+
+func NewReportingAmount(currency string, exchangeRate, amount float64) *ReportingAmount {
+	return &ReportingAmount{
+		Currency:     currency,
+		ExchangeRate: exchangeRate,
+		Amount:       amount,
 	}
 }
+
+func NewOrderPaidEvent(
+	orderID sharedVos.UUID,
+	currency string,
+	subtotal, discount, tax, shipping, amount float64,
+	taxes []*OrderPaidTax,
+	reporting *ReportingAmount,
+) sharedEvents.Event {
+	return sharedEvents.NewEvent(OrderPaidEvent, orderID, NewOrderPaid(orderID.String(), currency, subtotal, discount, tax, shipping, amount, taxes, reporting))
+}
@@ -0,0 +1,26 @@
+package events
+
+import (
+	sharedEvents "github.com/jailtonjunior94/order/pkg/events"
+	sharedVos "github.com/jailtonjunior94/order/pkg/vos"
+)
+
+const OrderPaymentDeclinedEvent = "order_payment_declined"
+
+type OrderPaymentDeclined struct {
+	OrderID   string `json:"order_id"`
+	PaymentID string `json:"payment_id"`
+	Reason    string `json:"reason"`
+}
+
+func NewOrderPaymentDeclined(orderID, paymentID, reason string) *OrderPaymentDeclined {
+	return &OrderPaymentDeclined{
+		OrderID:   orderID,
+		PaymentID: paymentID,
+		Reason:    reason,
+	}
+}
+
+func NewOrderPaymentDeclinedEvent(orderID sharedVos.UUID, paymentID, reason string) sharedEvents.Event {
+	return sharedEvents.NewEvent(OrderPaymentDeclinedEvent, orderID, NewOrderPaymentDeclined(orderID.String(), paymentID, reason))
+}
@@ -0,0 +1,26 @@
+package events
+
+import (
+	sharedEvents "github.com/jailtonjunior94/order/pkg/events"
+	sharedVos "github.com/jailtonjunior94/order/pkg/vos"
+)
+
+const OrderPaymentRegisteredEvent = "order_payment_registered"
+
+type OrderPaymentRegistered struct {
+	OrderID           string  `json:"order_id"`
+	PaymentID         string  `json:"payment_id"`
+	Amount            float64 `json:"amount"`
+	Currency          string  `json:"currency"`
+	Method            string  `json:"method"`
+	ProviderReference string  `json:"provider_reference,omitempty"`
+	PaymentStatus     string  `json:"payment_status"`
+	PaidAmount        float64 `json:"paid_amount"`
+	Balance           float64 `json:"balance"`
+	Status            string  `json:"status"`
+}
+
+func NewOrderPaymentRegisteredEvent(orderID sharedVos.UUID, payload *OrderPaymentRegistered) sharedEvents.Event {
+	payload.OrderID = orderID.String()
+	return sharedEvents.NewEvent(OrderPaymentRegisteredEvent, orderID, payload)
+}
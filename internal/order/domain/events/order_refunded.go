@@ -0,0 +1,30 @@
+package events
+
+import (
+	sharedEvents "github.com/jailtonjunior94/order/pkg/events"
+	sharedVos "github.com/jailtonjunior94/order/pkg/vos"
+)
+
+const OrderRefundedEvent = "order_refunded"
+
+type (
+	OrderRefunded struct {
+		OrderID        string                 `json:"order_id"`
+		ReturnID       string                 `json:"return_id,omitempty"`
+		Currency       string                 `json:"currency"`
+		Amount         float64                `json:"amount"`
+		RefundedAmount float64                `json:"refunded_amount"`
+		Refunds        []*OrderRefundedRefund `json:"refunds"`
+		Status         string                 `json:"status"`
+	}
+
+	OrderRefundedRefund struct {
+		PaymentID string  `json:"payment_id"`
+		Amount    float64 `json:"amount"`
+	}
+)
+
+func NewOrderRefundedEvent(orderID sharedVos.UUID, payload *OrderRefunded) sharedEvents.Event {
+	payload.OrderID = orderID.String()
+	return sharedEvents.NewEvent(OrderRefundedEvent, orderID, payload)
+}
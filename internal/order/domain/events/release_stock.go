@@ -0,0 +1,28 @@
+package events
+
+import (
+	sharedEvents "github.com/jailtonjunior94/order/pkg/events"
+	sharedVos "github.com/jailtonjunior94/order/pkg/vos"
+)
+
+const ReleaseStockCommand = "release_stock"
+
+type ReleaseStock struct {
+	SagaID        string `json:"saga_id"`
+	OrderID       string `json:"order_id"`
+	ReservationID string `json:"reservation_id,omitempty"`
+	Reason        string `json:"reason"`
+}
+
+func NewReleaseStock(sagaID, orderID, reservationID, reason string) *ReleaseStock {
+	return &ReleaseStock{
+		SagaID:        sagaID,
+		OrderID:       orderID,
+		ReservationID: reservationID,
+		Reason:        reason,
+	}
+}
+
+func NewReleaseStockCommand(sagaID, orderID sharedVos.UUID, reservationID, reason string) sharedEvents.Event {
+	return sharedEvents.NewEvent(ReleaseStockCommand, orderID, NewReleaseStock(sagaID.String(), orderID.String(), reservationID, reason))
+}
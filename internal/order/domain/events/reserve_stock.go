@@ -0,0 +1,37 @@
+package events
+
+import (
+	sharedEvents "github.com/jailtonjunior94/order/pkg/events"
+	sharedVos "github.com/jailtonjunior94/order/pkg/vos"
+)
+
+const ReserveStockCommand = "reserve_stock"
+
+type (
+	// ReserveStock is answered with the Step it was sent with, so the saga
+	// can tell replies to a superseded command apart.
+	ReserveStock struct {
+		SagaID  string              `json:"saga_id"`
+		OrderID string              `json:"order_id"`
+		Step    int                 `json:"step"`
+		Items   []*ReserveStockItem `json:"items"`
+	}
+
+	ReserveStockItem struct {
+		SKU      string `json:"sku"`
+		Quantity uint   `json:"quantity"`
+	}
+)
+
+func NewReserveStock(sagaID, orderID string, step int, items []*ReserveStockItem) *ReserveStock {
+	return &ReserveStock{
+		SagaID:  sagaID,
+		OrderID: orderID,
+		Step:    step,
+		Items:   items,
+	}
+}
+
+func NewReserveStockCommand(sagaID, orderID sharedVos.UUID, step int, items []*ReserveStockItem) sharedEvents.Event {
+	return sharedEvents.NewEvent(ReserveStockCommand, orderID, NewReserveStock(sagaID.String(), orderID.String(), step, items))
+}
@@ -0,0 +1,26 @@
+package events
+
+import (
+	sharedEvents "github.com/jailtonjunior94/order/pkg/events"
+	sharedVos "github.com/jailtonjunior94/order/pkg/vos"
+)
+
+const ReturnApprovedEvent = "return_approved"
+
+type ReturnApproved struct {
+	ReturnID string  `json:"return_id"`
+	OrderID  string  `json:"order_id"`
+	Amount   float64 `json:"amount"`
+}
+
+func NewReturnApproved(returnID, orderID string, amount float64) *ReturnApproved {
+	return &ReturnApproved{
+		ReturnID: returnID,
+		OrderID:  orderID,
+		Amount:   amount,
+	}
+}
+
+func NewReturnApprovedEvent(returnID, orderID sharedVos.UUID, amount float64) sharedEvents.Event {
+	return sharedEvents.NewEvent(ReturnApprovedEvent, orderID, NewReturnApproved(returnID.String(), orderID.String(), amount))
+}
@@ -0,0 +1,26 @@
+package events
+
+import (
+	sharedEvents "github.com/jailtonjunior94/order/pkg/events"
+	sharedVos "github.com/jailtonjunior94/order/pkg/vos"
+)
+
+const ReturnRefundedEvent = "return_refunded"
+
+type ReturnRefunded struct {
+	ReturnID string  `json:"return_id"`
+	OrderID  string  `json:"order_id"`
+	Amount   float64 `json:"amount"`
+}
+
+func NewReturnRefunded(returnID, orderID string, amount float64) *ReturnRefunded {
+	return &ReturnRefunded{
+		ReturnID: returnID,
+		OrderID:  orderID,
+		Amount:   amount,
+	}
+}
+
+func NewReturnRefundedEvent(returnID, orderID sharedVos.UUID, amount float64) sharedEvents.Event {
+	return sharedEvents.NewEvent(ReturnRefundedEvent, orderID, NewReturnRefunded(returnID.String(), orderID.String(), amount))
+}
@@ -0,0 +1,26 @@
+package events
+
+import (
+	sharedEvents "github.com/jailtonjunior94/order/pkg/events"
+	sharedVos "github.com/jailtonjunior94/order/pkg/vos"
+)
+
+const ReturnRejectedEvent = "return_rejected"
+
+type ReturnRejected struct {
+	ReturnID string `json:"return_id"`
+	OrderID  string `json:"order_id"`
+	Reason   string `json:"reason"`
+}
+
+func NewReturnRejected(returnID, orderID, reason string) *ReturnRejected {
+	return &ReturnRejected{
+		ReturnID: returnID,
+		OrderID:  orderID,
+		Reason:   reason,
+	}
+}
+
+func NewReturnRejectedEvent(returnID, orderID sharedVos.UUID, reason string) sharedEvents.Event {
+	return sharedEvents.NewEvent(ReturnRejectedEvent, orderID, NewReturnRejected(returnID.String(), orderID.String(), reason))
+}
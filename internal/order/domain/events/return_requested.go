@@ -0,0 +1,38 @@
+package events
+
+import (
+	sharedEvents "github.com/jailtonjunior94/order/pkg/events"
+	sharedVos "github.com/jailtonjunior94/order/pkg/vos"
+)
+
+const ReturnRequestedEvent = "return_requested"
+
+type (
+	ReturnRequested struct {
+		ReturnID string        `json:"return_id"`
+		OrderID  string        `json:"order_id"`
+		Reason   string        `json:"reason"`
+		Amount   float64       `json:"amount"`
+		Lines    []*ReturnLine `json:"lines"`
+	}
+
+	ReturnLine struct {
+		OrderItemID string  `json:"order_item_id"`
+		Quantity    uint    `json:"quantity"`
+		Amount      float64 `json:"amount"`
+	}
+)
+
+func NewReturnRequested(returnID, orderID, reason string, amount float64, lines []*ReturnLine) *ReturnRequested {
+	return &ReturnRequested{
+		ReturnID: returnID,
+		OrderID:  orderID,
+		Reason:   reason,
+		Amount:   amount,
+		Lines:    lines,
+	}
+}
+
+func NewReturnRequestedEvent(returnID, orderID sharedVos.UUID, reason string, amount float64, lines []*ReturnLine) sharedEvents.Event {
+	return sharedEvents.NewEvent(ReturnRequestedEvent, orderID, NewReturnRequested(returnID.String(), orderID.String(), reason, amount, lines))
+}
@@ -0,0 +1,28 @@
+package events
+
+import (
+	sharedEvents "github.com/jailtonjunior94/order/pkg/events"
+	sharedVos "github.com/jailtonjunior94/order/pkg/vos"
+)
+
+const ShipmentCreatedEvent = "shipment_created"
+
+type (
+	ShipmentCreated struct {
+		ShipmentID     string          `json:"shipment_id"`
+		OrderID        string          `json:"order_id"`
+		Carrier        string          `json:"carrier"`
+		TrackingNumber string          `json:"tracking_number"`
+		Lines          []*ShipmentLine `json:"lines"`
+	}
+
+	ShipmentLine struct {
+		OrderItemID string `json:"order_item_id"`
+		Quantity    uint   `json:"quantity"`
+	}
+)
+
+func NewShipmentCreatedEvent(orderID sharedVos.UUID, payload *ShipmentCreated) sharedEvents.Event {
+	payload.OrderID = orderID.String()
+	return sharedEvents.NewEvent(ShipmentCreatedEvent, orderID, payload)
+}
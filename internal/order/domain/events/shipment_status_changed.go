@@ -0,0 +1,26 @@
+package events
+
+import (
+	"time"
+
+	sharedEvents "github.com/jailtonjunior94/order/pkg/events"
+	sharedVos "github.com/jailtonjunior94/order/pkg/vos"
+)
+
+const ShipmentStatusChangedEvent = "shipment_status_changed"
+
+type ShipmentStatusChanged struct {
+	ShipmentID     string    `json:"shipment_id"`
+	OrderID        string    `json:"order_id"`
+	Carrier        string    `json:"carrier"`
+	TrackingNumber string    `json:"tracking_number"`
+	From           string    `json:"from"`
+	Status         string    `json:"status"`
+	Description    string    `json:"description,omitempty"`
+	OccurredAt     time.Time `json:"occurred_at"`
+}
+
+func NewShipmentStatusChangedEvent(orderID sharedVos.UUID, payload *ShipmentStatusChanged) sharedEvents.Event {
+	payload.OrderID = orderID.String()
+	return sharedEvents.NewEvent(ShipmentStatusChangedEvent, orderID, payload)
+}
@@ -0,0 +1,28 @@
+package events
+
+import (
+	sharedEvents "github.com/jailtonjunior94/order/pkg/events"
+	sharedVos "github.com/jailtonjunior94/order/pkg/vos"
+)
+
+const VoidPaymentCommand = "void_payment"
+
+type VoidPayment struct {
+	SagaID    string `json:"saga_id"`
+	OrderID   string `json:"order_id"`
+	PaymentID string `json:"payment_id"`
+	Reason    string `json:"reason"`
+}
+
+func NewVoidPayment(sagaID, orderID, paymentID, reason string) *VoidPayment {
+	return &VoidPayment{
+		SagaID:    sagaID,
+		OrderID:   orderID,
+		PaymentID: paymentID,
+		Reason:    reason,
+	}
+}
+
+func NewVoidPaymentCommand(sagaID, orderID sharedVos.UUID, paymentID, reason string) sharedEvents.Event {
+	return sharedEvents.NewEvent(VoidPaymentCommand, orderID, NewVoidPayment(sagaID.String(), orderID.String(), paymentID, reason))
+}
@@ -0,0 +1,85 @@
+package factories
+
+import (
+	"time"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/dtos"
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	"github.com/jailtonjunior94/order/internal/order/domain/vos"
+	sharedVos "github.com/jailtonjunior94/order/pkg/vos"
+)
+
+func CreateCoupon(input *dtos.CouponInput) (*entities.Coupon, error) {
+	if input == nil {
+		return nil, entities.ErrInvalidCoupon
+	}
+
+	couponID, err := sharedVos.NewUUID()
+	if err != nil {
+		return nil, err
+	}
+
+	startsAt := time.Now().UTC()
+	if input.StartsAt != nil {
+		startsAt = input.StartsAt.UTC()
+	}
+
+	coupon := entities.NewCoupon(input.Code, vos.CouponKind(input.Kind), input.Value, startsAt)
+	coupon.ID = couponID
+	coupon.SKU = input.SKU
+	coupon.BuyQuantity = input.BuyQuantity
+	coupon.GetQuantity = input.GetQuantity
+	coupon.MinimumSpend = input.MinimumSpend
+	coupon.MaxUses = input.MaxUses
+	coupon.MaxUsesPerCustomer = input.MaxUsesPerCustomer
+	if input.EndsAt != nil {
+		coupon.EndsAt = sharedVos.NewNullableTime(input.EndsAt.UTC())
+	}
+
+	if err := coupon.Validate(); err != nil {
+		return nil, err
+	}
+	return coupon, nil
+}
+
+// ApplyCoupon computes the coupon discounts for order, assigns their ids and
+// attaches them to the order lines.
+func ApplyCoupon(order *entities.Order, coupon *entities.Coupon, customerID string, customerUses int, now time.Time) error {
+	discounts, err := coupon.Discounts(order, customerID, customerUses, now)
+	if err != nil {
+		return err
+	}
+
+	for _, discount := range discounts {
+		discountID, err := sharedVos.NewUUID()
+		if err != nil {
+			return err
+		}
+		discount.ID = discountID
+	}
+
+	order.ApplyDiscounts(discounts)
+	coupon.Redeem()
+	return nil
+}
+
+// ReapplyCoupon prices the lines of an amended order again with the coupon it
+// redeemed; a nil coupon only clears stale discounts.
+func ReapplyCoupon(order *entities.Order, coupon *entities.Coupon) error {
+	order.ClearDiscounts()
+	if coupon == nil {
+		return nil
+	}
+
+	discounts := coupon.Rediscount(order)
+	for _, discount := range discounts {
+		discountID, err := sharedVos.NewUUID()
+		if err != nil {
+			return err
+		}
+		discount.ID = discountID
+	}
+
+	order.ApplyDiscounts(discounts)
+	return nil
+}
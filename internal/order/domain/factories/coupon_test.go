@@ -0,0 +1,147 @@
+package factories
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/dtos"
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	"github.com/jailtonjunior94/order/internal/order/domain/vos"
+	sharedVos "github.com/jailtonjunior94/order/pkg/vos"
+)
+
+func newOrder(t *testing.T) *entities.Order {
+	t.Helper()
+
+	order := entities.NewOrder()
+	order.Currency = vos.CurrencyBRL
+	order.AddItems([]*entities.OrderItem{
+		entities.NewOrderItem(order.ID, "SKU-1", "Keyboard", 100, 2),
+		entities.NewOrderItem(order.ID, "SKU-2", "Mouse", 50, 1),
+	})
+
+	for _, item := range order.Items {
+		id, err := sharedVos.NewUUID()
+		if err != nil {
+			t.Fatal(err)
+		}
+		item.ID = id
+	}
+	return order
+}
+
+func TestCreateCoupon(t *testing.T) {
+	startsAt := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+	endsAt := startsAt.Add(-time.Hour)
+
+	tests := []struct {
+		name        string
+		input       *dtos.CouponInput
+		expectedErr error
+	}{
+		{name: "percentage", input: &dtos.CouponInput{Code: " ten ", Kind: "PERCENTAGE", Value: 10}},
+		{name: "percentage above 100", input: &dtos.CouponInput{Code: "ALL", Kind: "PERCENTAGE", Value: 101}, expectedErr: entities.ErrInvalidCoupon},
+		{name: "fixed without value", input: &dtos.CouponInput{Code: "ZERO", Kind: "FIXED"}, expectedErr: entities.ErrInvalidCoupon},
+		{name: "buy x get y without sku", input: &dtos.CouponInput{Code: "BOGO", Kind: "BUY_X_GET_Y", BuyQuantity: 1, GetQuantity: 1}, expectedErr: entities.ErrInvalidCoupon},
+		{name: "unknown kind", input: &dtos.CouponInput{Code: "WHAT", Kind: "FREE", Value: 10}, expectedErr: entities.ErrInvalidCoupon},
+		{name: "ends before it starts", input: &dtos.CouponInput{Code: "PAST", Kind: "FIXED", Value: 10, StartsAt: &startsAt, EndsAt: &endsAt}, expectedErr: entities.ErrInvalidCoupon},
+		{name: "missing input", expectedErr: entities.ErrInvalidCoupon},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			coupon, err := CreateCoupon(tt.input)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("CreateCoupon() error = %v, want %v", err, tt.expectedErr)
+			}
+
+			if err == nil && coupon.Code != entities.NormalizeCouponCode(tt.input.Code) {
+				t.Errorf("code = %q, want it normalized", coupon.Code)
+			}
+		})
+	}
+}
+
+func TestApplyCoupon(t *testing.T) {
+	tests := []struct {
+		name             string
+		coupon           *entities.Coupon
+		expectedDiscount float64
+		expectedUses     int
+		expectedErr      error
+	}{
+		{
+			name:             "applicable coupon",
+			coupon:           entities.NewCoupon("TEN", vos.CouponPercentage, 10, time.Time{}),
+			expectedDiscount: 25,
+			expectedUses:     1,
+		},
+		{
+			name: "rejected coupon",
+			coupon: func() *entities.Coupon {
+				coupon := entities.NewCoupon("TEN", vos.CouponPercentage, 10, time.Time{})
+				coupon.Deactivate()
+				return coupon
+			}(),
+			expectedErr: entities.ErrCouponInactive,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := newOrder(t)
+
+			err := ApplyCoupon(order, tt.coupon, "", 0, time.Now())
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("ApplyCoupon() error = %v, want %v", err, tt.expectedErr)
+			}
+
+			if order.DiscountTotal() != tt.expectedDiscount || tt.coupon.Uses != tt.expectedUses {
+				t.Errorf("discount = %v, uses = %d; want %v, %d", order.DiscountTotal(), tt.coupon.Uses, tt.expectedDiscount, tt.expectedUses)
+			}
+
+			for _, discount := range order.Discounts {
+				if discount.ID == (sharedVos.UUID{}) {
+					t.Error("expected every discount to have an id")
+				}
+			}
+		})
+	}
+}
+
+func TestReapplyCoupon(t *testing.T) {
+	belowMinimumSpend := entities.NewCoupon("TEN", vos.CouponPercentage, 10, time.Time{})
+	belowMinimumSpend.MinimumSpend = 200
+
+	tests := []struct {
+		name             string
+		coupon           *entities.Coupon
+		expectedDiscount float64
+	}{
+		{name: "coupon follows the amended lines", coupon: entities.NewCoupon("TEN", vos.CouponPercentage, 10, time.Time{}), expectedDiscount: 15},
+		{name: "order below the minimum spend", coupon: belowMinimumSpend},
+		{name: "without a coupon"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := newOrder(t)
+			if err := ApplyCoupon(order, entities.NewCoupon("TEN", vos.CouponPercentage, 10, time.Time{}), "", 0, time.Now()); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := order.ChangeItemQuantity(order.Items[0].ID, 1); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := ReapplyCoupon(order, tt.coupon); err != nil {
+				t.Fatalf("ReapplyCoupon() error = %v", err)
+			}
+
+			if order.DiscountTotal() != tt.expectedDiscount {
+				t.Errorf("discount = %v, want %v", order.DiscountTotal(), tt.expectedDiscount)
+			}
+		})
+	}
+}
@@ -1,29 +1,197 @@
 package factories
 
 import (
+	"errors"
+	"fmt"
+	"strings"
+
 	"github.com/jailtonjunior94/order/internal/order/domain/dtos"
 	"github.com/jailtonjunior94/order/internal/order/domain/entities"
-	"github.com/jailtonjunior94/order/pkg/vos"
+	"github.com/jailtonjunior94/order/internal/order/domain/vos"
+	sharedVos "github.com/jailtonjunior94/order/pkg/vos"
+)
+
+var (
+	ErrOrderWithoutItems = errors.New("order must have at least one item")
+	ErrInvalidOrderItem  = errors.New("order item requires a sku and a positive quantity")
+	ErrUnknownProduct    = errors.New("unknown product")
+	ErrInvalidCurrency   = errors.New("currency must be one of BRL, USD or EUR")
+	ErrCurrencyMismatch  = errors.New("all order items must be priced in the order currency")
 )
 
-func CreateOrder(input *dtos.OrderInput) (*entities.Order, error) {
-	orderID, err := vos.NewUUID()
+// CreateOrder prices every item from products, the catalog snapshot resolved
+// for the requested skus, never from client input. Products the catalog
+// reports without a currency are priced in defaultCurrency. A requested
+// currency must match the products, since prices are never converted; without
+// one the order adopts the currency of its products.
+func CreateOrder(input *dtos.OrderInput, products map[string]*entities.Product, defaultCurrency vos.Currency) (*entities.Order, error) {
+	if err := ValidateOrderInput(input); err != nil {
+		return nil, err
+	}
+
+	orderID, err := sharedVos.NewUUID()
 	if err != nil {
 		return nil, err
 	}
 
 	order := entities.NewOrder()
 	order.ID = orderID
+	order.Currency = vos.NewCurrency(input.Currency)
+	order.TaxRegion = strings.ToUpper(strings.TrimSpace(input.Region))
+
+	shipping, err := newAddress(input.ShippingAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	billing, err := newAddress(input.BillingAddress)
+	if err != nil {
+		return nil, err
+	}
+	order.SetAddresses(shipping, billing)
 
 	for _, item := range input.Items {
-		orderItem := entities.NewOrderItem(order.ID, item.ProductName, item.Price, item.Quantity)
-		orderItemID, err := vos.NewUUID()
+		product, ok := products[item.SKU]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownProduct, item.SKU)
+		}
+
+		currency := priceCurrency(product, defaultCurrency)
+		if order.Currency == "" {
+			order.Currency = currency
+		}
+
+		if currency != order.Currency {
+			return nil, fmt.Errorf("%w: %s is priced in %s", ErrCurrencyMismatch, product.SKU, currency)
+		}
+
+		orderItem, err := newOrderItem(order, product, item.Quantity)
 		if err != nil {
 			return nil, err
 		}
-		orderItem.ID = orderItemID
 		order.Items = append(order.Items, orderItem)
 	}
 
 	return order, nil
 }
+
+// CreateOrderItem prices an item being added to an existing order from the
+// catalog snapshot, in the currency the order was placed in.
+func CreateOrderItem(order *entities.Order, input *dtos.OrderItemInput, products map[string]*entities.Product, defaultCurrency vos.Currency) (*entities.OrderItem, error) {
+	if err := ValidateOrderItemInput(input); err != nil {
+		return nil, err
+	}
+
+	product, ok := products[input.SKU]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownProduct, input.SKU)
+	}
+
+	if currency := priceCurrency(product, defaultCurrency); currency != order.Currency {
+		return nil, fmt.Errorf("%w: %s is priced in %s", ErrCurrencyMismatch, product.SKU, currency)
+	}
+	return newOrderItem(order, product, input.Quantity)
+}
+
+// priceCurrency is the currency a catalog price is quoted in.
+func priceCurrency(product *entities.Product, defaultCurrency vos.Currency) vos.Currency {
+	if product.Currency == "" {
+		return defaultCurrency
+	}
+	return product.Currency
+}
+
+func newOrderItem(order *entities.Order, product *entities.Product, quantity uint) (*entities.OrderItem, error) {
+	orderItemID, err := sharedVos.NewUUID()
+	if err != nil {
+		return nil, err
+	}
+
+	orderItem := entities.NewOrderItem(order.ID, product.SKU, product.Name, product.Price, quantity)
+	orderItem.ID = orderItemID
+	orderItem.TaxCategory = product.TaxCategory
+	return orderItem, nil
+}
+
+// ApplyTaxes assigns ids to the assessed tax lines and attaches them to the
+// order items.
+func ApplyTaxes(order *entities.Order, assessment *entities.TaxAssessment) error {
+	for _, line := range assessment.Lines {
+		lineID, err := sharedVos.NewUUID()
+		if err != nil {
+			return err
+		}
+		line.ID = lineID
+	}
+
+	order.ApplyTaxes(assessment)
+	return nil
+}
+
+func ValidateOrderInput(input *dtos.OrderInput) error {
+	if input == nil || len(input.Items) == 0 {
+		return ErrOrderWithoutItems
+	}
+
+	for _, item := range input.Items {
+		if err := ValidateOrderItemInput(item); err != nil {
+			return err
+		}
+	}
+
+	if currency := vos.NewCurrency(input.Currency); currency != "" && !currency.IsValid() {
+		return ErrInvalidCurrency
+	}
+
+	for _, address := range []*dtos.Address{input.ShippingAddress, input.BillingAddress} {
+		if _, err := newAddress(address); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func ValidateOrderItemInput(input *dtos.OrderItemInput) error {
+	if input == nil || strings.TrimSpace(input.SKU) == "" || input.Quantity == 0 {
+		return ErrInvalidOrderItem
+	}
+	return nil
+}
+
+func newAddress(input *dtos.Address) (*vos.Address, error) {
+	if input == nil {
+		return nil, nil
+	}
+
+	address, err := vos.NewAddress(
+		input.Street,
+		input.Number,
+		input.Complement,
+		input.District,
+		input.City,
+		input.State,
+		input.PostalCode,
+		input.Country,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &address, nil
+}
+
+func SKUs(input *dtos.OrderInput) []string {
+	return ItemSKUs(input.Items)
+}
+
+func ItemSKUs(items []*dtos.OrderItemInput) []string {
+	seen := make(map[string]struct{}, len(items))
+	skus := make([]string, 0, len(items))
+	for _, item := range items {
+		if _, ok := seen[item.SKU]; ok {
+			continue
+		}
+		seen[item.SKU] = struct{}{}
+		skus = append(skus, item.SKU)
+	}
+	return skus
+}
@@ -0,0 +1,103 @@
+package factories
+
+import (
+	"strings"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/dtos"
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	sharedVos "github.com/jailtonjunior94/order/pkg/vos"
+)
+
+// CreateOrderReturn prices the requested lines from what was paid for them;
+// quantities held by other open returns of the order cannot be claimed again.
+func CreateOrderReturn(order *entities.Order, returns []*entities.OrderReturn, input *dtos.ReturnInput) (*entities.OrderReturn, error) {
+	if !order.CanRefund() {
+		return nil, entities.ErrOrderNotRefundable
+	}
+
+	if input == nil || len(input.Lines) == 0 {
+		return nil, entities.ErrReturnWithoutLines
+	}
+
+	returnID, err := sharedVos.NewUUID()
+	if err != nil {
+		return nil, err
+	}
+
+	orderReturn := entities.NewOrderReturn(order.ID, strings.TrimSpace(input.Reason))
+	orderReturn.ID = returnID
+
+	returned := returnedQuantities(returns)
+	for _, lineInput := range input.Lines {
+		if lineInput == nil || lineInput.Quantity == 0 {
+			return nil, entities.ErrInvalidReturnLine
+		}
+
+		orderItemID, err := sharedVos.NewUUIDFromString(lineInput.OrderItemID)
+		if err != nil {
+			return nil, entities.ErrInvalidReturnLine
+		}
+
+		item := order.FindItem(orderItemID)
+		if item == nil {
+			return nil, entities.ErrInvalidReturnLine
+		}
+
+		alreadyReturned := returned[orderItemID]
+		if alreadyReturned+lineInput.Quantity > item.Quantity {
+			return nil, entities.ErrReturnQuantityExceeded
+		}
+
+		lineID, err := sharedVos.NewUUID()
+		if err != nil {
+			return nil, err
+		}
+
+		amount := order.ReturnAmount(item, alreadyReturned, lineInput.Quantity)
+		line := entities.NewReturnLine(orderReturn.ID, orderItemID, lineInput.Quantity, amount)
+		line.ID = lineID
+		orderReturn.Lines = append(orderReturn.Lines, line)
+		returned[orderItemID] = alreadyReturned + lineInput.Quantity
+	}
+
+	if err := orderReturn.Request(); err != nil {
+		return nil, err
+	}
+	return orderReturn, nil
+}
+
+// RefundReturn refunds the approved return against the order payments and
+// assigns ids to the resulting refunds.
+func RefundReturn(order *entities.Order, orderReturn *entities.OrderReturn) ([]*entities.Refund, error) {
+	refunds, err := order.Refund(orderReturn.ID, orderReturn.Amount())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, refund := range refunds {
+		refundID, err := sharedVos.NewUUID()
+		if err != nil {
+			return nil, err
+		}
+		refund.ID = refundID
+	}
+
+	if err := orderReturn.Refunded(refunds); err != nil {
+		return nil, err
+	}
+	return refunds, nil
+}
+
+func returnedQuantities(returns []*entities.OrderReturn) map[sharedVos.UUID]uint {
+	returned := make(map[sharedVos.UUID]uint)
+	for _, orderReturn := range returns {
+		if !orderReturn.IsOpen() {
+			continue
+		}
+
+		for _, line := range orderReturn.Lines {
+			returned[line.OrderItemID] += line.Quantity
+		}
+	}
+	return returned
+}
@@ -0,0 +1,27 @@
+package factories
+
+import (
+	"time"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	"github.com/jailtonjunior94/order/pkg/vos"
+)
+
+// CreateOrderSaga starts at payment authorization when stock was already
+// reserved synchronously, otherwise it asks inventory to reserve it.
+func CreateOrderSaga(order *entities.Order, reservationID string, deadline time.Time) (*entities.OrderSaga, error) {
+	sagaID, err := vos.NewUUID()
+	if err != nil {
+		return nil, err
+	}
+
+	saga := entities.NewOrderSaga(order.ID)
+	saga.ID = sagaID
+	if reservationID != "" {
+		saga.StartReserved(reservationID, order.Total(), order.Currency, deadline)
+		return saga, nil
+	}
+
+	saga.Start(order.Items, deadline)
+	return saga, nil
+}
@@ -0,0 +1,103 @@
+package factories
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/dtos"
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	"github.com/jailtonjunior94/order/internal/order/domain/vos"
+)
+
+func newAddressInput() *dtos.Address {
+	return &dtos.Address{Street: "Av. Paulista", Number: "1000", City: "São Paulo", State: "sp", PostalCode: "01310-100", Country: "br"}
+}
+
+func newProducts() map[string]*entities.Product {
+	return map[string]*entities.Product{
+		"SKU-1": {SKU: "SKU-1", Name: "Keyboard", Price: 100, TaxCategory: "electronics", Weight: 1},
+		"SKU-2": {SKU: "SKU-2", Name: "Mouse", Price: 50, Weight: 0.2},
+		"SKU-3": {SKU: "SKU-3", Name: "Monitor", Price: 300, Currency: vos.CurrencyUSD, Weight: 4},
+	}
+}
+
+func TestCreateOrder(t *testing.T) {
+	tests := []struct {
+		name             string
+		input            *dtos.OrderInput
+		expectedCurrency vos.Currency
+		expectedSubtotal float64
+		expectedErr      error
+	}{
+		{
+			name:             "billing defaults to the shipping address",
+			input:            &dtos.OrderInput{Items: []*dtos.OrderItemInput{{SKU: "SKU-1", Quantity: 2}, {SKU: "SKU-2", Quantity: 1}}},
+			expectedCurrency: vos.CurrencyBRL,
+			expectedSubtotal: 250,
+		},
+		{
+			name:             "requested currency matching the catalog",
+			input:            &dtos.OrderInput{Currency: "usd", Items: []*dtos.OrderItemInput{{SKU: "SKU-3", Quantity: 1}}},
+			expectedCurrency: vos.CurrencyUSD,
+			expectedSubtotal: 300,
+		},
+		{
+			name:        "requested currency not matching the catalog",
+			input:       &dtos.OrderInput{Currency: "EUR", Items: []*dtos.OrderItemInput{{SKU: "SKU-1", Quantity: 1}}},
+			expectedErr: ErrCurrencyMismatch,
+		},
+		{
+			name:        "items in different currencies",
+			input:       &dtos.OrderInput{Items: []*dtos.OrderItemInput{{SKU: "SKU-1", Quantity: 1}, {SKU: "SKU-3", Quantity: 1}}},
+			expectedErr: ErrCurrencyMismatch,
+		},
+		{
+			name:        "unsupported currency",
+			input:       &dtos.OrderInput{Currency: "JPY", Items: []*dtos.OrderItemInput{{SKU: "SKU-1", Quantity: 1}}},
+			expectedErr: ErrInvalidCurrency,
+		},
+		{
+			name:        "invalid billing address",
+			input:       &dtos.OrderInput{BillingAddress: &dtos.Address{Street: "Rua Augusta"}, Items: []*dtos.OrderItemInput{{SKU: "SKU-1", Quantity: 1}}},
+			expectedErr: vos.ErrInvalidAddress,
+		},
+		{
+			name:        "unknown product",
+			input:       &dtos.OrderInput{Items: []*dtos.OrderItemInput{{SKU: "SKU-9", Quantity: 1}}},
+			expectedErr: ErrUnknownProduct,
+		},
+		{
+			name:        "zero quantity",
+			input:       &dtos.OrderInput{Items: []*dtos.OrderItemInput{{SKU: "SKU-1", Quantity: 0}}},
+			expectedErr: ErrInvalidOrderItem,
+		},
+		{
+			name:        "without items",
+			input:       &dtos.OrderInput{},
+			expectedErr: ErrOrderWithoutItems,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.input.ShippingAddress = newAddressInput()
+
+			order, err := CreateOrder(tt.input, newProducts(), vos.CurrencyBRL)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("CreateOrder() error = %v, want %v", err, tt.expectedErr)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if order.Currency != tt.expectedCurrency || order.Subtotal() != tt.expectedSubtotal || order.CustomerID != "" {
+				t.Errorf("order = %s %v for %q, want %s %v without a customer", order.Currency, order.Subtotal(), order.CustomerID, tt.expectedCurrency, tt.expectedSubtotal)
+			}
+
+			if order.BillingAddress != order.ShippingAddress || order.ShippingAddress.Country != "BR" || order.ShippingAddress.State != "SP" {
+				t.Errorf("addresses = %+v / %+v, want billing to default to the normalized shipping address", order.ShippingAddress, order.BillingAddress)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package factories
+
+import (
+	"strings"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/dtos"
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	"github.com/jailtonjunior94/order/internal/order/domain/vos"
+	sharedVos "github.com/jailtonjunior94/order/pkg/vos"
+)
+
+// CreatePayment builds an approved payment in the order currency unless the
+// input says otherwise.
+func CreatePayment(order *entities.Order, input *dtos.PaymentInput) (*entities.Payment, error) {
+	if input == nil {
+		return nil, entities.ErrInvalidPaymentAmount
+	}
+
+	paymentID, err := sharedVos.NewUUID()
+	if err != nil {
+		return nil, err
+	}
+
+	currency := vos.NewCurrency(input.Currency)
+	if currency == "" {
+		currency = order.Currency
+	}
+
+	status := vos.PaymentStatus(strings.ToUpper(strings.TrimSpace(input.Status)))
+	if status == "" {
+		status = vos.PaymentApproved
+	}
+
+	payment := entities.NewPayment(
+		order.ID,
+		input.Amount,
+		currency,
+		vos.PaymentMethod(strings.ToUpper(strings.TrimSpace(input.Method))),
+		strings.TrimSpace(input.ProviderReference),
+		status,
+	)
+	payment.ID = paymentID
+
+	if err := payment.Validate(); err != nil {
+		return nil, err
+	}
+	return payment, nil
+}
+
+// CreateCapturedPayment builds the approved payment for an amount captured
+// outside the API; a zero amount settles the order balance.
+func CreateCapturedPayment(order *entities.Order, amount float64, providerReference string) (*entities.Payment, error) {
+	paymentID, err := sharedVos.NewUUID()
+	if err != nil {
+		return nil, err
+	}
+
+	if amount <= 0 {
+		amount = order.Balance()
+	}
+
+	payment := entities.NewPayment(
+		order.ID,
+		amount,
+		order.Currency,
+		vos.PaymentExternal,
+		strings.TrimSpace(providerReference),
+		vos.PaymentApproved,
+	)
+	payment.ID = paymentID
+
+	if err := payment.Validate(); err != nil {
+		return nil, err
+	}
+	return payment, nil
+}
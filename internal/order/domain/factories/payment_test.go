@@ -0,0 +1,57 @@
+package factories
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/dtos"
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	"github.com/jailtonjunior94/order/internal/order/domain/vos"
+)
+
+func TestCreatePayment(t *testing.T) {
+	tests := []struct {
+		name           string
+		input          *dtos.PaymentInput
+		expectedStatus vos.PaymentStatus
+		expectedErr    error
+	}{
+		{name: "defaults to an approved payment", input: &dtos.PaymentInput{Amount: 100, Method: " pix "}, expectedStatus: vos.PaymentApproved},
+		{name: "declined payment", input: &dtos.PaymentInput{Amount: 100, Method: "CREDIT_CARD", Status: "declined"}, expectedStatus: vos.PaymentDeclined},
+		{name: "unknown method", input: &dtos.PaymentInput{Amount: 100, Method: "CASH"}, expectedErr: entities.ErrInvalidPaymentMethod},
+		{name: "missing input", expectedErr: entities.ErrInvalidPaymentAmount},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payment, err := CreatePayment(newOrder(t), tt.input)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("CreatePayment() error = %v, want %v", err, tt.expectedErr)
+			}
+
+			if err == nil && (payment.Currency != vos.CurrencyBRL || payment.Status != tt.expectedStatus) {
+				t.Errorf("payment = %s %s, want BRL %s", payment.Currency, payment.Status, tt.expectedStatus)
+			}
+		})
+	}
+}
+
+func TestCreateCapturedPayment(t *testing.T) {
+	order := newOrder(t)
+	payment, err := CreateCapturedPayment(order, 0, " provider-1 ")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if payment.Amount != 250 || payment.Method != vos.PaymentExternal || payment.ProviderReference != "provider-1" {
+		t.Errorf("payment = %v %s %q, want the 250 balance captured externally as \"provider-1\"", payment.Amount, payment.Method, payment.ProviderReference)
+	}
+
+	if err := order.RegisterPayment(payment); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := CreateCapturedPayment(order, 0, "provider-2"); !errors.Is(err, entities.ErrInvalidPaymentAmount) {
+		t.Errorf("CreateCapturedPayment() on a settled order error = %v, want %v", err, entities.ErrInvalidPaymentAmount)
+	}
+}
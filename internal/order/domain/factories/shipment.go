@@ -0,0 +1,71 @@
+package factories
+
+import (
+	"strings"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/dtos"
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	sharedVos "github.com/jailtonjunior94/order/pkg/vos"
+)
+
+// CreateShipment packs the requested lines into a new shipment; units already
+// sent in other shipments of the order cannot be shipped again.
+func CreateShipment(order *entities.Order, shipments []*entities.Shipment, input *dtos.ShipmentInput) (*entities.Shipment, error) {
+	if !order.CanShip() {
+		return nil, entities.ErrOrderNotShippable
+	}
+
+	if input == nil {
+		return nil, entities.ErrInvalidShipment
+	}
+
+	shipmentID, err := sharedVos.NewUUID()
+	if err != nil {
+		return nil, err
+	}
+
+	shipment := entities.NewShipment(order.ID, NormalizeCarrier(input.Carrier), strings.TrimSpace(input.TrackingNumber))
+	shipment.ID = shipmentID
+
+	shipped := entities.ShippedQuantities(shipments)
+	for _, lineInput := range input.Lines {
+		if lineInput == nil || lineInput.Quantity == 0 {
+			return nil, entities.ErrInvalidShipmentLine
+		}
+
+		orderItemID, err := sharedVos.NewUUIDFromString(lineInput.OrderItemID)
+		if err != nil {
+			return nil, entities.ErrInvalidShipmentLine
+		}
+
+		item := order.FindItem(orderItemID)
+		if item == nil {
+			return nil, entities.ErrInvalidShipmentLine
+		}
+
+		if shipped[orderItemID]+lineInput.Quantity > item.Quantity {
+			return nil, entities.ErrShipmentQuantityExceeded
+		}
+
+		lineID, err := sharedVos.NewUUID()
+		if err != nil {
+			return nil, err
+		}
+
+		line := entities.NewShipmentLine(shipment.ID, orderItemID, lineInput.Quantity)
+		line.ID = lineID
+		shipment.Lines = append(shipment.Lines, line)
+		shipped[orderItemID] += lineInput.Quantity
+	}
+
+	if err := shipment.Ship(); err != nil {
+		return nil, err
+	}
+	return shipment, nil
+}
+
+// NormalizeCarrier makes carrier names from the API and from carrier
+// webhooks comparable.
+func NormalizeCarrier(carrier string) string {
+	return strings.ToLower(strings.TrimSpace(carrier))
+}
@@ -0,0 +1,118 @@
+package factories
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/dtos"
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	"github.com/jailtonjunior94/order/internal/order/domain/vos"
+)
+
+// CreateShippingRequest rates the items of a quote asked for before the order
+// exists, valued from the catalog snapshot and checked for currency like
+// CreateOrder.
+func CreateShippingRequest(input *dtos.ShippingQuoteInput, products map[string]*entities.Product, defaultCurrency vos.Currency) (*entities.ShippingRequest, error) {
+	if err := ValidateShippingQuoteInput(input); err != nil {
+		return nil, err
+	}
+
+	destination, err := newAddress(input.ShippingAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	request := &entities.ShippingRequest{Destination: destination, Currency: vos.NewCurrency(input.Currency)}
+	for _, item := range input.Items {
+		product, ok := products[item.SKU]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownProduct, item.SKU)
+		}
+
+		currency := priceCurrency(product, defaultCurrency)
+		if request.Currency == "" {
+			request.Currency = currency
+		}
+
+		if currency != request.Currency {
+			return nil, fmt.Errorf("%w: %s is priced in %s", ErrCurrencyMismatch, product.SKU, currency)
+		}
+
+		request.Subtotal += product.Price * float64(item.Quantity)
+		request.Packages = append(request.Packages, newShippingPackage(product, item.Quantity))
+	}
+
+	request.Subtotal = math.Round(request.Subtotal*100) / 100
+	return request, nil
+}
+
+// CreateOrderShippingRequest rates the order as it stands, on the same
+// subtotal CreateShippingRequest quotes, with the weight and dimensions of its
+// items taken from products.
+func CreateOrderShippingRequest(order *entities.Order, products map[string]*entities.Product) (*entities.ShippingRequest, error) {
+	if order.ShippingAddress == nil {
+		return nil, entities.ErrShippingAddressRequired
+	}
+
+	request := &entities.ShippingRequest{
+		Destination: order.ShippingAddress,
+		Currency:    order.Currency,
+		Subtotal:    math.Round(order.Subtotal()*100) / 100,
+	}
+
+	for _, item := range order.Items {
+		product, ok := products[item.SKU]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownProduct, item.SKU)
+		}
+		request.Packages = append(request.Packages, newShippingPackage(product, item.Quantity))
+	}
+	return request, nil
+}
+
+func ValidateShippingQuoteInput(input *dtos.ShippingQuoteInput) error {
+	if input == nil || len(input.Items) == 0 {
+		return ErrOrderWithoutItems
+	}
+
+	for _, item := range input.Items {
+		if err := ValidateOrderItemInput(item); err != nil {
+			return err
+		}
+	}
+
+	if currency := vos.NewCurrency(input.Currency); currency != "" && !currency.IsValid() {
+		return ErrInvalidCurrency
+	}
+
+	if input.ShippingAddress == nil {
+		return entities.ErrShippingAddressRequired
+	}
+
+	_, err := newAddress(input.ShippingAddress)
+	return err
+}
+
+func newShippingPackage(product *entities.Product, quantity uint) *entities.ShippingPackage {
+	return &entities.ShippingPackage{
+		SKU:      product.SKU,
+		Quantity: quantity,
+		Weight:   product.Weight,
+		Length:   product.Length,
+		Width:    product.Width,
+		Height:   product.Height,
+	}
+}
+
+func OrderSKUs(order *entities.Order) []string {
+	seen := make(map[string]struct{}, len(order.Items))
+	skus := make([]string, 0, len(order.Items))
+	for _, item := range order.Items {
+		if _, ok := seen[item.SKU]; ok {
+			continue
+		}
+		seen[item.SKU] = struct{}{}
+		skus = append(skus, item.SKU)
+	}
+	return skus
+}
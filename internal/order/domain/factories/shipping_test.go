@@ -0,0 +1,92 @@
+package factories
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/dtos"
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	"github.com/jailtonjunior94/order/internal/order/domain/vos"
+)
+
+func TestCreateShippingRequest(t *testing.T) {
+	tests := []struct {
+		name             string
+		input            *dtos.ShippingQuoteInput
+		expectedCurrency vos.Currency
+		expectedSubtotal float64
+		expectedPackages int
+		expectedErr      error
+	}{
+		{
+			name: "catalog default currency",
+			input: &dtos.ShippingQuoteInput{
+				ShippingAddress: newAddressInput(),
+				Items:           []*dtos.OrderItemInput{{SKU: "SKU-1", Quantity: 2}, {SKU: "SKU-2", Quantity: 1}},
+			},
+			expectedCurrency: vos.CurrencyBRL,
+			expectedSubtotal: 250,
+			expectedPackages: 2,
+		},
+		{
+			name: "requested currency not matching the catalog",
+			input: &dtos.ShippingQuoteInput{
+				Currency:        "USD",
+				ShippingAddress: newAddressInput(),
+				Items:           []*dtos.OrderItemInput{{SKU: "SKU-1", Quantity: 1}},
+			},
+			expectedErr: ErrCurrencyMismatch,
+		},
+		{
+			name:        "without an address",
+			input:       &dtos.ShippingQuoteInput{Items: []*dtos.OrderItemInput{{SKU: "SKU-1", Quantity: 1}}},
+			expectedErr: entities.ErrShippingAddressRequired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request, err := CreateShippingRequest(tt.input, newProducts(), vos.CurrencyBRL)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("CreateShippingRequest() error = %v, want %v", err, tt.expectedErr)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if request.Currency != tt.expectedCurrency || request.Subtotal != tt.expectedSubtotal || len(request.Packages) != tt.expectedPackages {
+				t.Errorf("request = %s %v with %d packages, want %s %v with %d", request.Currency, request.Subtotal, len(request.Packages), tt.expectedCurrency, tt.expectedSubtotal, tt.expectedPackages)
+			}
+		})
+	}
+}
+
+// TestShippingRequestsShareTheirSubtotal checks the quote shown before the
+// order exists and the one charged on the order rate the same subtotal, even
+// once a coupon discounts the order.
+func TestShippingRequestsShareTheirSubtotal(t *testing.T) {
+	order := newOrder(t)
+	order.SetAddresses(&vos.Address{Street: "Av. Paulista", City: "São Paulo", PostalCode: "01310-100", Country: "BR"}, nil)
+	if err := ApplyCoupon(order, entities.NewCoupon("TEN", vos.CouponPercentage, 10, time.Time{}), "", 0, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	quote, err := CreateShippingRequest(&dtos.ShippingQuoteInput{
+		ShippingAddress: newAddressInput(),
+		Items:           []*dtos.OrderItemInput{{SKU: "SKU-1", Quantity: 2}, {SKU: "SKU-2", Quantity: 1}},
+	}, newProducts(), vos.CurrencyBRL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	charged, err := CreateOrderShippingRequest(order, newProducts())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if quote.Subtotal != charged.Subtotal {
+		t.Errorf("quoted subtotal = %v, charged subtotal = %v", quote.Subtotal, charged.Subtotal)
+	}
+}
@@ -0,0 +1,11 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+)
+
+type CatalogClient interface {
+	FindProducts(ctx context.Context, skus []string) (map[string]*entities.Product, error)
+}
@@ -0,0 +1,22 @@
+package interfaces
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	sharedVos "github.com/jailtonjunior94/order/pkg/vos"
+)
+
+// ErrCouponCodeTaken is returned by Insert when another coupon has the code.
+var ErrCouponCodeTaken = errors.New("coupon code already exists")
+
+type CouponRepository interface {
+	Insert(ctx context.Context, coupon *entities.Coupon) error
+	Update(ctx context.Context, coupon *entities.Coupon) error
+	FindByCode(ctx context.Context, code string) (*entities.Coupon, error)
+	FindRedeemedBy(ctx context.Context, orderID sharedVos.UUID) (*entities.Coupon, error)
+	CountRedemptions(ctx context.Context, couponID sharedVos.UUID, customerID string) (int, error)
+	InsertRedemption(ctx context.Context, couponID, orderID sharedVos.UUID, customerID string) error
+	DeleteRedemption(ctx context.Context, couponID, orderID sharedVos.UUID) error
+}
@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	"github.com/jailtonjunior94/order/internal/order/domain/vos"
+)
+
+var ErrExchangeRateNotFound = errors.New("exchange rate not found")
+
+type ExchangeRateProvider interface {
+	Rate(ctx context.Context, base, quote vos.Currency) (*entities.ExchangeRate, error)
+}
@@ -0,0 +1,12 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	"github.com/jailtonjunior94/order/internal/order/domain/vos"
+)
+
+type ExchangeRateRepository interface {
+	FindLatest(ctx context.Context, base, quote vos.Currency) (*entities.ExchangeRate, error)
+}
@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	sharedVos "github.com/jailtonjunior94/order/pkg/vos"
+)
+
+var ErrInsufficientStock = errors.New("insufficient stock")
+
+type InventoryClient interface {
+	Reserve(ctx context.Context, orderID sharedVos.UUID, items []*entities.OrderItem) (string, error)
+	Release(ctx context.Context, reservationID string) error
+}
@@ -2,16 +2,39 @@ package interfaces
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/jailtonjunior94/order/internal/order/domain/entities"
 	"github.com/jailtonjunior94/order/internal/order/domain/vos"
 	sharedVos "github.com/jailtonjunior94/order/pkg/vos"
 )
 
-type OrderRepository interface {
-	Update(ctx context.Context, order *entities.Order) error
-	Insert(ctx context.Context, order *entities.Order) error
-	InsertItems(ctx context.Context, items []*entities.OrderItem) error
-	Find(ctx context.Context, orderID sharedVos.UUID) (*entities.Order, error)
-	FindAll(ctx context.Context, status vos.Status) ([]*entities.Order, error)
-}
+// ErrOrderConflict is returned by Update when the order changed since it was
+// read, so the caller's write would overwrite it.
+var ErrOrderConflict = errors.New("order was modified concurrently")
+
+type (
+	OrderRepository interface {
+		Update(ctx context.Context, order *entities.Order) error
+		Insert(ctx context.Context, order *entities.Order) error
+		InsertItems(ctx context.Context, items []*entities.OrderItem) error
+		ReplaceItems(ctx context.Context, order *entities.Order) error
+		InsertAddresses(ctx context.Context, order *entities.Order) error
+		InsertDiscounts(ctx context.Context, discounts []*entities.OrderDiscount) error
+		InsertTaxes(ctx context.Context, taxes []*entities.OrderItemTax) error
+		InsertPayment(ctx context.Context, payment *entities.Payment) error
+		UpdatePayment(ctx context.Context, payment *entities.Payment) error
+		InsertRefunds(ctx context.Context, refunds []*entities.Refund) error
+		Find(ctx context.Context, orderID sharedVos.UUID) (*entities.Order, error)
+		FindStatusHistory(ctx context.Context, orderID sharedVos.UUID) ([]*entities.OrderStatusChange, error)
+		List(ctx context.Context, filter *OrderFilter) ([]*entities.Order, error)
+		FindStale(ctx context.Context, status vos.Status, before time.Time, limit int) ([]*entities.Order, error)
+	}
+
+	OrderFilter struct {
+		CustomerID string
+		Status     vos.Status
+		Limit      int
+	}
+)
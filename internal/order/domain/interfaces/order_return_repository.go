@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	"github.com/jailtonjunior94/order/pkg/vos"
+)
+
+type OrderReturnRepository interface {
+	Insert(ctx context.Context, orderReturn *entities.OrderReturn) error
+	Update(ctx context.Context, orderReturn *entities.OrderReturn) error
+	Find(ctx context.Context, returnID vos.UUID) (*entities.OrderReturn, error)
+	FindByOrder(ctx context.Context, orderID vos.UUID) ([]*entities.OrderReturn, error)
+}
@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	sharedVos "github.com/jailtonjunior94/order/pkg/vos"
+)
+
+type OrderSagaRepository interface {
+	Insert(ctx context.Context, saga *entities.OrderSaga) error
+	Update(ctx context.Context, saga *entities.OrderSaga) error
+	FindByOrder(ctx context.Context, orderID sharedVos.UUID) (*entities.OrderSaga, error)
+	FindTimedOut(ctx context.Context, before time.Time, limit int) ([]*entities.OrderSaga, error)
+}
@@ -2,12 +2,34 @@ package interfaces
 
 import (
 	"context"
+	"time"
 
 	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	"github.com/jailtonjunior94/order/pkg/vos"
 )
 
-type OutboxRepository interface {
-	Insert(ctx context.Context, outbox *entities.Outbox) error
-	Update(ctx context.Context, outbox *entities.Outbox) error
-	FindAll(ctx context.Context, wasPublished bool) ([]*entities.Outbox, error)
-}
+type (
+	OutboxRepository interface {
+		Insert(ctx context.Context, outbox *entities.Outbox) error
+		Update(ctx context.Context, outbox *entities.Outbox) error
+		Find(ctx context.Context, id vos.UUID) (*entities.Outbox, error)
+		FindAll(ctx context.Context, wasPublished bool) ([]*entities.Outbox, error)
+		List(ctx context.Context, filter *OutboxFilter) ([]*entities.Outbox, error)
+		PendingStats(ctx context.Context) (int64, *time.Time, error)
+		// DeleteSettled and ArchiveSettled remove rows published or skipped
+		// before the given time; pending rows are never touched.
+		DeleteSettled(ctx context.Context, before time.Time, limit int) (int64, error)
+		ArchiveSettled(ctx context.Context, before time.Time, limit int) (int64, error)
+	}
+
+	// OutboxFilter lists rows ordered by creation. After continues a listing
+	// from the last row of the previous page.
+	OutboxFilter struct {
+		WasPublished *bool
+		Skipped      *bool
+		EventName    string
+		Since        time.Time
+		After        *entities.Outbox
+		Limit        int
+	}
+)
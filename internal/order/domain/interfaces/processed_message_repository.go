@@ -0,0 +1,7 @@
+package interfaces
+
+import "context"
+
+type ProcessedMessageRepository interface {
+	Register(ctx context.Context, consumer, messageID string) (bool, error)
+}
@@ -0,0 +1,8 @@
+package interfaces
+
+import "context"
+
+type RelayCheckpointRepository interface {
+	Find(ctx context.Context, name string) (string, error)
+	Save(ctx context.Context, name, position string) error
+}
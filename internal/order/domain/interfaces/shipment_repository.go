@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	"github.com/jailtonjunior94/order/pkg/vos"
+)
+
+type ShipmentRepository interface {
+	Insert(ctx context.Context, shipment *entities.Shipment) error
+	Update(ctx context.Context, shipment *entities.Shipment) error
+	Find(ctx context.Context, shipmentID vos.UUID) (*entities.Shipment, error)
+	FindByTracking(ctx context.Context, carrier, trackingNumber string) (*entities.Shipment, error)
+	FindByOrder(ctx context.Context, orderID vos.UUID) ([]*entities.Shipment, error)
+}
@@ -0,0 +1,11 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+)
+
+type ShippingRateProvider interface {
+	Quote(ctx context.Context, request *entities.ShippingRequest) ([]*entities.ShippingOption, error)
+}
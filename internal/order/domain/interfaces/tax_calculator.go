@@ -0,0 +1,11 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+)
+
+type TaxCalculator interface {
+	Calculate(ctx context.Context, order *entities.Order) (*entities.TaxAssessment, error)
+}
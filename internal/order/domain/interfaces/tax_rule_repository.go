@@ -0,0 +1,11 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+)
+
+type TaxRuleRepository interface {
+	FindByRegion(ctx context.Context, region string) ([]*entities.TaxRule, error)
+}
@@ -0,0 +1,79 @@
+package vos
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrInvalidAddress = errors.New("invalid address")
+
+type (
+	AddressKind string
+
+	Address struct {
+		Street     string
+		Number     string
+		Complement string
+		District   string
+		City       string
+		State      string
+		PostalCode string
+		Country    string
+	}
+)
+
+const (
+	AddressShipping AddressKind = "SHIPPING"
+	AddressBilling  AddressKind = "BILLING"
+)
+
+func (k AddressKind) String() string {
+	return string(k)
+}
+
+// NewAddress trims every field and upper-cases state and country, which is
+// expected as an ISO 3166-1 alpha-2 code.
+func NewAddress(street, number, complement, district, city, state, postalCode, country string) (Address, error) {
+	address := Address{
+		Street:     strings.TrimSpace(street),
+		Number:     strings.TrimSpace(number),
+		Complement: strings.TrimSpace(complement),
+		District:   strings.TrimSpace(district),
+		City:       strings.TrimSpace(city),
+		State:      strings.ToUpper(strings.TrimSpace(state)),
+		PostalCode: strings.TrimSpace(postalCode),
+		Country:    strings.ToUpper(strings.TrimSpace(country)),
+	}
+
+	if err := address.Validate(); err != nil {
+		return Address{}, err
+	}
+	return address, nil
+}
+
+func (a Address) Validate() error {
+	switch {
+	case a.Street == "":
+		return fmt.Errorf("%w: street is required", ErrInvalidAddress)
+	case a.City == "":
+		return fmt.Errorf("%w: city is required", ErrInvalidAddress)
+	case a.PostalCode == "":
+		return fmt.Errorf("%w: postal code is required", ErrInvalidAddress)
+	case !isCountryCode(a.Country):
+		return fmt.Errorf("%w: country must be a two-letter code", ErrInvalidAddress)
+	}
+	return nil
+}
+
+func isCountryCode(value string) bool {
+	if len(value) != 2 {
+		return false
+	}
+	for _, r := range value {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
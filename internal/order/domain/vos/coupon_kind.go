@@ -0,0 +1,17 @@
+package vos
+
+type CouponKind string
+
+const (
+	CouponPercentage CouponKind = "PERCENTAGE"
+	CouponFixed      CouponKind = "FIXED"
+	CouponBuyXGetY   CouponKind = "BUY_X_GET_Y"
+)
+
+func (k CouponKind) String() string {
+	return string(k)
+}
+
+func (k CouponKind) IsValid() bool {
+	return k == CouponPercentage || k == CouponFixed || k == CouponBuyXGetY
+}
@@ -0,0 +1,23 @@
+package vos
+
+import "strings"
+
+type Currency string
+
+const (
+	CurrencyBRL Currency = "BRL"
+	CurrencyUSD Currency = "USD"
+	CurrencyEUR Currency = "EUR"
+)
+
+func NewCurrency(value string) Currency {
+	return Currency(strings.ToUpper(strings.TrimSpace(value)))
+}
+
+func (c Currency) String() string {
+	return string(c)
+}
+
+func (c Currency) IsValid() bool {
+	return c == CurrencyBRL || c == CurrencyUSD || c == CurrencyEUR
+}
@@ -0,0 +1,43 @@
+package vos
+
+type (
+	PaymentMethod string
+	PaymentStatus string
+)
+
+const (
+	PaymentCreditCard   PaymentMethod = "CREDIT_CARD"
+	PaymentDebitCard    PaymentMethod = "DEBIT_CARD"
+	PaymentPix          PaymentMethod = "PIX"
+	PaymentBoleto       PaymentMethod = "BOLETO"
+	PaymentBankTransfer PaymentMethod = "BANK_TRANSFER"
+	PaymentVoucher      PaymentMethod = "VOUCHER"
+	// PaymentExternal marks payments captured outside the API whose method
+	// was not reported, such as provider events and manual settlements.
+	PaymentExternal PaymentMethod = "EXTERNAL"
+)
+
+const (
+	PaymentApproved PaymentStatus = "APPROVED"
+	PaymentDeclined PaymentStatus = "DECLINED"
+)
+
+func (m PaymentMethod) String() string {
+	return string(m)
+}
+
+func (m PaymentMethod) IsValid() bool {
+	switch m {
+	case PaymentCreditCard, PaymentDebitCard, PaymentPix, PaymentBoleto, PaymentBankTransfer, PaymentVoucher, PaymentExternal:
+		return true
+	}
+	return false
+}
+
+func (s PaymentStatus) String() string {
+	return string(s)
+}
+
+func (s PaymentStatus) IsValid() bool {
+	return s == PaymentApproved || s == PaymentDeclined
+}
@@ -0,0 +1,14 @@
+package vos
+
+type ReturnStatus string
+
+const (
+	ReturnRequested ReturnStatus = "REQUESTED"
+	ReturnApproved  ReturnStatus = "APPROVED"
+	ReturnRejected  ReturnStatus = "REJECTED"
+	ReturnRefunded  ReturnStatus = "REFUNDED"
+)
+
+func (s ReturnStatus) String() string {
+	return string(s)
+}
@@ -0,0 +1,20 @@
+package vos
+
+type SagaStatus string
+
+const (
+	SagaReservingStock     SagaStatus = "RESERVING_STOCK"
+	SagaAuthorizingPayment SagaStatus = "AUTHORIZING_PAYMENT"
+	SagaCompensating       SagaStatus = "COMPENSATING"
+	SagaCompleted          SagaStatus = "COMPLETED"
+	SagaAborted            SagaStatus = "ABORTED"
+	SagaFailed             SagaStatus = "FAILED"
+)
+
+func (s SagaStatus) String() string {
+	return string(s)
+}
+
+func (s SagaStatus) IsTerminal() bool {
+	return s == SagaCompleted || s == SagaAborted || s == SagaFailed
+}
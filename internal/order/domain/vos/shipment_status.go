@@ -0,0 +1,37 @@
+package vos
+
+type ShipmentStatus string
+
+const (
+	ShipmentShipped   ShipmentStatus = "SHIPPED"
+	ShipmentInTransit ShipmentStatus = "IN_TRANSIT"
+	ShipmentException ShipmentStatus = "EXCEPTION"
+	ShipmentDelivered ShipmentStatus = "DELIVERED"
+)
+
+func (s ShipmentStatus) String() string {
+	return string(s)
+}
+
+func (s ShipmentStatus) IsValid() bool {
+	return s.stage() > 0
+}
+
+// Follows reports whether a shipment in status previous may move to s.
+// Carriers redeliver and reorder updates, so only forward moves are taken; a
+// shipment in transit may still report an exception and recover from it.
+func (s ShipmentStatus) Follows(previous ShipmentStatus) bool {
+	return s != previous && s.stage() >= previous.stage()
+}
+
+func (s ShipmentStatus) stage() int {
+	switch s {
+	case ShipmentShipped:
+		return 1
+	case ShipmentInTransit, ShipmentException:
+		return 2
+	case ShipmentDelivered:
+		return 3
+	}
+	return 0
+}
@@ -0,0 +1,27 @@
+package vos
+
+import "testing"
+
+func TestShipmentStatusFollows(t *testing.T) {
+	tests := []struct {
+		name     string
+		previous ShipmentStatus
+		next     ShipmentStatus
+		expected bool
+	}{
+		{name: "shipped to in transit", previous: ShipmentShipped, next: ShipmentInTransit, expected: true},
+		{name: "exception back to in transit", previous: ShipmentException, next: ShipmentInTransit, expected: true},
+		{name: "repeated update", previous: ShipmentInTransit, next: ShipmentInTransit, expected: false},
+		{name: "in transit back to shipped", previous: ShipmentInTransit, next: ShipmentShipped, expected: false},
+		{name: "delivered to exception", previous: ShipmentDelivered, next: ShipmentException, expected: false},
+		{name: "unknown status", previous: ShipmentShipped, next: ShipmentStatus("LOST"), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.next.Follows(tt.previous); got != tt.expected {
+				t.Errorf("%s.Follows(%s) = %v, want %v", tt.next, tt.previous, got, tt.expected)
+			}
+		})
+	}
+}
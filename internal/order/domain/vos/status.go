@@ -3,11 +3,27 @@ package vos
 type Status string
 
 const (
-	StatusPending  Status = "PENDING"
-	StatusPaid     Status = "PAID"
-	StatusCanceled Status = "CANCELED"
+	StatusPending           Status = "PENDING"
+	StatusPartiallyPaid     Status = "PARTIALLY_PAID"
+	StatusPaid              Status = "PAID"
+	StatusPartiallyShipped  Status = "PARTIALLY_SHIPPED"
+	StatusShipped           Status = "SHIPPED"
+	StatusDelivered         Status = "DELIVERED"
+	StatusPartiallyRefunded Status = "PARTIALLY_REFUNDED"
+	StatusRefunded          Status = "REFUNDED"
+	StatusCanceled          Status = "CANCELED"
 )
 
 func (s Status) String() string {
 	return string(s)
 }
+
+func (s Status) IsValid() bool {
+	switch s {
+	case StatusPending, StatusPartiallyPaid, StatusPaid,
+		StatusPartiallyShipped, StatusShipped, StatusDelivered,
+		StatusPartiallyRefunded, StatusRefunded, StatusCanceled:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+package catalog
+
+import (
+	"context"
+	"time"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	"github.com/jailtonjunior94/order/internal/order/domain/interfaces"
+	"github.com/jailtonjunior94/order/pkg/cache"
+)
+
+type cachedCatalog struct {
+	next  interfaces.CatalogClient
+	cache *cache.TTLCache[string, *entities.Product]
+}
+
+// NewCachedCatalog serves known products from memory for ttl and only asks
+// next for the skus that are missing or expired. Skus next does not know are
+// cached as absent for ttl as well, so repeated unknown skus do not reach the
+// catalog; failed lookups are never cached.
+func NewCachedCatalog(next interfaces.CatalogClient, ttl time.Duration) interfaces.CatalogClient {
+	if ttl <= 0 {
+		return next
+	}
+
+	return &cachedCatalog{
+		next:  next,
+		cache: cache.NewTTLCache[string, *entities.Product](ttl),
+	}
+}
+
+func (c *cachedCatalog) FindProducts(ctx context.Context, skus []string) (map[string]*entities.Product, error) {
+	products := make(map[string]*entities.Product, len(skus))
+
+	var missing []string
+	for _, sku := range skus {
+		if product, ok := c.cache.Get(sku); ok {
+			if product != nil {
+				products[sku] = product
+			}
+			continue
+		}
+		missing = append(missing, sku)
+	}
+
+	if len(missing) == 0 {
+		return products, nil
+	}
+
+	fetched, err := c.next.FindProducts(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sku := range missing {
+		product := fetched[sku]
+		c.cache.Set(sku, product)
+		if product != nil {
+			products[sku] = product
+		}
+	}
+	return products, nil
+}
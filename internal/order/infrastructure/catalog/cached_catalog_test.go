@@ -0,0 +1,67 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+)
+
+type fakeCatalog struct {
+	products  map[string]*entities.Product
+	err       error
+	requested [][]string
+}
+
+func (c *fakeCatalog) FindProducts(_ context.Context, skus []string) (map[string]*entities.Product, error) {
+	c.requested = append(c.requested, skus)
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	found := make(map[string]*entities.Product)
+	for _, sku := range skus {
+		if product, ok := c.products[sku]; ok {
+			found[sku] = product
+		}
+	}
+	return found, nil
+}
+
+func TestCachedCatalogFindProducts(t *testing.T) {
+	next := &fakeCatalog{products: map[string]*entities.Product{"SKU-1": {SKU: "SKU-1", Price: 100}}}
+	catalog := NewCachedCatalog(next, time.Minute)
+
+	for range 2 {
+		products, err := catalog.FindProducts(context.Background(), []string{"SKU-1", "SKU-9"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(products) != 1 || products["SKU-1"] == nil {
+			t.Fatalf("products = %v, want only SKU-1", products)
+		}
+	}
+
+	if len(next.requested) != 1 {
+		t.Errorf("catalog requests = %v, want the found and the unknown sku served from cache", next.requested)
+	}
+}
+
+func TestCachedCatalogDoesNotCacheFailures(t *testing.T) {
+	failure := errors.New("catalog unavailable")
+	next := &fakeCatalog{err: failure}
+	catalog := NewCachedCatalog(next, time.Minute)
+
+	for range 2 {
+		if _, err := catalog.FindProducts(context.Background(), []string{"SKU-1"}); !errors.Is(err, failure) {
+			t.Fatalf("FindProducts() error = %v, want %v", err, failure)
+		}
+	}
+
+	if len(next.requested) != 2 {
+		t.Errorf("catalog requests = %d, want every failed lookup retried", len(next.requested))
+	}
+}
@@ -0,0 +1,113 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	"github.com/jailtonjunior94/order/internal/order/domain/interfaces"
+	"github.com/jailtonjunior94/order/internal/order/domain/vos"
+	httpclient "github.com/jailtonjunior94/order/pkg/http-client"
+	"github.com/jailtonjunior94/order/pkg/o11y"
+)
+
+const defaultTimeout = 5 * time.Second
+
+type (
+	httpCatalog struct {
+		baseURL string
+		timeout time.Duration
+		client  httpclient.HTTPClient
+		o11y    o11y.Observability
+	}
+
+	productResponse struct {
+		SKU         string  `json:"sku"`
+		Name        string  `json:"name"`
+		Price       float64 `json:"price"`
+		Currency    string  `json:"currency"`
+		TaxCategory string  `json:"tax_category"`
+		Weight      float64 `json:"weight"`
+		Length      float64 `json:"length"`
+		Width       float64 `json:"width"`
+		Height      float64 `json:"height"`
+	}
+
+	errorResponse struct {
+		Message string `json:"message"`
+	}
+)
+
+func NewHTTPCatalog(baseURL string, timeout time.Duration, client httpclient.HTTPClient, o11y o11y.Observability) interfaces.CatalogClient {
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	return &httpCatalog{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		timeout: timeout,
+		client:  client,
+		o11y:    o11y,
+	}
+}
+
+// FindProducts returns only the skus the catalog knows about; callers decide
+// whether a missing sku is an error.
+func (c *httpCatalog) FindProducts(ctx context.Context, skus []string) (map[string]*entities.Product, error) {
+	ctx, span := c.o11y.Start(ctx, "http_catalog.find_products")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	query := url.Values{}
+	for _, sku := range skus {
+		query.Add("sku", sku)
+	}
+
+	status, response, failure, err := httpclient.MakeRequest[[]*productResponse, errorResponse](
+		ctx,
+		c.client,
+		http.MethodGet,
+		c.baseURL+"/products?"+query.Encode(),
+		map[string]string{"Accept": "application/json"},
+		nil,
+	)
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error find products", o11y.Attributes{Key: "error", Value: err})
+		return nil, err
+	}
+
+	if failure != nil || response == nil {
+		err := fmt.Errorf("catalog lookup failed with status %d: %s", status, failure.message())
+		span.AddAttributes(ctx, o11y.Error, "error find products", o11y.Attributes{Key: "error", Value: err})
+		return nil, err
+	}
+
+	products := make(map[string]*entities.Product, len(*response))
+	for _, product := range *response {
+		products[product.SKU] = &entities.Product{
+			SKU:         product.SKU,
+			Name:        product.Name,
+			Price:       product.Price,
+			Currency:    vos.NewCurrency(product.Currency),
+			TaxCategory: product.TaxCategory,
+			Weight:      product.Weight,
+			Length:      product.Length,
+			Width:       product.Width,
+			Height:      product.Height,
+		}
+	}
+	return products, nil
+}
+
+func (e *errorResponse) message() string {
+	if e == nil {
+		return ""
+	}
+	return e.Message
+}
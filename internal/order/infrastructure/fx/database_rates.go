@@ -0,0 +1,45 @@
+package fx
+
+import (
+	"context"
+	"time"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	"github.com/jailtonjunior94/order/internal/order/domain/interfaces"
+	"github.com/jailtonjunior94/order/internal/order/domain/vos"
+	"github.com/jailtonjunior94/order/pkg/cache"
+)
+
+type databaseRates struct {
+	rates interfaces.ExchangeRateRepository
+	cache *cache.TTLCache[string, *entities.ExchangeRate]
+}
+
+// NewDatabaseRates reads the latest effective rate from the exchange_rates
+// table; a positive cacheTTL keeps resolved pairs in memory.
+func NewDatabaseRates(rates interfaces.ExchangeRateRepository, cacheTTL time.Duration) interfaces.ExchangeRateProvider {
+	provider := &databaseRates{rates: rates}
+	if cacheTTL > 0 {
+		provider.cache = cache.NewTTLCache[string, *entities.ExchangeRate](cacheTTL)
+	}
+	return provider
+}
+
+func (d *databaseRates) Rate(ctx context.Context, base, quote vos.Currency) (*entities.ExchangeRate, error) {
+	key := base.String() + "/" + quote.String()
+	if d.cache != nil {
+		if rate, ok := d.cache.Get(key); ok {
+			return rate, nil
+		}
+	}
+
+	rate, err := resolve(ctx, base, quote, d.rates.FindLatest)
+	if err != nil {
+		return nil, err
+	}
+
+	if d.cache != nil {
+		d.cache.Set(key, rate)
+	}
+	return rate, nil
+}
@@ -0,0 +1,82 @@
+package fx
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	"github.com/jailtonjunior94/order/internal/order/domain/interfaces"
+	"github.com/jailtonjunior94/order/internal/order/domain/vos"
+)
+
+type (
+	fileRates struct {
+		path  string
+		once  sync.Once
+		rates []*entities.ExchangeRate
+		err   error
+	}
+
+	fileRate struct {
+		Base        string    `json:"base"`
+		Quote       string    `json:"quote"`
+		Rate        float64   `json:"rate"`
+		EffectiveAt time.Time `json:"effective_at"`
+	}
+)
+
+// NewFileRates serves rates from a JSON array of {base, quote, rate,
+// effective_at} objects, read once on first use.
+func NewFileRates(path string) interfaces.ExchangeRateProvider {
+	return &fileRates{path: path}
+}
+
+func (f *fileRates) Rate(ctx context.Context, base, quote vos.Currency) (*entities.ExchangeRate, error) {
+	f.once.Do(f.load)
+	if f.err != nil {
+		return nil, f.err
+	}
+	return resolve(ctx, base, quote, f.findLatest)
+}
+
+func (f *fileRates) load() {
+	content, err := os.ReadFile(f.path)
+	if err != nil {
+		f.err = err
+		return
+	}
+
+	var rows []*fileRate
+	if err := json.Unmarshal(content, &rows); err != nil {
+		f.err = err
+		return
+	}
+
+	for _, row := range rows {
+		f.rates = append(f.rates, &entities.ExchangeRate{
+			Base:        vos.NewCurrency(row.Base),
+			Quote:       vos.NewCurrency(row.Quote),
+			Rate:        row.Rate,
+			EffectiveAt: row.EffectiveAt.UTC(),
+		})
+	}
+}
+
+func (f *fileRates) findLatest(_ context.Context, base, quote vos.Currency) (*entities.ExchangeRate, error) {
+	now := time.Now().UTC()
+
+	var latest *entities.ExchangeRate
+	for _, rate := range f.rates {
+		if rate.Base != base || rate.Quote != quote || rate.EffectiveAt.After(now) {
+			continue
+		}
+
+		if latest == nil || rate.EffectiveAt.After(latest.EffectiveAt) {
+			latest = rate
+		}
+	}
+	return latest, nil
+}
@@ -0,0 +1,45 @@
+package fx
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	"github.com/jailtonjunior94/order/internal/order/domain/interfaces"
+	"github.com/jailtonjunior94/order/internal/order/domain/vos"
+)
+
+const (
+	SourceFile     = "file"
+	SourceDatabase = "database"
+)
+
+type findRate func(ctx context.Context, base, quote vos.Currency) (*entities.ExchangeRate, error)
+
+// resolve looks up the direct pair first and falls back to inverting the
+// opposite pair, so a table only needs one row per currency pair.
+func resolve(ctx context.Context, base, quote vos.Currency, find findRate) (*entities.ExchangeRate, error) {
+	if base == quote {
+		return entities.NewIdentityRate(base, time.Now().UTC()), nil
+	}
+
+	rate, err := find(ctx, base, quote)
+	if err != nil {
+		return nil, err
+	}
+
+	if rate != nil {
+		return rate, nil
+	}
+
+	inverse, err := find(ctx, quote, base)
+	if err != nil {
+		return nil, err
+	}
+
+	if inverse != nil && inverse.Rate > 0 {
+		return inverse.Inverse(), nil
+	}
+	return nil, fmt.Errorf("%w: %s/%s", interfaces.ErrExchangeRateNotFound, base, quote)
+}
@@ -0,0 +1,81 @@
+package fx
+
+import (
+	"context"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	"github.com/jailtonjunior94/order/internal/order/domain/interfaces"
+	"github.com/jailtonjunior94/order/internal/order/domain/vos"
+)
+
+type fakeExchangeRateRepository struct {
+	rate  *entities.ExchangeRate
+	calls int
+}
+
+func (r *fakeExchangeRateRepository) FindLatest(context.Context, vos.Currency, vos.Currency) (*entities.ExchangeRate, error) {
+	r.calls++
+	return r.rate, nil
+}
+
+func TestFileRatesRate(t *testing.T) {
+	now := time.Now().UTC()
+	content := `[
+		{"base": "USD", "quote": "BRL", "rate": 5.00, "effective_at": "` + now.Add(-48*time.Hour).Format(time.RFC3339) + `"},
+		{"base": "usd", "quote": "brl", "rate": 5.50, "effective_at": "` + now.Add(-time.Hour).Format(time.RFC3339) + `"},
+		{"base": "USD", "quote": "BRL", "rate": 9.99, "effective_at": "` + now.Add(time.Hour).Format(time.RFC3339) + `"},
+		{"base": "EUR", "quote": "BRL", "rate": 4.00, "effective_at": "` + now.Add(-time.Hour).Format(time.RFC3339) + `"}
+	]`
+
+	path := filepath.Join(t.TempDir(), "rates.json")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		base        vos.Currency
+		quote       vos.Currency
+		expected    float64
+		expectedErr error
+	}{
+		{name: "latest effective rate", base: vos.CurrencyUSD, quote: vos.CurrencyBRL, expected: 5.50},
+		{name: "inverse pair", base: vos.CurrencyBRL, quote: vos.CurrencyEUR, expected: 0.25},
+		{name: "unknown pair", base: vos.CurrencyUSD, quote: vos.CurrencyEUR, expectedErr: interfaces.ErrExchangeRateNotFound},
+	}
+
+	rates := NewFileRates(path)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rate, err := rates.Rate(context.Background(), tt.base, tt.quote)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("Rate() error = %v, want %v", err, tt.expectedErr)
+			}
+
+			if err == nil && math.Abs(rate.Rate-tt.expected) > 1e-9 {
+				t.Errorf("rate = %v, want %v", rate.Rate, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDatabaseRatesCachesLookups(t *testing.T) {
+	repository := &fakeExchangeRateRepository{rate: &entities.ExchangeRate{Base: vos.CurrencyUSD, Quote: vos.CurrencyBRL, Rate: 5}}
+	rates := NewDatabaseRates(repository, time.Minute)
+
+	for range 2 {
+		if _, err := rates.Rate(context.Background(), vos.CurrencyUSD, vos.CurrencyBRL); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if repository.calls != 1 {
+		t.Errorf("repository calls = %d, want 1", repository.calls)
+	}
+}
@@ -0,0 +1,144 @@
+package inventory
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	"github.com/jailtonjunior94/order/internal/order/domain/interfaces"
+	httpclient "github.com/jailtonjunior94/order/pkg/http-client"
+	"github.com/jailtonjunior94/order/pkg/o11y"
+	"github.com/jailtonjunior94/order/pkg/vos"
+)
+
+const (
+	ModeHTTP       = "http"
+	ModeMemory     = "memory"
+	defaultTimeout = 5 * time.Second
+)
+
+type (
+	httpInventory struct {
+		baseURL string
+		timeout time.Duration
+		client  httpclient.HTTPClient
+		o11y    o11y.Observability
+	}
+
+	reserveRequest struct {
+		OrderID string         `json:"order_id"`
+		Items   []*reserveItem `json:"items"`
+	}
+
+	reserveItem struct {
+		SKU      string `json:"sku"`
+		Quantity uint   `json:"quantity"`
+	}
+
+	reserveResponse struct {
+		ID string `json:"id"`
+	}
+
+	errorResponse struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	}
+)
+
+func NewHTTPInventory(baseURL string, timeout time.Duration, client httpclient.HTTPClient, o11y o11y.Observability) interfaces.InventoryClient {
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	return &httpInventory{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		timeout: timeout,
+		client:  client,
+		o11y:    o11y,
+	}
+}
+
+func (i *httpInventory) Reserve(ctx context.Context, orderID vos.UUID, items []*entities.OrderItem) (string, error) {
+	ctx, span := i.o11y.Start(ctx, "http_inventory.reserve")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, i.timeout)
+	defer cancel()
+
+	request := &reserveRequest{OrderID: orderID.String()}
+	for _, item := range items {
+		request.Items = append(request.Items, &reserveItem{SKU: item.SKU, Quantity: item.Quantity})
+	}
+
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return "", err
+	}
+
+	status, response, failure, err := httpclient.MakeRequest[reserveResponse, errorResponse](
+		ctx,
+		i.client,
+		http.MethodPost,
+		i.baseURL+"/reservations",
+		map[string]string{"Content-Type": "application/json"},
+		bytes.NewReader(payload),
+	)
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error reserve stock", o11y.Attributes{Key: "error", Value: err})
+		return "", err
+	}
+
+	if status == http.StatusConflict {
+		return "", fmt.Errorf("%w: %s", interfaces.ErrInsufficientStock, failure.message())
+	}
+
+	if response == nil || response.ID == "" {
+		err := fmt.Errorf("inventory reserve failed with status %d: %s", status, failure.message())
+		span.AddAttributes(ctx, o11y.Error, "error reserve stock", o11y.Attributes{Key: "error", Value: err})
+		return "", err
+	}
+	return response.ID, nil
+}
+
+// Release treats an unknown reservation as already released so compensation
+// can be retried safely.
+func (i *httpInventory) Release(ctx context.Context, reservationID string) error {
+	ctx, span := i.o11y.Start(ctx, "http_inventory.release")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, i.timeout)
+	defer cancel()
+
+	status, _, failure, err := httpclient.MakeRequest[struct{}, errorResponse](
+		ctx,
+		i.client,
+		http.MethodDelete,
+		i.baseURL+"/reservations/"+reservationID,
+		nil,
+		nil,
+	)
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error release stock", o11y.Attributes{Key: "error", Value: err})
+		return err
+	}
+
+	if status == http.StatusNotFound || (status >= 200 && status <= 299) {
+		return nil
+	}
+
+	err = fmt.Errorf("inventory release failed with status %d: %s", status, failure.message())
+	span.AddAttributes(ctx, o11y.Error, "error release stock", o11y.Attributes{Key: "error", Value: err})
+	return err
+}
+
+func (e *errorResponse) message() string {
+	if e == nil {
+		return ""
+	}
+	return e.Message
+}
@@ -0,0 +1,101 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	"github.com/jailtonjunior94/order/internal/order/domain/interfaces"
+	"github.com/jailtonjunior94/order/pkg/o11y"
+	"github.com/jailtonjunior94/order/pkg/vos"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+type (
+	fakeObservability struct {
+		o11y.Observability
+	}
+
+	fakeSpan struct {
+		trace.Span
+	}
+)
+
+func (fakeObservability) Start(ctx context.Context, _ string, _ ...trace.SpanStartOption) (context.Context, o11y.Span) {
+	return ctx, fakeSpan{Span: trace.SpanFromContext(ctx)}
+}
+
+func (fakeSpan) AddStatus(context.Context, o11y.Code, string) {}
+
+func (fakeSpan) AddAttributes(context.Context, o11y.Code, string, ...o11y.Attributes) {}
+
+func newInventoryServer(t *testing.T, status int, body string) interfaces.InventoryClient {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return NewHTTPInventory(server.URL, 0, server.Client(), fakeObservability{})
+}
+
+func TestHTTPInventoryReserve(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		body          string
+		expected      string
+		expectedErr   error
+		expectFailure bool
+	}{
+		{name: "reserved", status: http.StatusCreated, body: `{"id":"reservation-1"}`, expected: "reservation-1"},
+		{name: "insufficient stock", status: http.StatusConflict, body: `{"message":"SKU-1 is out of stock"}`, expectedErr: interfaces.ErrInsufficientStock},
+		{name: "inventory unavailable", status: http.StatusServiceUnavailable, expectFailure: true},
+		{name: "reservation without id", status: http.StatusOK, body: `{}`, expectFailure: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newInventoryServer(t, tt.status, tt.body)
+			items := []*entities.OrderItem{{SKU: "SKU-1", Quantity: 2}}
+
+			reservationID, err := client.Reserve(context.Background(), vos.UUID{}, items)
+			if tt.expectFailure {
+				if err == nil || errors.Is(err, interfaces.ErrInsufficientStock) {
+					t.Fatalf("Reserve() error = %v, want a failure other than insufficient stock", err)
+				}
+				return
+			}
+
+			if !errors.Is(err, tt.expectedErr) || reservationID != tt.expected {
+				t.Errorf("Reserve() = %q, %v; want %q, %v", reservationID, err, tt.expected, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestHTTPInventoryRelease(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		expectError bool
+	}{
+		{name: "released", status: http.StatusNoContent},
+		{name: "unknown reservation", status: http.StatusNotFound},
+		{name: "inventory unavailable", status: http.StatusServiceUnavailable, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := newInventoryServer(t, tt.status, "").Release(context.Background(), "reservation-1")
+			if (err != nil) != tt.expectError {
+				t.Errorf("Release() error = %v, want error %v", err, tt.expectError)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	"github.com/jailtonjunior94/order/internal/order/domain/interfaces"
+	"github.com/jailtonjunior94/order/pkg/vos"
+)
+
+// MemoryInventory is an in-process fake for local runs and tests. Only skus
+// given a stock level are tracked; any other sku is unlimited.
+type MemoryInventory struct {
+	mu           sync.Mutex
+	stock        map[string]uint
+	reservations map[string][]*entities.OrderItem
+}
+
+func NewMemoryInventory(stock map[string]uint) *MemoryInventory {
+	levels := make(map[string]uint, len(stock))
+	for sku, quantity := range stock {
+		levels[sku] = quantity
+	}
+
+	return &MemoryInventory{
+		stock:        levels,
+		reservations: make(map[string][]*entities.OrderItem),
+	}
+}
+
+func (m *MemoryInventory) Reserve(_ context.Context, orderID vos.UUID, items []*entities.OrderItem) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	requested := make(map[string]uint)
+	for _, item := range items {
+		requested[item.SKU] += item.Quantity
+	}
+
+	for sku, quantity := range requested {
+		available, tracked := m.stock[sku]
+		if tracked && available < quantity {
+			return "", fmt.Errorf("%w: %s has %d, requested %d", interfaces.ErrInsufficientStock, sku, available, quantity)
+		}
+	}
+
+	for sku, quantity := range requested {
+		if _, tracked := m.stock[sku]; tracked {
+			m.stock[sku] -= quantity
+		}
+	}
+
+	reservationID := orderID.String()
+	m.reservations[reservationID] = items
+	return reservationID, nil
+}
+
+func (m *MemoryInventory) Release(_ context.Context, reservationID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, item := range m.reservations[reservationID] {
+		if _, tracked := m.stock[item.SKU]; tracked {
+			m.stock[item.SKU] += item.Quantity
+		}
+	}
+
+	delete(m.reservations, reservationID)
+	return nil
+}
+
+func (m *MemoryInventory) Available(sku string) (uint, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	quantity, tracked := m.stock[sku]
+	return quantity, tracked
+}
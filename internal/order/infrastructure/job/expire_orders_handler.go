@@ -0,0 +1,36 @@
+package job
+
+import (
+	"context"
+
+	"github.com/jailtonjunior94/order/internal/order/usecase"
+	"github.com/jailtonjunior94/order/pkg/o11y"
+)
+
+type ExpireOrdersHandler struct {
+	o11y         o11y.Observability
+	expireOrders usecase.ExpireOrdersUseCase
+}
+
+func NewExpireOrdersHandler(
+	o11y o11y.Observability,
+	expireOrders usecase.ExpireOrdersUseCase,
+) *ExpireOrdersHandler {
+	return &ExpireOrdersHandler{
+		o11y:         o11y,
+		expireOrders: expireOrders,
+	}
+}
+
+func (h *ExpireOrdersHandler) Handle(ctx context.Context) error {
+	ctx, span := h.o11y.Start(ctx, "expire_orders_handler.handle")
+	defer span.End()
+
+	expired, err := h.expireOrders.Execute(ctx)
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error expire orders", o11y.Attributes{Key: "error", Value: err})
+		return err
+	}
+	span.AddAttributes(ctx, o11y.Ok, "", o11y.Attributes{Key: "expired", Value: expired})
+	return nil
+}
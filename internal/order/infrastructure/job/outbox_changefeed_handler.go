@@ -0,0 +1,157 @@
+package job
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	"github.com/jailtonjunior94/order/internal/order/domain/interfaces"
+	"github.com/jailtonjunior94/order/internal/order/usecase"
+	"github.com/jailtonjunior94/order/pkg/database/changefeed"
+	"github.com/jailtonjunior94/order/pkg/o11y"
+	"github.com/jailtonjunior94/order/pkg/vos"
+
+	"github.com/cenkalti/backoff/v4"
+)
+
+const (
+	RelayModeCDC         = "cdc"
+	changefeedCheckpoint = "outbox_changefeed"
+	changefeedResolved   = 10 * time.Second
+)
+
+type (
+	OutboxChangefeedHandler struct {
+		db                  *sql.DB
+		o11y                o11y.Observability
+		checkpoints         interfaces.RelayCheckpointRepository
+		publishOutboxChange usecase.PublishOutboxChangeUseCase
+		drain               func(ctx context.Context) error
+	}
+
+	outboxRow struct {
+		ID          string          `json:"id"`
+		AggregateID *string         `json:"aggregate_id"`
+		EventName   string          `json:"event_name"`
+		Payload     json.RawMessage `json:"payload"`
+	}
+)
+
+func NewOutboxChangefeedHandler(
+	db *sql.DB,
+	o11y o11y.Observability,
+	checkpoints interfaces.RelayCheckpointRepository,
+	publishOutboxChange usecase.PublishOutboxChangeUseCase,
+	drain func(ctx context.Context) error,
+) *OutboxChangefeedHandler {
+	return &OutboxChangefeedHandler{
+		db:                  db,
+		o11y:                o11y,
+		checkpoints:         checkpoints,
+		publishOutboxChange: publishOutboxChange,
+		drain:               drain,
+	}
+}
+
+// Run tails the outbox changefeed until ctx is canceled, resuming from the
+// last persisted resolved timestamp after every disconnect.
+func (h *OutboxChangefeedHandler) Run(ctx context.Context) {
+	retry := backoff.WithContext(backoff.NewExponentialBackOff(backoff.WithMaxElapsedTime(0)), ctx)
+	_ = backoff.Retry(func() error {
+		err := h.stream(ctx)
+		if ctx.Err() != nil {
+			return backoff.Permanent(ctx.Err())
+		}
+		return err
+	}, retry)
+}
+
+func (h *OutboxChangefeedHandler) stream(ctx context.Context) error {
+	ctx, span := h.o11y.Start(ctx, "outbox_changefeed_handler.stream")
+	defer span.End()
+
+	cursor, err := h.checkpoints.Find(ctx, changefeedCheckpoint)
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error find checkpoint", o11y.Attributes{Key: "error", Value: err})
+		return err
+	}
+
+	if cursor == "" {
+		if cursor, err = h.cutOver(ctx); err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error cut over to changefeed", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+	}
+
+	err = changefeed.Stream(ctx, h.db, "outbox", cursor, changefeedResolved, h.handle)
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error stream outbox changefeed", o11y.Attributes{Key: "error", Value: err})
+		return err
+	}
+	return nil
+}
+
+// cutOver runs when there is no checkpoint yet, i.e. when switching from the
+// poll relay. It takes the cursor first and then drains the rows left
+// unpublished with the poll publisher, so every row is either drained or
+// emitted by the feed opened at the cursor; a row may be published by both.
+func (h *OutboxChangefeedHandler) cutOver(ctx context.Context) (string, error) {
+	cursor, err := changefeed.Now(ctx, h.db)
+	if err != nil {
+		return "", err
+	}
+
+	if err := h.drain(ctx); err != nil {
+		return "", err
+	}
+
+	if err := h.checkpoints.Save(ctx, changefeedCheckpoint, cursor); err != nil {
+		return "", err
+	}
+	return cursor, nil
+}
+
+func (h *OutboxChangefeedHandler) handle(ctx context.Context, message *changefeed.Message) error {
+	if message.IsResolved() {
+		return h.checkpoints.Save(ctx, changefeedCheckpoint, message.Resolved)
+	}
+
+	if !message.IsInsert() {
+		return nil
+	}
+
+	event, err := h.toOutbox(message.After)
+	if err != nil {
+		return err
+	}
+	return h.publishOutboxChange.Execute(ctx, event)
+}
+
+func (h *OutboxChangefeedHandler) toOutbox(raw json.RawMessage) (*entities.Outbox, error) {
+	var row outboxRow
+	if err := json.Unmarshal(raw, &row); err != nil {
+		return nil, err
+	}
+
+	id, err := vos.NewUUIDFromString(row.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	event := &entities.Outbox{
+		EventName: row.EventName,
+		Payload:   string(row.Payload),
+	}
+	event.ID = id
+
+	if row.AggregateID != nil {
+		aggregateID, err := vos.NewUUIDFromString(*row.AggregateID)
+		if err != nil {
+			return nil, err
+		}
+		event.AggregateID = aggregateID
+	}
+	return event, nil
+}
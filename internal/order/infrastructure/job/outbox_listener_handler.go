@@ -0,0 +1,102 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jailtonjunior94/order/pkg/jobs"
+	"github.com/jailtonjunior94/order/pkg/o11y"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/lib/pq"
+)
+
+const (
+	RelayModeNotify = "notify"
+	// NotifyChannel and NotifyTrigger are created by the PostgreSQL-only
+	// outbox_notify migration.
+	NotifyChannel        = "outbox_inserted"
+	NotifyTrigger        = "trg_outbox_notify"
+	listenerPingInterval = 90 * time.Second
+	listenerRetryWindow  = time.Minute
+)
+
+type OutboxListenerHandler struct {
+	o11y     o11y.Observability
+	listener *pq.Listener
+	drain    func(ctx context.Context) error
+}
+
+func NewOutboxListenerHandler(
+	o11y o11y.Observability,
+	listener *pq.Listener,
+	drain func(ctx context.Context) error,
+) *OutboxListenerHandler {
+	return &OutboxListenerHandler{
+		o11y:     o11y,
+		listener: listener,
+		drain:    drain,
+	}
+}
+
+func (h *OutboxListenerHandler) Run(ctx context.Context) {
+	ticker := time.NewTicker(listenerPingInterval)
+	defer ticker.Stop()
+
+	defer h.listener.Close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-h.listener.NotificationChannel():
+			h.coalesce()
+			h.handle(ctx)
+		case <-ticker.C:
+			go func() {
+				_ = h.listener.Ping()
+			}()
+		}
+	}
+}
+
+// handle drains the outbox, backing off and retrying failed drains for up to
+// listenerRetryWindow; rows still pending after that are left to the poll job.
+// A drain skipped because another replica holds the publish lease is not an
+// error, that replica publishes the rows.
+func (h *OutboxListenerHandler) handle(ctx context.Context) {
+	ctx, span := h.o11y.Start(ctx, "outbox_listener_handler.handle")
+	defer span.End()
+
+	retry := backoff.WithContext(backoff.NewExponentialBackOff(backoff.WithMaxElapsedTime(listenerRetryWindow)), ctx)
+	err := backoff.RetryNotify(func() error {
+		if err := h.drain(ctx); err != nil && !errors.Is(err, jobs.ErrJobSkipped) {
+			return err
+		}
+		return nil
+	}, retry, func(err error, wait time.Duration) {
+		span.AddAttributes(ctx, o11y.Error, "error drain outbox, retrying",
+			o11y.Attributes{Key: "error", Value: err},
+			o11y.Attributes{Key: "retry_in", Value: wait.String()},
+		)
+	})
+
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error drain outbox", o11y.Attributes{Key: "error", Value: err})
+		return
+	}
+	span.AddAttributes(ctx, o11y.Ok, "")
+}
+
+// coalesce discards notifications already queued, since a single drain
+// publishes every pending row regardless of how many inserts woke it up.
+func (h *OutboxListenerHandler) coalesce() {
+	for {
+		select {
+		case <-h.listener.NotificationChannel():
+		default:
+			return
+		}
+	}
+}
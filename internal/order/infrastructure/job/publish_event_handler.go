@@ -2,12 +2,14 @@ package job
 
 import (
 	"context"
+	"sync"
 
 	"github.com/jailtonjunior94/order/internal/order/usecase"
 	"github.com/jailtonjunior94/order/pkg/o11y"
 )
 
 type PublishEventHandler struct {
+	mu           sync.Mutex
 	o11y         o11y.Observability
 	publishEvent usecase.PublishEventUseCase
 }
@@ -22,13 +24,17 @@ func NewPublishEventHandler(
 	}
 }
 
-func (h *PublishEventHandler) Handle() {
-	ctx, span := h.o11y.Start(context.Background(), "publish_event_handler.handle")
+func (h *PublishEventHandler) Handle(ctx context.Context) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	ctx, span := h.o11y.Start(ctx, "publish_event_handler.handle")
 	defer span.End()
 
 	if err := h.publishEvent.Execute(ctx); err != nil {
 		span.AddAttributes(ctx, o11y.Error, "error publish event", o11y.Attributes{Key: "error", Value: err})
-		return
+		return err
 	}
 	span.AddAttributes(ctx, o11y.Ok, "")
+	return nil
 }
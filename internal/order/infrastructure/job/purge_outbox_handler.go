@@ -0,0 +1,54 @@
+package job
+
+import (
+	"context"
+	"log"
+
+	"github.com/jailtonjunior94/order/internal/order/usecase"
+	"github.com/jailtonjunior94/order/pkg/o11y"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+type PurgeOutboxHandler struct {
+	o11y        o11y.Observability
+	purgeOutbox usecase.PurgeOutboxUseCase
+	archive     bool
+	purged      metric.Int64Counter
+}
+
+func NewPurgeOutboxHandler(
+	o11y o11y.Observability,
+	purgeOutbox usecase.PurgeOutboxUseCase,
+	archive bool,
+) *PurgeOutboxHandler {
+	purged, err := o11y.MeterProvider().Meter("order").Int64Counter(
+		"outbox_rows_purged",
+		metric.WithDescription("Number of published and skipped outbox rows deleted or archived by the retention job"),
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return &PurgeOutboxHandler{
+		o11y:        o11y,
+		purgeOutbox: purgeOutbox,
+		archive:     archive,
+		purged:      purged,
+	}
+}
+
+func (h *PurgeOutboxHandler) Handle(ctx context.Context) error {
+	ctx, span := h.o11y.Start(ctx, "purge_outbox_handler.handle")
+	defer span.End()
+
+	purged, err := h.purgeOutbox.Execute(ctx)
+	h.purged.Add(ctx, purged, metric.WithAttributes(attribute.Bool("archive", h.archive)))
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error purge outbox", o11y.Attributes{Key: "error", Value: err})
+		return err
+	}
+	span.AddAttributes(ctx, o11y.Ok, "", o11y.Attributes{Key: "purged", Value: purged})
+	return nil
+}
@@ -0,0 +1,36 @@
+package job
+
+import (
+	"context"
+
+	"github.com/jailtonjunior94/order/internal/order/usecase"
+	"github.com/jailtonjunior94/order/pkg/o11y"
+)
+
+type TimeoutOrderSagasHandler struct {
+	o11y              o11y.Observability
+	timeoutOrderSagas usecase.TimeoutOrderSagasUseCase
+}
+
+func NewTimeoutOrderSagasHandler(
+	o11y o11y.Observability,
+	timeoutOrderSagas usecase.TimeoutOrderSagasUseCase,
+) *TimeoutOrderSagasHandler {
+	return &TimeoutOrderSagasHandler{
+		o11y:              o11y,
+		timeoutOrderSagas: timeoutOrderSagas,
+	}
+}
+
+func (h *TimeoutOrderSagasHandler) Handle(ctx context.Context) error {
+	ctx, span := h.o11y.Start(ctx, "timeout_order_sagas_handler.handle")
+	defer span.End()
+
+	timedOut, err := h.timeoutOrderSagas.Execute(ctx)
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error time out order sagas", o11y.Attributes{Key: "error", Value: err})
+		return err
+	}
+	span.AddAttributes(ctx, o11y.Ok, "", o11y.Attributes{Key: "timed_out", Value: timedOut})
+	return nil
+}
@@ -0,0 +1,89 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/dtos"
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	"github.com/jailtonjunior94/order/internal/order/usecase"
+	"github.com/jailtonjunior94/order/pkg/o11y"
+	"github.com/jailtonjunior94/order/pkg/vos"
+)
+
+const (
+	StockReservedEvent = "stock_reserved"
+	StockRejectedEvent = "stock_rejected"
+	StockReleasedEvent = "stock_released"
+)
+
+type InventoryHandler struct {
+	o11y      o11y.Observability
+	orderSaga usecase.OrderSagaUseCase
+}
+
+func NewInventoryHandler(
+	o11y o11y.Observability,
+	orderSaga usecase.OrderSagaUseCase,
+) *InventoryHandler {
+	return &InventoryHandler{
+		o11y:      o11y,
+		orderSaga: orderSaga,
+	}
+}
+
+// Handle advances the order saga from inventory replies; replies the saga is
+// no longer waiting for, such as redeliveries, are acknowledged and dropped.
+func (h *InventoryHandler) Handle(ctx context.Context, body []byte) error {
+	ctx, span := h.o11y.Start(ctx, "inventory_handler.handle")
+	defer span.End()
+
+	var input dtos.InventoryEventInput
+	if err := json.Unmarshal(body, &input); err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error decode inventory event", o11y.Attributes{Key: "error", Value: err})
+		return nil
+	}
+
+	orderID, err := vos.NewUUIDFromString(input.OrderID)
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "invalid inventory event", o11y.Attributes{Key: "order_id", Value: input.OrderID})
+		return nil
+	}
+
+	switch input.EventName {
+	case StockReservedEvent:
+		err = h.orderSaga.StockReserved(ctx, orderID, input.Step, input.ReservationID)
+	case StockRejectedEvent:
+		err = h.orderSaga.StockRejected(ctx, orderID, input.Reason)
+	case StockReleasedEvent:
+		err = h.orderSaga.StockReleased(ctx, orderID, input.ReservationID)
+	default:
+		span.AddAttributes(ctx, o11y.Ok, "ignored inventory event", o11y.Attributes{Key: "event_name", Value: input.EventName})
+		return nil
+	}
+
+	if isRejected(err) {
+		span.AddAttributes(ctx, o11y.Error, "inventory event rejected",
+			o11y.Attributes{Key: "event_name", Value: input.EventName},
+			o11y.Attributes{Key: "error", Value: err},
+		)
+		return nil
+	}
+
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error handle inventory event", o11y.Attributes{Key: "error", Value: err})
+		return err
+	}
+	span.AddAttributes(ctx, o11y.Ok, "", o11y.Attributes{Key: "event_name", Value: input.EventName})
+	return nil
+}
+
+func isRejected(err error) bool {
+	return errors.Is(err, usecase.ErrOrderNotFound) ||
+		errors.Is(err, usecase.ErrSagaNotFound) ||
+		errors.Is(err, entities.ErrOrderNotPending) ||
+		errors.Is(err, entities.ErrOrderNotPayable) ||
+		errors.Is(err, entities.ErrPaymentExceedsBalance) ||
+		errors.Is(err, entities.ErrSagaStepMismatch)
+}
@@ -0,0 +1,92 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/dtos"
+	"github.com/jailtonjunior94/order/internal/order/usecase"
+	"github.com/jailtonjunior94/order/pkg/o11y"
+	"github.com/jailtonjunior94/order/pkg/vos"
+)
+
+const (
+	PaymentApprovedEvent = "payment_approved"
+	PaymentDeclinedEvent = "payment_declined"
+)
+
+type PaymentHandler struct {
+	o11y           o11y.Observability
+	orderSaga      usecase.OrderSagaUseCase
+	markAsPaid     usecase.MarkAsPaidUseCase
+	declinePayment usecase.DeclinePaymentUseCase
+}
+
+func NewPaymentHandler(
+	o11y o11y.Observability,
+	orderSaga usecase.OrderSagaUseCase,
+	markAsPaid usecase.MarkAsPaidUseCase,
+	declinePayment usecase.DeclinePaymentUseCase,
+) *PaymentHandler {
+	return &PaymentHandler{
+		o11y:           o11y,
+		orderSaga:      orderSaga,
+		markAsPaid:     markAsPaid,
+		declinePayment: declinePayment,
+	}
+}
+
+// Handle returns an error only for failures worth retrying; malformed
+// messages and invalid transitions are logged and acknowledged. Orders placed
+// before the saga existed fall back to the standalone payment use cases.
+func (h *PaymentHandler) Handle(ctx context.Context, body []byte) error {
+	ctx, span := h.o11y.Start(ctx, "payment_handler.handle")
+	defer span.End()
+
+	var input dtos.PaymentEventInput
+	if err := json.Unmarshal(body, &input); err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error decode payment event", o11y.Attributes{Key: "error", Value: err})
+		return nil
+	}
+
+	orderID, err := vos.NewUUIDFromString(input.OrderID)
+	if err != nil || input.PaymentID == "" {
+		span.AddAttributes(ctx, o11y.Error, "invalid payment event",
+			o11y.Attributes{Key: "order_id", Value: input.OrderID},
+			o11y.Attributes{Key: "payment_id", Value: input.PaymentID},
+		)
+		return nil
+	}
+
+	switch input.EventName {
+	case PaymentApprovedEvent:
+		err = h.orderSaga.PaymentAuthorized(ctx, orderID, input.PaymentID, input.Step, input.Amount)
+		if errors.Is(err, usecase.ErrSagaNotFound) {
+			_, err = h.markAsPaid.ExecuteForPayment(ctx, orderID, input.PaymentID, input.Amount)
+		}
+	case PaymentDeclinedEvent:
+		err = h.orderSaga.PaymentDeclined(ctx, orderID, input.PaymentID, input.Step, input.Reason)
+		if errors.Is(err, usecase.ErrSagaNotFound) {
+			_, err = h.declinePayment.Execute(ctx, orderID, input.PaymentID, input.Reason)
+		}
+	default:
+		span.AddAttributes(ctx, o11y.Ok, "ignored payment event", o11y.Attributes{Key: "event_name", Value: input.EventName})
+		return nil
+	}
+
+	if isRejected(err) {
+		span.AddAttributes(ctx, o11y.Error, "payment event rejected",
+			o11y.Attributes{Key: "event_name", Value: input.EventName},
+			o11y.Attributes{Key: "error", Value: err},
+		)
+		return nil
+	}
+
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error handle payment event", o11y.Attributes{Key: "error", Value: err})
+		return err
+	}
+	span.AddAttributes(ctx, o11y.Ok, "", o11y.Attributes{Key: "event_name", Value: input.EventName})
+	return nil
+}
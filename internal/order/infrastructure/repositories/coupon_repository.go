@@ -0,0 +1,271 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	"github.com/jailtonjunior94/order/internal/order/domain/interfaces"
+	"github.com/jailtonjunior94/order/pkg/database/postgres"
+	"github.com/jailtonjunior94/order/pkg/o11y"
+	sharedVos "github.com/jailtonjunior94/order/pkg/vos"
+)
+
+type couponRepository struct {
+	db   *sql.DB
+	tx   *sql.Tx
+	o11y o11y.Observability
+}
+
+func NewCouponRepository(db *sql.DB, tx *sql.Tx, o11y o11y.Observability) interfaces.CouponRepository {
+	return &couponRepository{
+		db:   db,
+		tx:   tx,
+		o11y: o11y,
+	}
+}
+
+func (r *couponRepository) Insert(ctx context.Context, coupon *entities.Coupon) error {
+	ctx, span := r.o11y.Start(ctx, "coupon_repository.insert")
+	defer span.End()
+
+	query := `insert into
+				coupons (
+					id,
+					code,
+					kind,
+					value,
+					sku,
+					buy_quantity,
+					get_quantity,
+					minimum_spend,
+					max_uses,
+					max_uses_per_customer,
+					uses,
+					active,
+					starts_at,
+					ends_at,
+					created_at,
+					updated_at
+				)
+			  values
+				($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)`
+
+	_, err := r.tx.ExecContext(
+		ctx,
+		query,
+		coupon.ID.Value,
+		coupon.Code,
+		coupon.Kind.String(),
+		coupon.Value,
+		nullString(coupon.SKU),
+		coupon.BuyQuantity,
+		coupon.GetQuantity,
+		coupon.MinimumSpend,
+		coupon.MaxUses,
+		coupon.MaxUsesPerCustomer,
+		coupon.Uses,
+		coupon.Active,
+		coupon.StartsAt,
+		coupon.EndsAt.Time,
+		coupon.CreatedAt,
+		coupon.UpdatedAt.Time,
+	)
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error insert coupon", o11y.Attributes{Key: "error", Value: err})
+		if postgres.IsUniqueViolation(err) {
+			return interfaces.ErrCouponCodeTaken
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *couponRepository) Update(ctx context.Context, coupon *entities.Coupon) error {
+	ctx, span := r.o11y.Start(ctx, "coupon_repository.update")
+	defer span.End()
+
+	query := `update
+				coupons
+			  set
+				uses = $1,
+				active = $2,
+				updated_at = $3
+			  where
+				id = $4`
+
+	_, err := r.tx.ExecContext(ctx, query, coupon.Uses, coupon.Active, coupon.UpdatedAt.Time, coupon.ID.Value)
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error update coupon", o11y.Attributes{Key: "error", Value: err})
+		return err
+	}
+	return nil
+}
+
+// FindByCode locks the coupon row so usage limits hold under concurrent orders.
+func (r *couponRepository) FindByCode(ctx context.Context, code string) (*entities.Coupon, error) {
+	ctx, span := r.o11y.Start(ctx, "coupon_repository.find_by_code")
+	defer span.End()
+
+	query := `select
+				id,
+				code,
+				kind,
+				value,
+				sku,
+				buy_quantity,
+				get_quantity,
+				minimum_spend,
+				max_uses,
+				max_uses_per_customer,
+				uses,
+				active,
+				starts_at,
+				ends_at,
+				created_at,
+				updated_at
+			  from
+				coupons
+			  where
+				code = $1
+			  for update`
+
+	coupon, err := scanCoupon(r.tx.QueryRowContext(ctx, query, code))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		span.AddAttributes(ctx, o11y.Error, "error find coupon", o11y.Attributes{Key: "code", Value: code})
+		return nil, err
+	}
+	return coupon, nil
+}
+
+// FindRedeemedBy returns the coupon redeemed by the order, if any, locking it
+// like FindByCode.
+func (r *couponRepository) FindRedeemedBy(ctx context.Context, orderID sharedVos.UUID) (*entities.Coupon, error) {
+	ctx, span := r.o11y.Start(ctx, "coupon_repository.find_redeemed_by")
+	defer span.End()
+
+	query := `select
+				c.id,
+				c.code,
+				c.kind,
+				c.value,
+				c.sku,
+				c.buy_quantity,
+				c.get_quantity,
+				c.minimum_spend,
+				c.max_uses,
+				c.max_uses_per_customer,
+				c.uses,
+				c.active,
+				c.starts_at,
+				c.ends_at,
+				c.created_at,
+				c.updated_at
+			  from
+				coupons c
+				inner join coupon_redemptions cr on cr.coupon_id = c.id
+			  where
+				cr.order_id = $1
+			  for update`
+
+	coupon, err := scanCoupon(r.tx.QueryRowContext(ctx, query, orderID.Value))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		span.AddAttributes(ctx, o11y.Error, "error find redeemed coupon", o11y.Attributes{Key: "order_id", Value: orderID.String()})
+		return nil, err
+	}
+	return coupon, nil
+}
+
+func scanCoupon(row scanner) (*entities.Coupon, error) {
+	var (
+		coupon entities.Coupon
+		sku    sql.NullString
+	)
+
+	err := row.Scan(
+		&coupon.ID.Value,
+		&coupon.Code,
+		&coupon.Kind,
+		&coupon.Value,
+		&sku,
+		&coupon.BuyQuantity,
+		&coupon.GetQuantity,
+		&coupon.MinimumSpend,
+		&coupon.MaxUses,
+		&coupon.MaxUsesPerCustomer,
+		&coupon.Uses,
+		&coupon.Active,
+		&coupon.StartsAt,
+		&coupon.EndsAt.Time,
+		&coupon.CreatedAt,
+		&coupon.UpdatedAt.Time,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	coupon.SKU = sku.String
+	return &coupon, nil
+}
+
+func (r *couponRepository) CountRedemptions(ctx context.Context, couponID sharedVos.UUID, customerID string) (int, error) {
+	ctx, span := r.o11y.Start(ctx, "coupon_repository.count_redemptions")
+	defer span.End()
+
+	query := `select
+				count(*)
+			  from
+				coupon_redemptions
+			  where
+				coupon_id = $1
+				and customer_id = $2`
+
+	var count int
+	if err := r.tx.QueryRowContext(ctx, query, couponID.Value, customerID).Scan(&count); err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error count redemptions", o11y.Attributes{Key: "error", Value: err})
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *couponRepository) InsertRedemption(ctx context.Context, couponID, orderID sharedVos.UUID, customerID string) error {
+	ctx, span := r.o11y.Start(ctx, "coupon_repository.insert_redemption")
+	defer span.End()
+
+	query := `insert into
+				coupon_redemptions (coupon_id, order_id, customer_id, created_at)
+			  values
+				($1, $2, $3, $4)`
+
+	_, err := r.tx.ExecContext(ctx, query, couponID.Value, orderID.Value, nullString(customerID), time.Now().UTC())
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error insert redemption", o11y.Attributes{Key: "error", Value: err})
+		return err
+	}
+	return nil
+}
+
+func (r *couponRepository) DeleteRedemption(ctx context.Context, couponID, orderID sharedVos.UUID) error {
+	ctx, span := r.o11y.Start(ctx, "coupon_repository.delete_redemption")
+	defer span.End()
+
+	query := `delete from
+				coupon_redemptions
+			  where
+				coupon_id = $1
+				and order_id = $2`
+
+	_, err := r.tx.ExecContext(ctx, query, couponID.Value, orderID.Value)
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error delete redemption", o11y.Attributes{Key: "error", Value: err})
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	"github.com/jailtonjunior94/order/internal/order/domain/interfaces"
+	"github.com/jailtonjunior94/order/internal/order/domain/vos"
+	"github.com/jailtonjunior94/order/pkg/o11y"
+)
+
+type exchangeRateRepository struct {
+	db   *sql.DB
+	o11y o11y.Observability
+}
+
+func NewExchangeRateRepository(db *sql.DB, o11y o11y.Observability) interfaces.ExchangeRateRepository {
+	return &exchangeRateRepository{
+		db:   db,
+		o11y: o11y,
+	}
+}
+
+func (r *exchangeRateRepository) FindLatest(ctx context.Context, base, quote vos.Currency) (*entities.ExchangeRate, error) {
+	ctx, span := r.o11y.Start(ctx, "exchange_rate_repository.find_latest")
+	defer span.End()
+
+	query := `select
+				base,
+				quote,
+				rate,
+				effective_at
+			  from
+				exchange_rates
+			  where
+				base = $1
+				and quote = $2
+				and effective_at <= now()
+			  order by
+				effective_at desc
+			  limit 1`
+
+	var rate entities.ExchangeRate
+	err := r.db.QueryRowContext(ctx, query, base.String(), quote.String()).Scan(
+		&rate.Base,
+		&rate.Quote,
+		&rate.Rate,
+		&rate.EffectiveAt,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		span.AddAttributes(ctx, o11y.Error, "error find exchange rate", o11y.Attributes{Key: "error", Value: err})
+		return nil, err
+	}
+	return &rate, nil
+}
@@ -3,15 +3,23 @@ package repositories
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/jailtonjunior94/order/internal/order/domain/entities"
 	"github.com/jailtonjunior94/order/internal/order/domain/interfaces"
 	"github.com/jailtonjunior94/order/internal/order/domain/vos"
+	"github.com/jailtonjunior94/order/pkg/audit"
+	"github.com/jailtonjunior94/order/pkg/database/uow"
 	"github.com/jailtonjunior94/order/pkg/o11y"
 	sharedVos "github.com/jailtonjunior94/order/pkg/vos"
+
+	"github.com/lib/pq"
 )
 
 type orderRepository struct {
+	uow.AggregateTracker
 	db   *sql.DB
 	tx   *sql.Tx
 	o11y o11y.Observability
@@ -25,23 +33,108 @@ func NewOrderRepository(db *sql.DB, tx *sql.Tx, o11y o11y.Observability) interfa
 	}
 }
 
-func (r *orderRepository) FindAll(ctx context.Context, status vos.Status) ([]*entities.Order, error) {
-	ctx, span := r.o11y.Start(ctx, "order_repository.find_all")
+// List returns the newest orders first; the items, taxes, addresses and
+// payments of the whole page are loaded in batches.
+func (r *orderRepository) List(ctx context.Context, filter *interfaces.OrderFilter) ([]*entities.Order, error) {
+	ctx, span := r.o11y.Start(ctx, "order_repository.list")
+	defer span.End()
+
+	var (
+		conditions []string
+		args       []any
+	)
+
+	if filter.CustomerID != "" {
+		args = append(args, filter.CustomerID)
+		conditions = append(conditions, fmt.Sprintf("o.customer_id = $%d", len(args)))
+	}
+
+	if filter.Status != "" {
+		args = append(args, filter.Status.String())
+		conditions = append(conditions, fmt.Sprintf("o.status = $%d", len(args)))
+	}
+
+	query := `select
+				id,
+				customer_id,
+				currency,
+				reporting_currency,
+				exchange_rate,
+				exchange_rate_at,
+				tax_region,
+				tax_inclusive,
+				shipping_method,
+				shipping_carrier,
+				shipping_amount,
+				status,
+				version,
+				created_at,
+				updated_at
+			  from
+				orders o`
+
+	if len(conditions) > 0 {
+		query += " where " + strings.Join(conditions, " and ")
+	}
+	query += " order by o.created_at desc"
+
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" limit $%d", len(args))
+	}
+
+	rows, err := r.tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error list orders", o11y.Attributes{Key: "error", Value: err})
+		return nil, err
+	}
+	defer rows.Close()
+
+	var orders []*entities.Order
+	for rows.Next() {
+		order, err := scanOrder(rows)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error scan row", o11y.Attributes{Key: "error", Value: err})
+			return nil, err
+		}
+		orders = append(orders, order)
+	}
+
+	if err := rows.Err(); err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error list orders", o11y.Attributes{Key: "error", Value: err})
+		return nil, err
+	}
+
+	if err := r.loadDetails(ctx, orders...); err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error load order details", o11y.Attributes{Key: "error", Value: err})
+		return nil, err
+	}
+	return orders, nil
+}
+
+func (r *orderRepository) FindStale(ctx context.Context, status vos.Status, before time.Time, limit int) ([]*entities.Order, error) {
+	ctx, span := r.o11y.Start(ctx, "order_repository.find_stale")
 	defer span.End()
 
-	query := `select 
+	query := `select
 				id,
 				status,
+				version,
 				created_at,
 				updated_at
 			  from
 				orders
 			  where
-				status = $1`
+				status = $1
+				and created_at < $2
+			  order by
+				created_at
+			  limit $3
+			  for update`
 
-	rows, err := r.tx.QueryContext(ctx, query, status.String())
+	rows, err := r.tx.QueryContext(ctx, query, status.String(), before, limit)
 	if err != nil {
-		span.AddAttributes(ctx, o11y.Error, "error find all orders", o11y.Attributes{Key: "error", Value: err})
+		span.AddAttributes(ctx, o11y.Error, "error find stale orders", o11y.Attributes{Key: "error", Value: err})
 		return nil, err
 	}
 	defer rows.Close()
@@ -52,6 +145,7 @@ func (r *orderRepository) FindAll(ctx context.Context, status vos.Status) ([]*en
 		err := rows.Scan(
 			&order.ID.Value,
 			&order.Status,
+			&order.Version,
 			&order.CreatedAt,
 			&order.UpdatedAt.Time,
 		)
@@ -61,7 +155,7 @@ func (r *orderRepository) FindAll(ctx context.Context, status vos.Status) ([]*en
 		}
 		orders = append(orders, &order)
 	}
-	return orders, nil
+	return orders, rows.Err()
 }
 
 func (r *orderRepository) Find(ctx context.Context, orderID sharedVos.UUID) (*entities.Order, error) {
@@ -70,7 +164,18 @@ func (r *orderRepository) Find(ctx context.Context, orderID sharedVos.UUID) (*en
 
 	query := `select
 				id,
+				customer_id,
+				currency,
+				reporting_currency,
+				exchange_rate,
+				exchange_rate_at,
+				tax_region,
+				tax_inclusive,
+				shipping_method,
+				shipping_carrier,
+				shipping_amount,
 				status,
+				version,
 				created_at,
 				updated_at
 			  from
@@ -78,23 +183,331 @@ func (r *orderRepository) Find(ctx context.Context, orderID sharedVos.UUID) (*en
 			  where
 				id = $1`
 
-	var order entities.Order
-	err := r.tx.QueryRowContext(ctx, query, orderID.String()).Scan(
+	order, err := scanOrder(r.tx.QueryRowContext(ctx, query, orderID.String()))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			span.AddAttributes(ctx, o11y.Ok, "order found", o11y.Attributes{Key: "order_id", Value: orderID.String()})
+			return nil, nil
+		}
+		span.AddAttributes(ctx, o11y.Error, "error find order", o11y.Attributes{Key: "order_id", Value: orderID.String()})
+		return nil, err
+	}
+
+	if err := r.loadDetails(ctx, order); err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error load order details", o11y.Attributes{Key: "error", Value: err})
+		return nil, err
+	}
+	return order, nil
+}
+
+func scanOrder(row scanner) (*entities.Order, error) {
+	var (
+		order             entities.Order
+		customerID        sql.NullString
+		reportingCurrency sql.NullString
+		exchangeRateAt    sql.NullTime
+		taxRegion         sql.NullString
+		shippingMethod    sql.NullString
+		shippingCarrier   sql.NullString
+	)
+
+	err := row.Scan(
 		&order.ID.Value,
+		&customerID,
+		&order.Currency,
+		&reportingCurrency,
+		&order.ExchangeRate,
+		&exchangeRateAt,
+		&taxRegion,
+		&order.TaxInclusive,
+		&shippingMethod,
+		&shippingCarrier,
+		&order.ShippingAmount,
 		&order.Status,
+		&order.Version,
 		&order.CreatedAt,
 		&order.UpdatedAt.Time,
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	order.CustomerID = customerID.String
+	order.ReportingCurrency = vos.Currency(reportingCurrency.String)
+	order.ExchangeRateAt = exchangeRateAt.Time
+	order.TaxRegion = taxRegion.String
+	order.ShippingMethod = shippingMethod.String
+	order.ShippingCarrier = shippingCarrier.String
+	return &order, nil
+}
+
+// loadDetails fills in the items, taxes, addresses and payments of orders
+// with one query per table, however many orders there are.
+func (r *orderRepository) loadDetails(ctx context.Context, orders ...*entities.Order) error {
+	if len(orders) == 0 {
+		return nil
+	}
 
+	orderIDs := make([]string, 0, len(orders))
+	for _, order := range orders {
+		orderIDs = append(orderIDs, order.ID.String())
+	}
+
+	items, err := r.findItems(ctx, orderIDs)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			span.AddAttributes(ctx, o11y.Ok, "order found", o11y.Attributes{Key: "order_id", Value: orderID.String()})
-			return nil, nil
+		return err
+	}
+
+	taxes, err := r.findTaxes(ctx, orderIDs)
+	if err != nil {
+		return err
+	}
+
+	addresses, err := r.findAddresses(ctx, orderIDs)
+	if err != nil {
+		return err
+	}
+
+	payments, err := r.findPayments(ctx, orderIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, order := range orders {
+		orderID := order.ID.String()
+		orderItems := items[orderID]
+		for _, item := range orderItems {
+			for _, tax := range taxes[orderID] {
+				if tax.OrderItemID == item.ID {
+					item.Taxes = append(item.Taxes, tax)
+				}
+			}
 		}
-		span.AddAttributes(ctx, o11y.Error, "error find order", o11y.Attributes{Key: "order_id", Value: orderID.String()})
+
+		order.ShippingAddress = addresses[orderID][vos.AddressShipping]
+		order.BillingAddress = addresses[orderID][vos.AddressBilling]
+		order.Payments = payments[orderID]
+		order.AddItems(orderItems)
+	}
+	return nil
+}
+
+func (r *orderRepository) findPayments(ctx context.Context, orderIDs []string) (map[string][]*entities.Payment, error) {
+	query := `select
+				id,
+				order_id,
+				amount,
+				currency,
+				method,
+				provider_reference,
+				status,
+				refunded_amount,
+				created_at,
+				updated_at
+			  from
+				payments
+			  where
+				order_id = any($1)
+			  order by
+				created_at`
+
+	rows, err := r.tx.QueryContext(ctx, query, pq.Array(orderIDs))
+	if err != nil {
 		return nil, err
 	}
-	return &order, nil
+	defer rows.Close()
+
+	payments := make(map[string][]*entities.Payment)
+	for rows.Next() {
+		var (
+			payment           entities.Payment
+			providerReference sql.NullString
+		)
+
+		err := rows.Scan(
+			&payment.ID.Value,
+			&payment.OrderID.Value,
+			&payment.Amount,
+			&payment.Currency,
+			&payment.Method,
+			&providerReference,
+			&payment.Status,
+			&payment.RefundedAmount,
+			&payment.CreatedAt,
+			&payment.UpdatedAt.Time,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		payment.ProviderReference = providerReference.String
+		orderID := payment.OrderID.String()
+		payments[orderID] = append(payments[orderID], &payment)
+	}
+	return payments, rows.Err()
+}
+
+func (r *orderRepository) findItems(ctx context.Context, orderIDs []string) (map[string][]*entities.OrderItem, error) {
+	query := `select
+				id,
+				order_id,
+				sku,
+				product_name,
+				quantity,
+				price,
+				discount,
+				tax_category,
+				tax,
+				created_at,
+				updated_at
+			  from
+				order_items
+			  where
+				order_id = any($1)
+			  order by
+				created_at`
+
+	rows, err := r.tx.QueryContext(ctx, query, pq.Array(orderIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make(map[string][]*entities.OrderItem)
+	for rows.Next() {
+		var (
+			item        entities.OrderItem
+			sku         sql.NullString
+			taxCategory sql.NullString
+		)
+
+		err := rows.Scan(
+			&item.ID.Value,
+			&item.OrderID.Value,
+			&sku,
+			&item.ProductName,
+			&item.Quantity,
+			&item.Price,
+			&item.Discount,
+			&taxCategory,
+			&item.Tax,
+			&item.CreatedAt,
+			&item.UpdatedAt.Time,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		item.SKU = sku.String
+		item.TaxCategory = taxCategory.String
+		orderID := item.OrderID.String()
+		items[orderID] = append(items[orderID], &item)
+	}
+	return items, rows.Err()
+}
+
+func (r *orderRepository) findTaxes(ctx context.Context, orderIDs []string) (map[string][]*entities.OrderItemTax, error) {
+	query := `select
+				id,
+				order_id,
+				order_item_id,
+				jurisdiction,
+				rate,
+				amount,
+				created_at
+			  from
+				order_item_taxes
+			  where
+				order_id = any($1)
+			  order by
+				jurisdiction`
+
+	rows, err := r.tx.QueryContext(ctx, query, pq.Array(orderIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	taxes := make(map[string][]*entities.OrderItemTax)
+	for rows.Next() {
+		var tax entities.OrderItemTax
+		err := rows.Scan(
+			&tax.ID.Value,
+			&tax.OrderID.Value,
+			&tax.OrderItemID.Value,
+			&tax.Jurisdiction,
+			&tax.Rate,
+			&tax.Amount,
+			&tax.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		orderID := tax.OrderID.String()
+		taxes[orderID] = append(taxes[orderID], &tax)
+	}
+	return taxes, rows.Err()
+}
+
+func (r *orderRepository) findAddresses(ctx context.Context, orderIDs []string) (map[string]map[vos.AddressKind]*vos.Address, error) {
+	query := `select
+				order_id,
+				kind,
+				street,
+				number,
+				complement,
+				district,
+				city,
+				state,
+				postal_code,
+				country
+			  from
+				order_addresses
+			  where
+				order_id = any($1)`
+
+	rows, err := r.tx.QueryContext(ctx, query, pq.Array(orderIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	addresses := make(map[string]map[vos.AddressKind]*vos.Address)
+	for rows.Next() {
+		var (
+			orderID                             string
+			kind                                vos.AddressKind
+			address                             vos.Address
+			number, complement, district, state sql.NullString
+		)
+
+		err := rows.Scan(
+			&orderID,
+			&kind,
+			&address.Street,
+			&number,
+			&complement,
+			&district,
+			&address.City,
+			&state,
+			&address.PostalCode,
+			&address.Country,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		address.Number = number.String
+		address.Complement = complement.String
+		address.District = district.String
+		address.State = state.String
+		if addresses[orderID] == nil {
+			addresses[orderID] = make(map[vos.AddressKind]*vos.Address)
+		}
+		addresses[orderID][kind] = &address
+	}
+	return addresses, rows.Err()
 }
 
 func (r *orderRepository) Insert(ctx context.Context, order *entities.Order) error {
@@ -102,15 +515,52 @@ func (r *orderRepository) Insert(ctx context.Context, order *entities.Order) err
 	defer span.End()
 
 	query := `insert into
-				orders (id, status, created_at, updated_at)
+				orders (
+					id,
+					customer_id,
+					status,
+					currency,
+					subtotal,
+					discount_total,
+					tax_region,
+					tax_inclusive,
+					tax_total,
+					shipping_method,
+					shipping_carrier,
+					shipping_amount,
+					total,
+					reporting_currency,
+					exchange_rate,
+					exchange_rate_at,
+					reporting_total,
+					version,
+					created_at,
+					updated_at
+				)
 			  values
-				($1, $2, $3, $4)`
+				($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)`
 
 	_, err := r.tx.ExecContext(
 		ctx,
 		query,
 		order.ID.Value,
+		nullString(order.CustomerID),
 		order.Status.String(),
+		order.Currency.String(),
+		order.Subtotal(),
+		order.DiscountTotal(),
+		nullString(order.TaxRegion),
+		order.TaxInclusive,
+		order.TaxTotal(),
+		nullString(order.ShippingMethod),
+		nullString(order.ShippingCarrier),
+		order.ShippingAmount,
+		order.Total(),
+		nullString(order.ReportingCurrency.String()),
+		order.ExchangeRate,
+		nullTime(order.ExchangeRateAt),
+		order.ReportingTotal(),
+		order.Version,
 		order.CreatedAt,
 		order.UpdatedAt.Time,
 	)
@@ -118,6 +568,13 @@ func (r *orderRepository) Insert(ctx context.Context, order *entities.Order) err
 		span.AddAttributes(ctx, o11y.Error, "error insert order", o11y.Attributes{Key: "error", Value: err})
 		return err
 	}
+
+	if err := r.insertStatusChanges(ctx, order); err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error insert status history", o11y.Attributes{Key: "error", Value: err})
+		return err
+	}
+
+	r.Track(order)
 	return nil
 }
 
@@ -129,14 +586,18 @@ func (r *orderRepository) InsertItems(ctx context.Context, items []*entities.Ord
 				order_items (
 					id,
 					order_id,
+					sku,
 					product_name,
 					quantity,
 					price,
+					discount,
+					tax_category,
+					tax,
 					created_at,
 					updated_at
 					)
 				values
-					($1, $2, $3, $4, $5, $6, $7)`
+					($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
 
 	for _, item := range items {
 		_, err := r.tx.ExecContext(
@@ -144,9 +605,13 @@ func (r *orderRepository) InsertItems(ctx context.Context, items []*entities.Ord
 			query,
 			item.ID.Value,
 			item.OrderID.Value,
+			nullString(item.SKU),
 			item.ProductName,
 			item.Quantity,
 			item.Price,
+			item.Discount,
+			nullString(item.TaxCategory),
+			item.Tax,
 			item.CreatedAt,
 			item.UpdatedAt.Time,
 		)
@@ -159,6 +624,305 @@ func (r *orderRepository) InsertItems(ctx context.Context, items []*entities.Ord
 	return nil
 }
 
+// ReplaceItems rewrites the lines of an amended order with their discounts
+// and taxes, and refreshes the stored totals and shipping.
+func (r *orderRepository) ReplaceItems(ctx context.Context, order *entities.Order) error {
+	ctx, span := r.o11y.Start(ctx, "order_repository.replace_items")
+	defer span.End()
+
+	for _, query := range []string{
+		`delete from order_item_taxes where order_id = $1`,
+		`delete from order_discounts where order_id = $1`,
+		`delete from order_items where order_id = $1`,
+	} {
+		if _, err := r.tx.ExecContext(ctx, query, order.ID.Value); err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error delete order items", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+	}
+
+	if err := r.InsertItems(ctx, order.Items); err != nil {
+		return err
+	}
+
+	if err := r.InsertDiscounts(ctx, order.Discounts); err != nil {
+		return err
+	}
+
+	if err := r.InsertTaxes(ctx, order.TaxLines()); err != nil {
+		return err
+	}
+
+	query := `update
+				orders
+			  set
+				subtotal = $1,
+				discount_total = $2,
+				tax_inclusive = $3,
+				tax_total = $4,
+				shipping_method = $5,
+				shipping_carrier = $6,
+				shipping_amount = $7,
+				total = $8,
+				reporting_total = $9
+			  where
+				id = $10`
+
+	_, err := r.tx.ExecContext(
+		ctx,
+		query,
+		order.Subtotal(),
+		order.DiscountTotal(),
+		order.TaxInclusive,
+		order.TaxTotal(),
+		nullString(order.ShippingMethod),
+		nullString(order.ShippingCarrier),
+		order.ShippingAmount,
+		order.Total(),
+		order.ReportingTotal(),
+		order.ID.Value,
+	)
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error update order totals", o11y.Attributes{Key: "error", Value: err})
+		return err
+	}
+	return nil
+}
+
+func (r *orderRepository) InsertDiscounts(ctx context.Context, discounts []*entities.OrderDiscount) error {
+	ctx, span := r.o11y.Start(ctx, "order_repository.insert_discounts")
+	defer span.End()
+
+	query := `insert into
+				order_discounts (
+					id,
+					order_id,
+					order_item_id,
+					coupon_id,
+					code,
+					amount,
+					created_at
+				)
+			  values
+				($1, $2, $3, $4, $5, $6, $7)`
+
+	for _, discount := range discounts {
+		_, err := r.tx.ExecContext(
+			ctx,
+			query,
+			discount.ID.Value,
+			discount.OrderID.Value,
+			discount.OrderItemID.Value,
+			discount.CouponID.Value,
+			discount.Code,
+			discount.Amount,
+			discount.CreatedAt,
+		)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error insert order discount", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *orderRepository) InsertAddresses(ctx context.Context, order *entities.Order) error {
+	ctx, span := r.o11y.Start(ctx, "order_repository.insert_addresses")
+	defer span.End()
+
+	query := `insert into
+				order_addresses (
+					order_id,
+					kind,
+					street,
+					number,
+					complement,
+					district,
+					city,
+					state,
+					postal_code,
+					country,
+					created_at
+				)
+			  values
+				($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+
+	addresses := []struct {
+		kind    vos.AddressKind
+		address *vos.Address
+	}{
+		{kind: vos.AddressShipping, address: order.ShippingAddress},
+		{kind: vos.AddressBilling, address: order.BillingAddress},
+	}
+
+	for _, entry := range addresses {
+		kind, address := entry.kind, entry.address
+		if address == nil {
+			continue
+		}
+
+		_, err := r.tx.ExecContext(
+			ctx,
+			query,
+			order.ID.Value,
+			kind.String(),
+			address.Street,
+			nullString(address.Number),
+			nullString(address.Complement),
+			nullString(address.District),
+			address.City,
+			nullString(address.State),
+			address.PostalCode,
+			address.Country,
+			order.CreatedAt,
+		)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error insert order address", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *orderRepository) InsertPayment(ctx context.Context, payment *entities.Payment) error {
+	ctx, span := r.o11y.Start(ctx, "order_repository.insert_payment")
+	defer span.End()
+
+	query := `insert into
+				payments (
+					id,
+					order_id,
+					amount,
+					currency,
+					method,
+					provider_reference,
+					status,
+					created_at,
+					updated_at
+				)
+			  values
+				($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	_, err := r.tx.ExecContext(
+		ctx,
+		query,
+		payment.ID.Value,
+		payment.OrderID.Value,
+		payment.Amount,
+		payment.Currency.String(),
+		payment.Method.String(),
+		nullString(payment.ProviderReference),
+		payment.Status.String(),
+		payment.CreatedAt,
+		payment.UpdatedAt.Time,
+	)
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error insert payment", o11y.Attributes{Key: "error", Value: err})
+		return err
+	}
+	return nil
+}
+
+func (r *orderRepository) UpdatePayment(ctx context.Context, payment *entities.Payment) error {
+	ctx, span := r.o11y.Start(ctx, "order_repository.update_payment")
+	defer span.End()
+
+	query := `update
+				payments
+			  set
+				status = $1,
+				refunded_amount = $2,
+				updated_at = $3
+			  where
+				id = $4`
+
+	_, err := r.tx.ExecContext(
+		ctx,
+		query,
+		payment.Status.String(),
+		payment.RefundedAmount,
+		payment.UpdatedAt.Time,
+		payment.ID.Value,
+	)
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error update payment", o11y.Attributes{Key: "error", Value: err})
+		return err
+	}
+	return nil
+}
+
+func (r *orderRepository) InsertRefunds(ctx context.Context, refunds []*entities.Refund) error {
+	ctx, span := r.o11y.Start(ctx, "order_repository.insert_refunds")
+	defer span.End()
+
+	query := `insert into
+				refunds (
+					id,
+					order_id,
+					payment_id,
+					return_id,
+					amount,
+					created_at
+				)
+			  values
+				($1, $2, $3, $4, $5, $6)`
+
+	for _, refund := range refunds {
+		_, err := r.tx.ExecContext(
+			ctx,
+			query,
+			refund.ID.Value,
+			refund.OrderID.Value,
+			refund.PaymentID.Value,
+			refund.ReturnID.Value,
+			refund.Amount,
+			refund.CreatedAt,
+		)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error insert refund", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *orderRepository) InsertTaxes(ctx context.Context, taxes []*entities.OrderItemTax) error {
+	ctx, span := r.o11y.Start(ctx, "order_repository.insert_taxes")
+	defer span.End()
+
+	query := `insert into
+				order_item_taxes (
+					id,
+					order_id,
+					order_item_id,
+					jurisdiction,
+					rate,
+					amount,
+					created_at
+				)
+			  values
+				($1, $2, $3, $4, $5, $6, $7)`
+
+	for _, tax := range taxes {
+		_, err := r.tx.ExecContext(
+			ctx,
+			query,
+			tax.ID.Value,
+			tax.OrderID.Value,
+			tax.OrderItemID.Value,
+			tax.Jurisdiction,
+			tax.Rate,
+			tax.Amount,
+			tax.CreatedAt,
+		)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error insert order item tax", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+	}
+	return nil
+}
+
 func (r *orderRepository) Update(ctx context.Context, order *entities.Order) error {
 	ctx, span := r.o11y.Start(ctx, "order_repository.update")
 	defer span.End()
@@ -167,20 +931,139 @@ func (r *orderRepository) Update(ctx context.Context, order *entities.Order) err
 				orders
 			  set
 				status = $1,
-				updated_at = $2
+				updated_at = $2,
+				version = version + 1
 			  where
-				id = $3`
+				id = $3
+				and version = $4`
 
-	_, err := r.tx.ExecContext(
+	result, err := r.tx.ExecContext(
 		ctx,
 		query,
 		order.Status.String(),
 		order.UpdatedAt.Time,
 		order.ID.Value,
+		order.Version,
 	)
 	if err != nil {
 		span.AddAttributes(ctx, o11y.Error, "error update order", o11y.Attributes{Key: "error", Value: err})
 		return err
 	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error update order", o11y.Attributes{Key: "error", Value: err})
+		return err
+	}
+
+	if affected == 0 {
+		span.AddAttributes(ctx, o11y.Error, "error update order", o11y.Attributes{Key: "error", Value: interfaces.ErrOrderConflict})
+		return interfaces.ErrOrderConflict
+	}
+
+	order.Version++
+	if err := r.insertStatusChanges(ctx, order); err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error insert status history", o11y.Attributes{Key: "error", Value: err})
+		return err
+	}
+
+	r.Track(order)
+	return nil
+}
+
+// insertStatusChanges appends the pending transitions of order to its status
+// history, attributed to the actor and request found in ctx.
+func (r *orderRepository) insertStatusChanges(ctx context.Context, order *entities.Order) error {
+	query := `insert into
+				order_status_history (
+					order_id,
+					from_status,
+					to_status,
+					actor,
+					reason,
+					request_id,
+					created_at
+				)
+			  values
+				($1, $2, $3, $4, $5, $6, $7)`
+
+	metadata := audit.FromContext(ctx)
+	for _, change := range order.StatusChanges() {
+		_, err := r.tx.ExecContext(
+			ctx,
+			query,
+			order.ID.Value,
+			nullString(change.From.String()),
+			change.To.String(),
+			metadata.Actor,
+			nullString(change.Reason),
+			nullString(metadata.RequestID),
+			change.CreatedAt,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	order.ClearStatusChanges()
 	return nil
 }
+
+func (r *orderRepository) FindStatusHistory(ctx context.Context, orderID sharedVos.UUID) ([]*entities.OrderStatusChange, error) {
+	ctx, span := r.o11y.Start(ctx, "order_repository.find_status_history")
+	defer span.End()
+
+	query := `select
+				id,
+				order_id,
+				from_status,
+				to_status,
+				actor,
+				reason,
+				request_id,
+				created_at
+			  from
+				order_status_history
+			  where
+				order_id = $1
+			  order by
+				created_at`
+
+	rows, err := r.tx.QueryContext(ctx, query, orderID.Value)
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error find status history", o11y.Attributes{Key: "error", Value: err})
+		return nil, err
+	}
+	defer rows.Close()
+
+	var history []*entities.OrderStatusChange
+	for rows.Next() {
+		var (
+			change    entities.OrderStatusChange
+			from      sql.NullString
+			reason    sql.NullString
+			requestID sql.NullString
+		)
+
+		err := rows.Scan(
+			&change.ID.Value,
+			&change.OrderID.Value,
+			&from,
+			&change.To,
+			&change.Actor,
+			&reason,
+			&requestID,
+			&change.CreatedAt,
+		)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error scan row", o11y.Attributes{Key: "error", Value: err})
+			return nil, err
+		}
+
+		change.From = vos.Status(from.String)
+		change.Reason = reason.String
+		change.RequestID = requestID.String
+		history = append(history, &change)
+	}
+	return history, rows.Err()
+}
@@ -0,0 +1,312 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	"github.com/jailtonjunior94/order/internal/order/domain/interfaces"
+	"github.com/jailtonjunior94/order/pkg/database/uow"
+	"github.com/jailtonjunior94/order/pkg/o11y"
+	sharedVos "github.com/jailtonjunior94/order/pkg/vos"
+)
+
+type orderReturnRepository struct {
+	uow.AggregateTracker
+	db   *sql.DB
+	tx   *sql.Tx
+	o11y o11y.Observability
+}
+
+func NewOrderReturnRepository(db *sql.DB, tx *sql.Tx, o11y o11y.Observability) interfaces.OrderReturnRepository {
+	return &orderReturnRepository{
+		db:   db,
+		tx:   tx,
+		o11y: o11y,
+	}
+}
+
+func (r *orderReturnRepository) Insert(ctx context.Context, orderReturn *entities.OrderReturn) error {
+	ctx, span := r.o11y.Start(ctx, "order_return_repository.insert")
+	defer span.End()
+
+	query := `insert into
+				order_returns (
+					id,
+					order_id,
+					status,
+					reason,
+					rejection_reason,
+					amount,
+					created_at,
+					updated_at
+				)
+			  values
+				($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	_, err := r.tx.ExecContext(
+		ctx,
+		query,
+		orderReturn.ID.Value,
+		orderReturn.OrderID.Value,
+		orderReturn.Status.String(),
+		nullString(orderReturn.Reason),
+		nullString(orderReturn.RejectionReason),
+		orderReturn.Amount(),
+		orderReturn.CreatedAt,
+		orderReturn.UpdatedAt.Time,
+	)
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error insert order return", o11y.Attributes{Key: "error", Value: err})
+		return err
+	}
+
+	lineQuery := `insert into
+					order_return_lines (
+						id,
+						return_id,
+						order_item_id,
+						quantity,
+						amount,
+						created_at
+					)
+				  values
+					($1, $2, $3, $4, $5, $6)`
+
+	for _, line := range orderReturn.Lines {
+		_, err := r.tx.ExecContext(
+			ctx,
+			lineQuery,
+			line.ID.Value,
+			line.ReturnID.Value,
+			line.OrderItemID.Value,
+			line.Quantity,
+			line.Amount,
+			line.CreatedAt,
+		)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error insert order return line", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+	}
+
+	r.Track(orderReturn)
+	return nil
+}
+
+func (r *orderReturnRepository) Update(ctx context.Context, orderReturn *entities.OrderReturn) error {
+	ctx, span := r.o11y.Start(ctx, "order_return_repository.update")
+	defer span.End()
+
+	query := `update
+				order_returns
+			  set
+				status = $1,
+				rejection_reason = $2,
+				updated_at = $3
+			  where
+				id = $4`
+
+	_, err := r.tx.ExecContext(
+		ctx,
+		query,
+		orderReturn.Status.String(),
+		nullString(orderReturn.RejectionReason),
+		orderReturn.UpdatedAt.Time,
+		orderReturn.ID.Value,
+	)
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error update order return", o11y.Attributes{Key: "error", Value: err})
+		return err
+	}
+
+	r.Track(orderReturn)
+	return nil
+}
+
+func (r *orderReturnRepository) Find(ctx context.Context, returnID sharedVos.UUID) (*entities.OrderReturn, error) {
+	ctx, span := r.o11y.Start(ctx, "order_return_repository.find")
+	defer span.End()
+
+	query := `select
+				id,
+				order_id,
+				status,
+				reason,
+				rejection_reason,
+				created_at,
+				updated_at
+			  from
+				order_returns
+			  where
+				id = $1
+			  for update`
+
+	orderReturn, err := scanOrderReturn(r.tx.QueryRowContext(ctx, query, returnID.String()))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		span.AddAttributes(ctx, o11y.Error, "error find order return", o11y.Attributes{Key: "error", Value: err})
+		return nil, err
+	}
+
+	if err := r.loadDetails(ctx, orderReturn); err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error load order return details", o11y.Attributes{Key: "error", Value: err})
+		return nil, err
+	}
+	return orderReturn, nil
+}
+
+func (r *orderReturnRepository) FindByOrder(ctx context.Context, orderID sharedVos.UUID) ([]*entities.OrderReturn, error) {
+	ctx, span := r.o11y.Start(ctx, "order_return_repository.find_by_order")
+	defer span.End()
+
+	query := `select
+				id,
+				order_id,
+				status,
+				reason,
+				rejection_reason,
+				created_at,
+				updated_at
+			  from
+				order_returns
+			  where
+				order_id = $1
+			  order by
+				created_at`
+
+	rows, err := r.tx.QueryContext(ctx, query, orderID.String())
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error find order returns", o11y.Attributes{Key: "error", Value: err})
+		return nil, err
+	}
+	defer rows.Close()
+
+	var returns []*entities.OrderReturn
+	for rows.Next() {
+		orderReturn, err := scanOrderReturn(rows)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error scan row", o11y.Attributes{Key: "error", Value: err})
+			return nil, err
+		}
+		returns = append(returns, orderReturn)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, orderReturn := range returns {
+		if err := r.loadDetails(ctx, orderReturn); err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error load order return details", o11y.Attributes{Key: "error", Value: err})
+			return nil, err
+		}
+	}
+	return returns, nil
+}
+
+func scanOrderReturn(row scanner) (*entities.OrderReturn, error) {
+	var (
+		orderReturn     entities.OrderReturn
+		reason          sql.NullString
+		rejectionReason sql.NullString
+	)
+
+	err := row.Scan(
+		&orderReturn.ID.Value,
+		&orderReturn.OrderID.Value,
+		&orderReturn.Status,
+		&reason,
+		&rejectionReason,
+		&orderReturn.CreatedAt,
+		&orderReturn.UpdatedAt.Time,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	orderReturn.Reason = reason.String
+	orderReturn.RejectionReason = rejectionReason.String
+	return &orderReturn, nil
+}
+
+func (r *orderReturnRepository) loadDetails(ctx context.Context, orderReturn *entities.OrderReturn) error {
+	lineQuery := `select
+					id,
+					return_id,
+					order_item_id,
+					quantity,
+					amount,
+					created_at
+				  from
+					order_return_lines
+				  where
+					return_id = $1
+				  order by
+					created_at`
+
+	rows, err := r.tx.QueryContext(ctx, lineQuery, orderReturn.ID.String())
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var line entities.ReturnLine
+		err := rows.Scan(
+			&line.ID.Value,
+			&line.ReturnID.Value,
+			&line.OrderItemID.Value,
+			&line.Quantity,
+			&line.Amount,
+			&line.CreatedAt,
+		)
+		if err != nil {
+			return err
+		}
+		orderReturn.Lines = append(orderReturn.Lines, &line)
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	refundQuery := `select
+					  id,
+					  order_id,
+					  payment_id,
+					  return_id,
+					  amount,
+					  created_at
+					from
+					  refunds
+					where
+					  return_id = $1
+					order by
+					  created_at`
+
+	refundRows, err := r.tx.QueryContext(ctx, refundQuery, orderReturn.ID.String())
+	if err != nil {
+		return err
+	}
+	defer refundRows.Close()
+
+	for refundRows.Next() {
+		var refund entities.Refund
+		err := refundRows.Scan(
+			&refund.ID.Value,
+			&refund.OrderID.Value,
+			&refund.PaymentID.Value,
+			&refund.ReturnID.Value,
+			&refund.Amount,
+			&refund.CreatedAt,
+		)
+		if err != nil {
+			return err
+		}
+		orderReturn.Refunds = append(orderReturn.Refunds, &refund)
+	}
+	return refundRows.Err()
+}
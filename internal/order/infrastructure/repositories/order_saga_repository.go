@@ -0,0 +1,246 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	"github.com/jailtonjunior94/order/internal/order/domain/interfaces"
+	"github.com/jailtonjunior94/order/internal/order/domain/vos"
+	"github.com/jailtonjunior94/order/pkg/database/uow"
+	"github.com/jailtonjunior94/order/pkg/o11y"
+	sharedVos "github.com/jailtonjunior94/order/pkg/vos"
+)
+
+type (
+	orderSagaRepository struct {
+		uow.AggregateTracker
+		db   *sql.DB
+		tx   *sql.Tx
+		o11y o11y.Observability
+	}
+
+	scanner interface {
+		Scan(dest ...any) error
+	}
+)
+
+func NewOrderSagaRepository(db *sql.DB, tx *sql.Tx, o11y o11y.Observability) interfaces.OrderSagaRepository {
+	return &orderSagaRepository{
+		db:   db,
+		tx:   tx,
+		o11y: o11y,
+	}
+}
+
+func (r *orderSagaRepository) Insert(ctx context.Context, saga *entities.OrderSaga) error {
+	ctx, span := r.o11y.Start(ctx, "order_saga_repository.insert")
+	defer span.End()
+
+	query := `insert into
+				order_sagas (
+					id,
+					order_id,
+					status,
+					reservation_id,
+					payment_id,
+					failure_reason,
+					attempts,
+					step,
+					deadline_at,
+					created_at,
+					updated_at
+				)
+			  values
+				($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+
+	_, err := r.tx.ExecContext(
+		ctx,
+		query,
+		saga.ID.Value,
+		saga.OrderID.Value,
+		saga.Status.String(),
+		nullString(saga.ReservationID),
+		nullString(saga.PaymentID),
+		nullString(saga.FailureReason),
+		saga.Attempts,
+		saga.Step,
+		nullTime(saga.DeadlineAt),
+		saga.CreatedAt,
+		saga.UpdatedAt.Time,
+	)
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error insert order saga", o11y.Attributes{Key: "error", Value: err})
+		return err
+	}
+
+	r.Track(saga)
+	return nil
+}
+
+func (r *orderSagaRepository) Update(ctx context.Context, saga *entities.OrderSaga) error {
+	ctx, span := r.o11y.Start(ctx, "order_saga_repository.update")
+	defer span.End()
+
+	query := `update
+				order_sagas
+			  set
+				status = $1,
+				reservation_id = $2,
+				payment_id = $3,
+				failure_reason = $4,
+				attempts = $5,
+				step = $6,
+				deadline_at = $7,
+				updated_at = $8
+			  where
+				id = $9`
+
+	_, err := r.tx.ExecContext(
+		ctx,
+		query,
+		saga.Status.String(),
+		nullString(saga.ReservationID),
+		nullString(saga.PaymentID),
+		nullString(saga.FailureReason),
+		saga.Attempts,
+		saga.Step,
+		nullTime(saga.DeadlineAt),
+		saga.UpdatedAt.Time,
+		saga.ID.Value,
+	)
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error update order saga", o11y.Attributes{Key: "error", Value: err})
+		return err
+	}
+
+	r.Track(saga)
+	return nil
+}
+
+func (r *orderSagaRepository) FindByOrder(ctx context.Context, orderID sharedVos.UUID) (*entities.OrderSaga, error) {
+	ctx, span := r.o11y.Start(ctx, "order_saga_repository.find_by_order")
+	defer span.End()
+
+	query := `select
+				id,
+				order_id,
+				status,
+				reservation_id,
+				payment_id,
+				failure_reason,
+				attempts,
+				step,
+				deadline_at,
+				created_at,
+				updated_at
+			  from
+				order_sagas
+			  where
+				order_id = $1
+			  for update`
+
+	saga, err := scanOrderSaga(r.tx.QueryRowContext(ctx, query, orderID.String()))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		span.AddAttributes(ctx, o11y.Error, "error find order saga", o11y.Attributes{Key: "order_id", Value: orderID.String()})
+		return nil, err
+	}
+	return saga, nil
+}
+
+func (r *orderSagaRepository) FindTimedOut(ctx context.Context, before time.Time, limit int) ([]*entities.OrderSaga, error) {
+	ctx, span := r.o11y.Start(ctx, "order_saga_repository.find_timed_out")
+	defer span.End()
+
+	query := `select
+				id,
+				order_id,
+				status,
+				reservation_id,
+				payment_id,
+				failure_reason,
+				attempts,
+				step,
+				deadline_at,
+				created_at,
+				updated_at
+			  from
+				order_sagas
+			  where
+				deadline_at is not null
+				and deadline_at < $1
+				and status in ($2, $3, $4)
+			  order by
+				deadline_at
+			  limit $5
+			  for update`
+
+	rows, err := r.tx.QueryContext(
+		ctx,
+		query,
+		before,
+		vos.SagaReservingStock.String(),
+		vos.SagaAuthorizingPayment.String(),
+		vos.SagaCompensating.String(),
+		limit,
+	)
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error find timed out sagas", o11y.Attributes{Key: "error", Value: err})
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sagas []*entities.OrderSaga
+	for rows.Next() {
+		saga, err := scanOrderSaga(rows)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error scan row", o11y.Attributes{Key: "error", Value: err})
+			return nil, err
+		}
+		sagas = append(sagas, saga)
+	}
+	return sagas, rows.Err()
+}
+
+func scanOrderSaga(row scanner) (*entities.OrderSaga, error) {
+	var (
+		saga                                    entities.OrderSaga
+		reservationID, paymentID, failureReason sql.NullString
+		deadlineAt                              sql.NullTime
+	)
+
+	err := row.Scan(
+		&saga.ID.Value,
+		&saga.OrderID.Value,
+		&saga.Status,
+		&reservationID,
+		&paymentID,
+		&failureReason,
+		&saga.Attempts,
+		&saga.Step,
+		&deadlineAt,
+		&saga.CreatedAt,
+		&saga.UpdatedAt.Time,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	saga.ReservationID = reservationID.String
+	saga.PaymentID = paymentID.String
+	saga.FailureReason = failureReason.String
+	saga.DeadlineAt = deadlineAt.Time
+	return &saga, nil
+}
+
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
+
+func nullTime(value time.Time) sql.NullTime {
+	return sql.NullTime{Time: value, Valid: !value.IsZero()}
+}
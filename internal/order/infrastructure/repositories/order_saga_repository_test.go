@@ -0,0 +1,49 @@
+package repositories
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	sharedVos "github.com/jailtonjunior94/order/pkg/vos"
+)
+
+func TestOrderSagaRepositoryWritesStep(t *testing.T) {
+	db, tx := newRecordingTx(t)
+	repository := NewOrderSagaRepository(db, tx, fakeObservability{})
+
+	orderID, _ := sharedVos.NewUUID()
+	saga := entities.NewOrderSaga(orderID)
+	saga.ID, _ = sharedVos.NewUUID()
+	saga.Step = 3
+
+	if err := repository.Insert(context.Background(), saga); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := repository.Update(context.Background(), saga); err != nil {
+		t.Fatal(err)
+	}
+
+	statements := recorder.recorded(t.Name())
+	if len(statements) != 2 {
+		t.Fatalf("statements = %d, want 2", len(statements))
+	}
+
+	for _, statement := range statements {
+		query := strings.Join(strings.Fields(statement.query), " ")
+		if distinct := uniquePlaceholders(query); distinct != len(statement.args) {
+			t.Errorf("%d placeholders for %d arguments:\n%s", distinct, len(statement.args), query)
+		}
+
+		var written bool
+		for _, arg := range statement.args {
+			written = written || arg.Value == int64(3)
+		}
+
+		if !strings.Contains(query, "step") || !written {
+			t.Errorf("expected the step to be written:\n%s", query)
+		}
+	}
+}
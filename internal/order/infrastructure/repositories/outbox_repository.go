@@ -3,10 +3,14 @@ package repositories
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/jailtonjunior94/order/internal/order/domain/entities"
 	"github.com/jailtonjunior94/order/internal/order/domain/interfaces"
 	"github.com/jailtonjunior94/order/pkg/o11y"
+	"github.com/jailtonjunior94/order/pkg/vos"
 )
 
 type outboxRepository struct {
@@ -27,14 +31,15 @@ func (r *outboxRepository) Insert(ctx context.Context, outbox *entities.Outbox)
 	ctx, span := r.o11y.Start(ctx, "outbox_repository.insert")
 	defer span.End()
 	query := `insert into
-				outbox (id, event_name, was_published, published_at, payload, created_at)
+				outbox (id, aggregate_id, event_name, was_published, published_at, payload, created_at)
 			  values
-				($1, $2, $3, $4, $5, $6)`
+				($1, $2, $3, $4, $5, $6, $7)`
 
 	_, err := r.tx.ExecContext(
 		ctx,
 		query,
 		outbox.ID.Value,
+		outbox.AggregateID.SafeUUID(),
 		outbox.EventName,
 		outbox.WasPublished,
 		outbox.PublishedAt.Time,
@@ -54,15 +59,20 @@ func (r *outboxRepository) FindAll(ctx context.Context, wasPublished bool) ([]*e
 
 	query := `select
 				id,
+				aggregate_id,
 				event_name,
 				was_published,
 				published_at,
+				skipped_at,
 				payload,
 				created_at
 			  from
 				outbox o
 			  where
-				o.was_published = $1`
+				o.was_published = $1
+				and o.skipped_at is null
+			  order by
+				o.created_at`
 
 	rows, err := r.tx.QueryContext(ctx, query, wasPublished)
 	if err != nil {
@@ -71,24 +81,148 @@ func (r *outboxRepository) FindAll(ctx context.Context, wasPublished bool) ([]*e
 	}
 	defer rows.Close()
 
+	outboxes, err := r.scan(rows)
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error scan row", o11y.Attributes{Key: "error", Value: err})
+		return nil, err
+	}
+	return outboxes, nil
+}
+
+func (r *outboxRepository) Find(ctx context.Context, id vos.UUID) (*entities.Outbox, error) {
+	ctx, span := r.o11y.Start(ctx, "outbox_repository.find")
+	defer span.End()
+
+	query := `select
+				id,
+				aggregate_id,
+				event_name,
+				was_published,
+				published_at,
+				skipped_at,
+				payload,
+				created_at
+			  from
+				outbox o
+			  where
+				o.id = $1`
+
+	var outbox entities.Outbox
+	err := r.tx.QueryRowContext(ctx, query, id.String()).Scan(
+		&outbox.ID.Value,
+		&outbox.AggregateID.Value,
+		&outbox.EventName,
+		&outbox.WasPublished,
+		&outbox.PublishedAt.Time,
+		&outbox.SkippedAt.Time,
+		&outbox.Payload,
+		&outbox.CreatedAt,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		span.AddAttributes(ctx, o11y.Error, "error find outbox", o11y.Attributes{Key: "outbox_id", Value: id.String()})
+		return nil, err
+	}
+	return &outbox, nil
+}
+
+func (r *outboxRepository) List(ctx context.Context, filter *interfaces.OutboxFilter) ([]*entities.Outbox, error) {
+	ctx, span := r.o11y.Start(ctx, "outbox_repository.list")
+	defer span.End()
+
+	var (
+		conditions []string
+		args       []any
+	)
+
+	if filter.WasPublished != nil {
+		args = append(args, *filter.WasPublished)
+		conditions = append(conditions, fmt.Sprintf("o.was_published = $%d", len(args)))
+	}
+
+	if filter.Skipped != nil {
+		if *filter.Skipped {
+			conditions = append(conditions, "o.skipped_at is not null")
+		} else {
+			conditions = append(conditions, "o.skipped_at is null")
+		}
+	}
+
+	if filter.EventName != "" {
+		args = append(args, filter.EventName)
+		conditions = append(conditions, fmt.Sprintf("o.event_name = $%d", len(args)))
+	}
+
+	if !filter.Since.IsZero() {
+		args = append(args, filter.Since)
+		conditions = append(conditions, fmt.Sprintf("o.created_at >= $%d", len(args)))
+	}
+
+	if filter.After != nil {
+		args = append(args, filter.After.CreatedAt, filter.After.ID.Value)
+		conditions = append(conditions, fmt.Sprintf("(o.created_at, o.id) > ($%d, $%d)", len(args)-1, len(args)))
+	}
+
+	query := `select
+				id,
+				aggregate_id,
+				event_name,
+				was_published,
+				published_at,
+				skipped_at,
+				payload,
+				created_at
+			  from
+				outbox o`
+
+	if len(conditions) > 0 {
+		query += " where " + strings.Join(conditions, " and ")
+	}
+	query += " order by o.created_at, o.id"
+
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" limit $%d", len(args))
+	}
+
+	rows, err := r.tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error list outbox", o11y.Attributes{Key: "error", Value: err})
+		return nil, err
+	}
+	defer rows.Close()
+
+	outboxes, err := r.scan(rows)
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error scan row", o11y.Attributes{Key: "error", Value: err})
+		return nil, err
+	}
+	return outboxes, nil
+}
+
+func (r *outboxRepository) scan(rows *sql.Rows) ([]*entities.Outbox, error) {
 	var outboxes []*entities.Outbox
 	for rows.Next() {
 		var outbox entities.Outbox
 		err := rows.Scan(
 			&outbox.ID.Value,
+			&outbox.AggregateID.Value,
 			&outbox.EventName,
 			&outbox.WasPublished,
 			&outbox.PublishedAt.Time,
+			&outbox.SkippedAt.Time,
 			&outbox.Payload,
 			&outbox.CreatedAt,
 		)
 		if err != nil {
-			span.AddAttributes(ctx, o11y.Error, "error scan row", o11y.Attributes{Key: "error", Value: err})
 			return nil, err
 		}
 		outboxes = append(outboxes, &outbox)
 	}
-	return outboxes, nil
+	return outboxes, rows.Err()
 }
 
 func (r *outboxRepository) Update(ctx context.Context, outbox *entities.Outbox) error {
@@ -99,15 +233,17 @@ func (r *outboxRepository) Update(ctx context.Context, outbox *entities.Outbox)
 				outbox
 			  set
 				was_published = $1,
-				published_at = $2
+				published_at = $2,
+				skipped_at = $3
 			  where
-				id = $3`
+				id = $4`
 
 	_, err := r.tx.ExecContext(
 		ctx,
 		query,
 		outbox.WasPublished,
 		outbox.PublishedAt.Time,
+		outbox.SkippedAt.Time,
 		outbox.ID.Value,
 	)
 	if err != nil {
@@ -116,3 +252,89 @@ func (r *outboxRepository) Update(ctx context.Context, outbox *entities.Outbox)
 	}
 	return nil
 }
+
+func (r *outboxRepository) DeleteSettled(ctx context.Context, before time.Time, limit int) (int64, error) {
+	ctx, span := r.o11y.Start(ctx, "outbox_repository.delete_settled")
+	defer span.End()
+
+	query := `delete from
+				outbox
+			  where
+				id in (
+					select
+						id
+					from
+						outbox o
+					where
+						(o.was_published = true and o.published_at < $1)
+						or o.skipped_at < $1
+					order by
+						coalesce(o.published_at, o.skipped_at),
+						o.id
+					limit $2
+				)`
+
+	result, err := r.tx.ExecContext(ctx, query, before, limit)
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error delete settled outbox", o11y.Attributes{Key: "error", Value: err})
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (r *outboxRepository) ArchiveSettled(ctx context.Context, before time.Time, limit int) (int64, error) {
+	ctx, span := r.o11y.Start(ctx, "outbox_repository.archive_settled")
+	defer span.End()
+
+	query := `insert into
+				outbox_archive (id, aggregate_id, event_name, published_at, skipped_at, payload, created_at)
+			  select
+				id,
+				aggregate_id,
+				event_name,
+				published_at,
+				skipped_at,
+				payload,
+				created_at
+			  from
+				outbox o
+			  where
+				(o.was_published = true and o.published_at < $1)
+				or o.skipped_at < $1
+			  order by
+				coalesce(o.published_at, o.skipped_at),
+				o.id
+			  limit $2
+			  on conflict (id) do nothing`
+
+	if _, err := r.tx.ExecContext(ctx, query, before, limit); err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error archive settled outbox", o11y.Attributes{Key: "error", Value: err})
+		return 0, err
+	}
+	return r.DeleteSettled(ctx, before, limit)
+}
+
+func (r *outboxRepository) PendingStats(ctx context.Context) (int64, *time.Time, error) {
+	ctx, span := r.o11y.Start(ctx, "outbox_repository.pending_stats")
+	defer span.End()
+
+	query := `select
+				count(*),
+				min(created_at)
+			  from
+				outbox o
+			  where
+				o.was_published = false
+				and o.skipped_at is null`
+
+	var (
+		pending int64
+		oldest  *time.Time
+	)
+
+	if err := r.tx.QueryRowContext(ctx, query).Scan(&pending, &oldest); err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error pending stats outbox", o11y.Attributes{Key: "error", Value: err})
+		return 0, nil, err
+	}
+	return pending, oldest, nil
+}
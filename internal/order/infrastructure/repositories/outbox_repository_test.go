@@ -0,0 +1,194 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jailtonjunior94/order/pkg/o11y"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+type (
+	// recordingDriver accepts every statement and records it, so the SQL
+	// sent by a repository can be checked without a database.
+	recordingDriver struct {
+		mu         sync.Mutex
+		statements map[string][]recordedStatement
+	}
+
+	recordedStatement struct {
+		query string
+		args  []driver.NamedValue
+	}
+
+	recordingConn struct {
+		driver *recordingDriver
+		name   string
+	}
+
+	recordingRows struct{}
+
+	fakeObservability struct {
+		o11y.Observability
+	}
+
+	fakeSpan struct {
+		trace.Span
+	}
+)
+
+var (
+	recorder           = &recordingDriver{statements: make(map[string][]recordedStatement)}
+	placeholderPattern = regexp.MustCompile(`\$\d+`)
+)
+
+func init() {
+	sql.Register("recording", recorder)
+}
+
+func (d *recordingDriver) Open(name string) (driver.Conn, error) {
+	return &recordingConn{driver: d, name: name}, nil
+}
+
+func (d *recordingDriver) recorded(name string) []recordedStatement {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.statements[name]
+}
+
+func (c *recordingConn) record(query string, args []driver.NamedValue) {
+	c.driver.mu.Lock()
+	defer c.driver.mu.Unlock()
+	c.driver.statements[c.name] = append(c.driver.statements[c.name], recordedStatement{query: query, args: args})
+}
+
+func (c *recordingConn) Prepare(string) (driver.Stmt, error) {
+	return nil, driver.ErrSkip
+}
+
+func (c *recordingConn) Close() error {
+	return nil
+}
+
+func (c *recordingConn) Begin() (driver.Tx, error) {
+	return c, nil
+}
+
+func (c *recordingConn) Commit() error {
+	return nil
+}
+
+func (c *recordingConn) Rollback() error {
+	return nil
+}
+
+func (c *recordingConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.record(query, args)
+	return driver.RowsAffected(1), nil
+}
+
+func (c *recordingConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.record(query, args)
+	return recordingRows{}, nil
+}
+
+func (recordingRows) Columns() []string {
+	return nil
+}
+
+func (recordingRows) Close() error {
+	return nil
+}
+
+func (recordingRows) Next([]driver.Value) error {
+	return io.EOF
+}
+
+func (fakeObservability) Start(ctx context.Context, _ string, _ ...trace.SpanStartOption) (context.Context, o11y.Span) {
+	return ctx, fakeSpan{Span: trace.SpanFromContext(ctx)}
+}
+
+func (fakeSpan) AddStatus(context.Context, o11y.Code, string) {}
+
+func (fakeSpan) AddAttributes(context.Context, o11y.Code, string, ...o11y.Attributes) {}
+
+func newRecordingTx(t *testing.T) (*sql.DB, *sql.Tx) {
+	t.Helper()
+
+	db, err := sql.Open("recording", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db, tx
+}
+
+func TestOutboxRepositoryRetention(t *testing.T) {
+	before := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		archive    bool
+		statements int
+	}{
+		{name: "delete", statements: 1},
+		{name: "archive", archive: true, statements: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, tx := newRecordingTx(t)
+			repository := NewOutboxRepository(db, tx, fakeObservability{})
+
+			purge := repository.DeleteSettled
+			if tt.archive {
+				purge = repository.ArchiveSettled
+			}
+
+			if _, err := purge(context.Background(), before, 100); err != nil {
+				t.Fatalf("purge error = %v", err)
+			}
+
+			statements := recorder.recorded(t.Name())
+			if len(statements) != tt.statements {
+				t.Fatalf("statements = %d, want %d", len(statements), tt.statements)
+			}
+
+			for _, statement := range statements {
+				query := strings.Join(strings.Fields(statement.query), " ")
+				if !strings.Contains(query, "(o.was_published = true and o.published_at < $1) or o.skipped_at < $1") {
+					t.Errorf("expected published and skipped rows to be settled:\n%s", query)
+				}
+
+				if distinct := uniquePlaceholders(query); distinct != len(statement.args) {
+					t.Errorf("%d placeholders for %d arguments", distinct, len(statement.args))
+				}
+
+				if statement.args[0].Value != before || statement.args[1].Value != int64(100) {
+					t.Errorf("arguments = %v, want the cutoff and the limit", statement.args)
+				}
+			}
+		})
+	}
+}
+
+func uniquePlaceholders(query string) int {
+	seen := make(map[string]bool)
+	for _, placeholder := range placeholderPattern.FindAllString(query, -1) {
+		seen[placeholder] = true
+	}
+	return len(seen)
+}
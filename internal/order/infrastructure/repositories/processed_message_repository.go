@@ -0,0 +1,50 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/interfaces"
+	"github.com/jailtonjunior94/order/pkg/o11y"
+)
+
+type processedMessageRepository struct {
+	db   *sql.DB
+	tx   *sql.Tx
+	o11y o11y.Observability
+}
+
+func NewProcessedMessageRepository(db *sql.DB, tx *sql.Tx, o11y o11y.Observability) interfaces.ProcessedMessageRepository {
+	return &processedMessageRepository{
+		db:   db,
+		tx:   tx,
+		o11y: o11y,
+	}
+}
+
+// Register records messageID for consumer and reports false when it was
+// already processed.
+func (r *processedMessageRepository) Register(ctx context.Context, consumer, messageID string) (bool, error) {
+	ctx, span := r.o11y.Start(ctx, "processed_message_repository.register")
+	defer span.End()
+
+	query := `insert into
+				processed_messages (consumer, message_id, processed_at)
+			  values
+				($1, $2, $3)
+			  on conflict (consumer, message_id) do nothing`
+
+	result, err := r.tx.ExecContext(ctx, query, consumer, messageID, time.Now().UTC())
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error register processed message", o11y.Attributes{Key: "error", Value: err})
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error register processed message", o11y.Attributes{Key: "error", Value: err})
+		return false, err
+	}
+	return affected > 0, nil
+}
@@ -0,0 +1,76 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/interfaces"
+	"github.com/jailtonjunior94/order/pkg/database/lease"
+	"github.com/jailtonjunior94/order/pkg/o11y"
+)
+
+type relayCheckpointRepository struct {
+	db   *sql.DB
+	o11y o11y.Observability
+}
+
+func NewRelayCheckpointRepository(db *sql.DB, o11y o11y.Observability) interfaces.RelayCheckpointRepository {
+	return &relayCheckpointRepository{
+		db:   db,
+		o11y: o11y,
+	}
+}
+
+func (r *relayCheckpointRepository) Find(ctx context.Context, name string) (string, error) {
+	ctx, span := r.o11y.Start(ctx, "relay_checkpoint_repository.find")
+	defer span.End()
+
+	query := `select
+				position
+			  from
+				relay_checkpoints
+			  where
+				name = $1`
+
+	var position string
+	err := r.db.QueryRowContext(ctx, query, name).Scan(&position)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil
+		}
+		span.AddAttributes(ctx, o11y.Error, "error find relay checkpoint", o11y.Attributes{Key: "error", Value: err})
+		return "", err
+	}
+	return position, nil
+}
+
+func (r *relayCheckpointRepository) Save(ctx context.Context, name, position string) error {
+	ctx, span := r.o11y.Start(ctx, "relay_checkpoint_repository.save")
+	defer span.End()
+
+	query := `insert into
+				relay_checkpoints (name, position, updated_at)
+			  values
+				($1, $2, $3)
+			  on conflict (name) do update set
+				position = excluded.position,
+				updated_at = excluded.updated_at`
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := lease.Fence(ctx, tx); err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error save relay checkpoint", o11y.Attributes{Key: "error", Value: err})
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, query, name, position, time.Now().UTC()); err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error save relay checkpoint", o11y.Attributes{Key: "error", Value: err})
+		return err
+	}
+	return tx.Commit()
+}
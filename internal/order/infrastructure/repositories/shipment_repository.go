@@ -0,0 +1,305 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	"github.com/jailtonjunior94/order/internal/order/domain/interfaces"
+	"github.com/jailtonjunior94/order/pkg/database/uow"
+	"github.com/jailtonjunior94/order/pkg/o11y"
+	sharedVos "github.com/jailtonjunior94/order/pkg/vos"
+)
+
+type shipmentRepository struct {
+	uow.AggregateTracker
+	db   *sql.DB
+	tx   *sql.Tx
+	o11y o11y.Observability
+}
+
+func NewShipmentRepository(db *sql.DB, tx *sql.Tx, o11y o11y.Observability) interfaces.ShipmentRepository {
+	return &shipmentRepository{
+		db:   db,
+		tx:   tx,
+		o11y: o11y,
+	}
+}
+
+func (r *shipmentRepository) Insert(ctx context.Context, shipment *entities.Shipment) error {
+	ctx, span := r.o11y.Start(ctx, "shipment_repository.insert")
+	defer span.End()
+
+	query := `insert into
+				shipments (
+					id,
+					order_id,
+					carrier,
+					tracking_number,
+					status,
+					status_description,
+					delivered_at,
+					created_at,
+					updated_at
+				)
+			  values
+				($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	_, err := r.tx.ExecContext(
+		ctx,
+		query,
+		shipment.ID.Value,
+		shipment.OrderID.Value,
+		shipment.Carrier,
+		shipment.TrackingNumber,
+		shipment.Status.String(),
+		nullString(shipment.StatusDescription),
+		shipment.DeliveredAt.Time,
+		shipment.CreatedAt,
+		shipment.UpdatedAt.Time,
+	)
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error insert shipment", o11y.Attributes{Key: "error", Value: err})
+		return err
+	}
+
+	lineQuery := `insert into
+					shipment_lines (
+						id,
+						shipment_id,
+						order_item_id,
+						quantity,
+						created_at
+					)
+				  values
+					($1, $2, $3, $4, $5)`
+
+	for _, line := range shipment.Lines {
+		_, err := r.tx.ExecContext(
+			ctx,
+			lineQuery,
+			line.ID.Value,
+			line.ShipmentID.Value,
+			line.OrderItemID.Value,
+			line.Quantity,
+			line.CreatedAt,
+		)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error insert shipment line", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+	}
+
+	r.Track(shipment)
+	return nil
+}
+
+func (r *shipmentRepository) Update(ctx context.Context, shipment *entities.Shipment) error {
+	ctx, span := r.o11y.Start(ctx, "shipment_repository.update")
+	defer span.End()
+
+	query := `update
+				shipments
+			  set
+				status = $1,
+				status_description = $2,
+				delivered_at = $3,
+				updated_at = $4
+			  where
+				id = $5`
+
+	_, err := r.tx.ExecContext(
+		ctx,
+		query,
+		shipment.Status.String(),
+		nullString(shipment.StatusDescription),
+		shipment.DeliveredAt.Time,
+		shipment.UpdatedAt.Time,
+		shipment.ID.Value,
+	)
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error update shipment", o11y.Attributes{Key: "error", Value: err})
+		return err
+	}
+
+	r.Track(shipment)
+	return nil
+}
+
+func (r *shipmentRepository) Find(ctx context.Context, shipmentID sharedVos.UUID) (*entities.Shipment, error) {
+	ctx, span := r.o11y.Start(ctx, "shipment_repository.find")
+	defer span.End()
+
+	query := `select
+				id,
+				order_id,
+				carrier,
+				tracking_number,
+				status,
+				status_description,
+				delivered_at,
+				created_at,
+				updated_at
+			  from
+				shipments
+			  where
+				id = $1`
+
+	return r.findOne(ctx, span, query, shipmentID.String())
+}
+
+// FindByTracking locks the shipment so concurrent carrier updates for the same
+// parcel apply one after the other.
+func (r *shipmentRepository) FindByTracking(ctx context.Context, carrier, trackingNumber string) (*entities.Shipment, error) {
+	ctx, span := r.o11y.Start(ctx, "shipment_repository.find_by_tracking")
+	defer span.End()
+
+	query := `select
+				id,
+				order_id,
+				carrier,
+				tracking_number,
+				status,
+				status_description,
+				delivered_at,
+				created_at,
+				updated_at
+			  from
+				shipments
+			  where
+				carrier = $1
+				and tracking_number = $2
+			  for update`
+
+	return r.findOne(ctx, span, query, carrier, trackingNumber)
+}
+
+func (r *shipmentRepository) findOne(ctx context.Context, span o11y.Span, query string, args ...any) (*entities.Shipment, error) {
+	shipment, err := scanShipment(r.tx.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		span.AddAttributes(ctx, o11y.Error, "error find shipment", o11y.Attributes{Key: "error", Value: err})
+		return nil, err
+	}
+
+	if err := r.loadLines(ctx, shipment); err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error load shipment lines", o11y.Attributes{Key: "error", Value: err})
+		return nil, err
+	}
+	return shipment, nil
+}
+
+func (r *shipmentRepository) FindByOrder(ctx context.Context, orderID sharedVos.UUID) ([]*entities.Shipment, error) {
+	ctx, span := r.o11y.Start(ctx, "shipment_repository.find_by_order")
+	defer span.End()
+
+	query := `select
+				id,
+				order_id,
+				carrier,
+				tracking_number,
+				status,
+				status_description,
+				delivered_at,
+				created_at,
+				updated_at
+			  from
+				shipments
+			  where
+				order_id = $1
+			  order by
+				created_at`
+
+	rows, err := r.tx.QueryContext(ctx, query, orderID.String())
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error find shipments", o11y.Attributes{Key: "error", Value: err})
+		return nil, err
+	}
+	defer rows.Close()
+
+	var shipments []*entities.Shipment
+	for rows.Next() {
+		shipment, err := scanShipment(rows)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error scan row", o11y.Attributes{Key: "error", Value: err})
+			return nil, err
+		}
+		shipments = append(shipments, shipment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, shipment := range shipments {
+		if err := r.loadLines(ctx, shipment); err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error load shipment lines", o11y.Attributes{Key: "error", Value: err})
+			return nil, err
+		}
+	}
+	return shipments, nil
+}
+
+func scanShipment(row scanner) (*entities.Shipment, error) {
+	var (
+		shipment          entities.Shipment
+		statusDescription sql.NullString
+	)
+
+	err := row.Scan(
+		&shipment.ID.Value,
+		&shipment.OrderID.Value,
+		&shipment.Carrier,
+		&shipment.TrackingNumber,
+		&shipment.Status,
+		&statusDescription,
+		&shipment.DeliveredAt.Time,
+		&shipment.CreatedAt,
+		&shipment.UpdatedAt.Time,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	shipment.StatusDescription = statusDescription.String
+	return &shipment, nil
+}
+
+func (r *shipmentRepository) loadLines(ctx context.Context, shipment *entities.Shipment) error {
+	query := `select
+				id,
+				shipment_id,
+				order_item_id,
+				quantity,
+				created_at
+			  from
+				shipment_lines
+			  where
+				shipment_id = $1
+			  order by
+				created_at`
+
+	rows, err := r.tx.QueryContext(ctx, query, shipment.ID.String())
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var line entities.ShipmentLine
+		err := rows.Scan(
+			&line.ID.Value,
+			&line.ShipmentID.Value,
+			&line.OrderItemID.Value,
+			&line.Quantity,
+			&line.CreatedAt,
+		)
+		if err != nil {
+			return err
+		}
+		shipment.Lines = append(shipment.Lines, &line)
+	}
+	return rows.Err()
+}
@@ -0,0 +1,57 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	"github.com/jailtonjunior94/order/internal/order/domain/interfaces"
+	"github.com/jailtonjunior94/order/pkg/o11y"
+)
+
+type taxRuleRepository struct {
+	db   *sql.DB
+	o11y o11y.Observability
+}
+
+func NewTaxRuleRepository(db *sql.DB, o11y o11y.Observability) interfaces.TaxRuleRepository {
+	return &taxRuleRepository{
+		db:   db,
+		o11y: o11y,
+	}
+}
+
+func (r *taxRuleRepository) FindByRegion(ctx context.Context, region string) ([]*entities.TaxRule, error) {
+	ctx, span := r.o11y.Start(ctx, "tax_rule_repository.find_by_region")
+	defer span.End()
+
+	query := `select
+				region,
+				jurisdiction,
+				category,
+				rate
+			  from
+				tax_rules
+			  where
+				region = $1
+			  order by
+				jurisdiction`
+
+	rows, err := r.db.QueryContext(ctx, query, region)
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error find tax rules", o11y.Attributes{Key: "error", Value: err})
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rules []*entities.TaxRule
+	for rows.Next() {
+		var rule entities.TaxRule
+		if err := rows.Scan(&rule.Region, &rule.Jurisdiction, &rule.Category, &rule.Rate); err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error scan row", o11y.Attributes{Key: "error", Value: err})
+			return nil, err
+		}
+		rules = append(rules, &rule)
+	}
+	return rules, rows.Err()
+}
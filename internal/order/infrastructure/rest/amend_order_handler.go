@@ -0,0 +1,162 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/dtos"
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	"github.com/jailtonjunior94/order/internal/order/domain/factories"
+	"github.com/jailtonjunior94/order/internal/order/domain/interfaces"
+	"github.com/jailtonjunior94/order/internal/order/usecase"
+	"github.com/jailtonjunior94/order/pkg/o11y"
+	"github.com/jailtonjunior94/order/pkg/responses"
+	"github.com/jailtonjunior94/order/pkg/vos"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type AmendOrderHandler struct {
+	o11y         o11y.Observability
+	amendUseCase usecase.AmendOrderUseCase
+}
+
+func NewAmendOrderHandler(
+	o11y o11y.Observability,
+	amendUseCase usecase.AmendOrderUseCase,
+) *AmendOrderHandler {
+	return &AmendOrderHandler{
+		o11y:         o11y,
+		amendUseCase: amendUseCase,
+	}
+}
+
+func (h *AmendOrderHandler) AddItem(w http.ResponseWriter, r *http.Request) {
+	ctx, span := h.o11y.Start(r.Context(), "amend_order_handler.add_item")
+	defer span.End()
+
+	orderID, ok := h.uuid(w, r, "id", "order id is invalid")
+	if !ok {
+		return
+	}
+
+	var input *dtos.OrderItemInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		span.RecordError(err)
+		responses.Error(w, http.StatusUnprocessableEntity, "Unprocessable Entity")
+		return
+	}
+
+	version, err := ifMatch(r)
+	if err != nil {
+		responses.Error(w, http.StatusPreconditionFailed, err.Error())
+		return
+	}
+
+	output, err := h.amendUseCase.AddItem(ctx, orderID, version, input)
+	if err != nil {
+		span.RecordError(err)
+		h.error(w, err, "error adding order item")
+		return
+	}
+	setETag(w, output.Version)
+	responses.JSON(w, http.StatusOK, output)
+}
+
+func (h *AmendOrderHandler) ChangeItemQuantity(w http.ResponseWriter, r *http.Request) {
+	ctx, span := h.o11y.Start(r.Context(), "amend_order_handler.change_item_quantity")
+	defer span.End()
+
+	orderID, ok := h.uuid(w, r, "id", "order id is invalid")
+	if !ok {
+		return
+	}
+
+	orderItemID, ok := h.uuid(w, r, "item_id", "order item id is invalid")
+	if !ok {
+		return
+	}
+
+	var input *dtos.OrderItemQuantityInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		span.RecordError(err)
+		responses.Error(w, http.StatusUnprocessableEntity, "Unprocessable Entity")
+		return
+	}
+
+	version, err := ifMatch(r)
+	if err != nil {
+		responses.Error(w, http.StatusPreconditionFailed, err.Error())
+		return
+	}
+
+	output, err := h.amendUseCase.ChangeItemQuantity(ctx, orderID, orderItemID, version, input)
+	if err != nil {
+		span.RecordError(err)
+		h.error(w, err, "error changing order item")
+		return
+	}
+	setETag(w, output.Version)
+	responses.JSON(w, http.StatusOK, output)
+}
+
+func (h *AmendOrderHandler) RemoveItem(w http.ResponseWriter, r *http.Request) {
+	ctx, span := h.o11y.Start(r.Context(), "amend_order_handler.remove_item")
+	defer span.End()
+
+	orderID, ok := h.uuid(w, r, "id", "order id is invalid")
+	if !ok {
+		return
+	}
+
+	orderItemID, ok := h.uuid(w, r, "item_id", "order item id is invalid")
+	if !ok {
+		return
+	}
+
+	version, err := ifMatch(r)
+	if err != nil {
+		responses.Error(w, http.StatusPreconditionFailed, err.Error())
+		return
+	}
+
+	output, err := h.amendUseCase.RemoveItem(ctx, orderID, orderItemID, version)
+	if err != nil {
+		span.RecordError(err)
+		h.error(w, err, "error removing order item")
+		return
+	}
+	setETag(w, output.Version)
+	responses.JSON(w, http.StatusOK, output)
+}
+
+func (h *AmendOrderHandler) uuid(w http.ResponseWriter, r *http.Request, param, message string) (vos.UUID, bool) {
+	id, err := vos.NewUUIDFromString(chi.URLParam(r, param))
+	if err != nil {
+		responses.Error(w, http.StatusUnprocessableEntity, message)
+		return vos.UUID{}, false
+	}
+	return id, true
+}
+
+func (h *AmendOrderHandler) error(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, usecase.ErrOrderNotFound), errors.Is(err, entities.ErrOrderItemNotFound):
+		responses.Error(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, factories.ErrInvalidOrderItem),
+		errors.Is(err, factories.ErrUnknownProduct),
+		errors.Is(err, factories.ErrCurrencyMismatch),
+		errors.Is(err, entities.ErrShippingOptionNotFound),
+		errors.Is(err, entities.ErrShippingOptionRequired):
+		responses.Error(w, http.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, entities.ErrOrderVersionMismatch),
+		errors.Is(err, interfaces.ErrOrderConflict):
+		responses.Error(w, http.StatusPreconditionFailed, err.Error())
+	case errors.Is(err, entities.ErrOrderNotPending),
+		errors.Is(err, entities.ErrOrderItemRequired):
+		responses.Error(w, http.StatusConflict, err.Error())
+	default:
+		responses.Error(w, http.StatusInternalServerError, message)
+	}
+}
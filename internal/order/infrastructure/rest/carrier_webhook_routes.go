@@ -0,0 +1,33 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type (
+	CarrierWebhookRoutes func(carrierWebhookRoute *carrierWebhookRoute)
+	carrierWebhookRoute  struct {
+		CarrierUpdateHandler func(w http.ResponseWriter, r *http.Request)
+	}
+)
+
+func NewCarrierWebhookRoute(router chi.Router, carrierWebhookRoutes ...CarrierWebhookRoutes) *carrierWebhookRoute {
+	route := &carrierWebhookRoute{}
+	for _, carrierWebhookRoute := range carrierWebhookRoutes {
+		carrierWebhookRoute(route)
+	}
+	route.Register(router)
+	return route
+}
+
+func (u *carrierWebhookRoute) Register(router chi.Router) {
+	router.Post("/v1/carriers", u.CarrierUpdateHandler)
+}
+
+func WithCarrierUpdateHandler(handler func(w http.ResponseWriter, r *http.Request)) CarrierWebhookRoutes {
+	return func(carrierWebhookRoute *carrierWebhookRoute) {
+		carrierWebhookRoute.CarrierUpdateHandler = handler
+	}
+}
@@ -0,0 +1,90 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/dtos"
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	"github.com/jailtonjunior94/order/internal/order/domain/interfaces"
+	"github.com/jailtonjunior94/order/internal/order/usecase"
+	"github.com/jailtonjunior94/order/pkg/o11y"
+	"github.com/jailtonjunior94/order/pkg/responses"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type CouponAdminHandler struct {
+	o11y        o11y.Observability
+	couponAdmin usecase.CouponAdminUseCase
+}
+
+func NewCouponAdminHandler(
+	o11y o11y.Observability,
+	couponAdmin usecase.CouponAdminUseCase,
+) *CouponAdminHandler {
+	return &CouponAdminHandler{
+		o11y:        o11y,
+		couponAdmin: couponAdmin,
+	}
+}
+
+func (h *CouponAdminHandler) Create(w http.ResponseWriter, r *http.Request) {
+	ctx, span := h.o11y.Start(r.Context(), "coupon_admin_handler.create")
+	defer span.End()
+
+	var input *dtos.CouponInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		span.RecordError(err)
+		responses.Error(w, http.StatusUnprocessableEntity, "Unprocessable Entity")
+		return
+	}
+
+	output, err := h.couponAdmin.Create(ctx, input)
+	if err != nil {
+		span.RecordError(err)
+		h.error(w, err, "error creating coupon")
+		return
+	}
+	responses.JSON(w, http.StatusCreated, output)
+}
+
+func (h *CouponAdminHandler) Show(w http.ResponseWriter, r *http.Request) {
+	ctx, span := h.o11y.Start(r.Context(), "coupon_admin_handler.show")
+	defer span.End()
+
+	output, err := h.couponAdmin.Show(ctx, chi.URLParam(r, "code"))
+	if err != nil {
+		span.RecordError(err)
+		h.error(w, err, "error finding coupon")
+		return
+	}
+	responses.JSON(w, http.StatusOK, output)
+}
+
+func (h *CouponAdminHandler) Deactivate(w http.ResponseWriter, r *http.Request) {
+	ctx, span := h.o11y.Start(r.Context(), "coupon_admin_handler.deactivate")
+	defer span.End()
+
+	output, err := h.couponAdmin.Deactivate(ctx, chi.URLParam(r, "code"))
+	if err != nil {
+		span.RecordError(err)
+		h.error(w, err, "error deactivating coupon")
+		return
+	}
+	responses.JSON(w, http.StatusOK, output)
+}
+
+func (h *CouponAdminHandler) error(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, usecase.ErrCouponNotFound):
+		responses.Error(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, entities.ErrInvalidCoupon):
+		responses.Error(w, http.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, interfaces.ErrCouponCodeTaken):
+		responses.Error(w, http.StatusConflict, err.Error())
+	default:
+		responses.Error(w, http.StatusInternalServerError, message)
+	}
+}
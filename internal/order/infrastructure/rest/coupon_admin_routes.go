@@ -0,0 +1,51 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type (
+	CouponAdminRoutes func(couponAdminRoute *couponAdminRoute)
+	couponAdminRoute  struct {
+		CreateHandler     func(w http.ResponseWriter, r *http.Request)
+		ShowHandler       func(w http.ResponseWriter, r *http.Request)
+		DeactivateHandler func(w http.ResponseWriter, r *http.Request)
+	}
+)
+
+func NewCouponAdminRoute(router chi.Router, couponAdminRoutes ...CouponAdminRoutes) *couponAdminRoute {
+	route := &couponAdminRoute{}
+	for _, couponAdminRoute := range couponAdminRoutes {
+		couponAdminRoute(route)
+	}
+	route.Register(router)
+	return route
+}
+
+func (u *couponAdminRoute) Register(router chi.Router) {
+	router.Route("/v1/coupons", func(r chi.Router) {
+		r.Post("/", u.CreateHandler)
+		r.Get("/{code}", u.ShowHandler)
+		r.Post("/{code}/deactivate", u.DeactivateHandler)
+	})
+}
+
+func WithCreateCouponHandler(handler func(w http.ResponseWriter, r *http.Request)) CouponAdminRoutes {
+	return func(couponAdminRoute *couponAdminRoute) {
+		couponAdminRoute.CreateHandler = handler
+	}
+}
+
+func WithShowCouponHandler(handler func(w http.ResponseWriter, r *http.Request)) CouponAdminRoutes {
+	return func(couponAdminRoute *couponAdminRoute) {
+		couponAdminRoute.ShowHandler = handler
+	}
+}
+
+func WithDeactivateCouponHandler(handler func(w http.ResponseWriter, r *http.Request)) CouponAdminRoutes {
+	return func(couponAdminRoute *couponAdminRoute) {
+		couponAdminRoute.DeactivateHandler = handler
+	}
+}
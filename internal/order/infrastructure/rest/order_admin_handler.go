@@ -0,0 +1,86 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/dtos"
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	"github.com/jailtonjunior94/order/internal/order/domain/interfaces"
+	"github.com/jailtonjunior94/order/internal/order/usecase"
+	"github.com/jailtonjunior94/order/pkg/o11y"
+	"github.com/jailtonjunior94/order/pkg/responses"
+	sharedVos "github.com/jailtonjunior94/order/pkg/vos"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type OrderAdminHandler struct {
+	o11y           o11y.Observability
+	listUseCase    usecase.ListOrdersUseCase
+	paymentUseCase usecase.RegisterPaymentUseCase
+}
+
+func NewOrderAdminHandler(
+	o11y o11y.Observability,
+	listUseCase usecase.ListOrdersUseCase,
+	paymentUseCase usecase.RegisterPaymentUseCase,
+) *OrderAdminHandler {
+	return &OrderAdminHandler{
+		o11y:           o11y,
+		listUseCase:    listUseCase,
+		paymentUseCase: paymentUseCase,
+	}
+}
+
+// List lists orders across customers, optionally filtered by customer_id.
+func (h *OrderAdminHandler) List(w http.ResponseWriter, r *http.Request) {
+	ctx, span := h.o11y.Start(r.Context(), "order_admin_handler.list")
+	defer span.End()
+
+	listOrders(ctx, span, w, r, h.listUseCase, r.URL.Query().Get("customer_id"))
+}
+
+// RegisterPayment records a payment confirmed by the payment provider, which
+// is why it is only reachable with the admin token.
+func (h *OrderAdminHandler) RegisterPayment(w http.ResponseWriter, r *http.Request) {
+	ctx, span := h.o11y.Start(r.Context(), "order_admin_handler.register_payment")
+	defer span.End()
+
+	orderID, err := sharedVos.NewUUIDFromString(chi.URLParam(r, "id"))
+	if err != nil {
+		responses.Error(w, http.StatusUnprocessableEntity, "order id is invalid")
+		return
+	}
+
+	var input *dtos.PaymentInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		span.RecordError(err)
+		responses.Error(w, http.StatusUnprocessableEntity, "Unprocessable Entity")
+		return
+	}
+
+	output, err := h.paymentUseCase.Execute(ctx, orderID, input)
+	if err != nil {
+		span.RecordError(err)
+		switch {
+		case errors.Is(err, usecase.ErrOrderNotFound):
+			responses.Error(w, http.StatusNotFound, err.Error())
+		case errors.Is(err, entities.ErrInvalidPaymentAmount),
+			errors.Is(err, entities.ErrInvalidPaymentMethod),
+			errors.Is(err, entities.ErrInvalidPaymentStatus),
+			errors.Is(err, entities.ErrPaymentCurrencyMismatch):
+			responses.Error(w, http.StatusUnprocessableEntity, err.Error())
+		case errors.Is(err, entities.ErrOrderNotPayable),
+			errors.Is(err, entities.ErrPaymentExceedsBalance):
+			responses.Error(w, http.StatusConflict, err.Error())
+		case errors.Is(err, interfaces.ErrOrderConflict):
+			responses.Error(w, http.StatusPreconditionFailed, err.Error())
+		default:
+			responses.Error(w, http.StatusBadRequest, "error registering payment")
+		}
+		return
+	}
+	responses.JSON(w, http.StatusCreated, output)
+}
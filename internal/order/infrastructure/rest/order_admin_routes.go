@@ -0,0 +1,43 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type (
+	OrderAdminRoutes func(orderAdminRoute *orderAdminRoute)
+	orderAdminRoute  struct {
+		ListHandler    func(w http.ResponseWriter, r *http.Request)
+		PaymentHandler func(w http.ResponseWriter, r *http.Request)
+	}
+)
+
+func NewOrderAdminRoute(router chi.Router, orderAdminRoutes ...OrderAdminRoutes) *orderAdminRoute {
+	route := &orderAdminRoute{}
+	for _, orderAdminRoute := range orderAdminRoutes {
+		orderAdminRoute(route)
+	}
+	route.Register(router)
+	return route
+}
+
+func (u *orderAdminRoute) Register(router chi.Router) {
+	router.Route("/v1/orders", func(r chi.Router) {
+		r.Get("/", u.ListHandler)
+		r.Post("/{id}/payments", u.PaymentHandler)
+	})
+}
+
+func WithListOrdersAdminHandler(handler func(w http.ResponseWriter, r *http.Request)) OrderAdminRoutes {
+	return func(orderAdminRoute *orderAdminRoute) {
+		orderAdminRoute.ListHandler = handler
+	}
+}
+
+func WithPaymentHandler(handler func(w http.ResponseWriter, r *http.Request)) OrderAdminRoutes {
+	return func(orderAdminRoute *orderAdminRoute) {
+		orderAdminRoute.PaymentHandler = handler
+	}
+}
@@ -1,14 +1,22 @@
 package rest
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/jailtonjunior94/order/internal/order/domain/dtos"
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	"github.com/jailtonjunior94/order/internal/order/domain/factories"
+	"github.com/jailtonjunior94/order/internal/order/domain/interfaces"
+	"github.com/jailtonjunior94/order/internal/order/domain/vos"
 	"github.com/jailtonjunior94/order/internal/order/usecase"
+	"github.com/jailtonjunior94/order/pkg/identity"
 	"github.com/jailtonjunior94/order/pkg/o11y"
 	"github.com/jailtonjunior94/order/pkg/responses"
-	"github.com/jailtonjunior94/order/pkg/vos"
+	sharedVos "github.com/jailtonjunior94/order/pkg/vos"
 
 	"github.com/go-chi/chi/v5"
 )
@@ -16,18 +24,27 @@ import (
 type UserHandler struct {
 	o11y              o11y.Observability
 	createUseCase     usecase.CreateOrderUseCase
+	findUseCase       usecase.FindOrderUseCase
+	listUseCase       usecase.ListOrdersUseCase
 	markAsPaidUseCase usecase.MarkAsPaidUseCase
+	historyUseCase    usecase.OrderHistoryUseCase
 }
 
 func NewUserHandler(
 	o11y o11y.Observability,
 	createUseCase usecase.CreateOrderUseCase,
+	findUseCase usecase.FindOrderUseCase,
+	listUseCase usecase.ListOrdersUseCase,
 	markAsPaidUseCase usecase.MarkAsPaidUseCase,
+	historyUseCase usecase.OrderHistoryUseCase,
 ) *UserHandler {
 	return &UserHandler{
 		o11y:              o11y,
 		createUseCase:     createUseCase,
+		findUseCase:       findUseCase,
+		listUseCase:       listUseCase,
 		markAsPaidUseCase: markAsPaidUseCase,
+		historyUseCase:    historyUseCase,
 	}
 }
 
@@ -46,12 +63,121 @@ func (h *UserHandler) Create(w http.ResponseWriter, r *http.Request) {
 	output, err := h.createUseCase.Execute(ctx, input)
 	if err != nil {
 		span.RecordError(err)
-		responses.Error(w, http.StatusBadRequest, "error creating order")
+		switch {
+		case errors.Is(err, factories.ErrOrderWithoutItems),
+			errors.Is(err, factories.ErrInvalidOrderItem),
+			errors.Is(err, factories.ErrUnknownProduct),
+			errors.Is(err, vos.ErrInvalidAddress),
+			errors.Is(err, factories.ErrInvalidCurrency),
+			errors.Is(err, factories.ErrCurrencyMismatch),
+			errors.Is(err, usecase.ErrCouponNotFound),
+			errors.Is(err, entities.ErrShippingAddressRequired),
+			errors.Is(err, entities.ErrShippingOptionNotFound),
+			errors.Is(err, entities.ErrShippingOptionRequired),
+			isCouponRejection(err):
+			responses.Error(w, http.StatusUnprocessableEntity, err.Error())
+		case errors.Is(err, interfaces.ErrInsufficientStock):
+			responses.Error(w, http.StatusConflict, err.Error())
+		default:
+			responses.Error(w, http.StatusBadRequest, "error creating order")
+		}
 		return
 	}
+	setETag(w, output.Version)
 	responses.JSON(w, http.StatusCreated, output)
 }
 
+func (h *UserHandler) Find(w http.ResponseWriter, r *http.Request) {
+	ctx, span := h.o11y.Start(r.Context(), "order_handler.find")
+	defer span.End()
+
+	orderID, err := sharedVos.NewUUIDFromString(chi.URLParam(r, "id"))
+	if err != nil {
+		responses.Error(w, http.StatusUnprocessableEntity, "order id is invalid")
+		return
+	}
+
+	output, err := h.findUseCase.Execute(ctx, orderID)
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, usecase.ErrOrderNotFound) {
+			responses.Error(w, http.StatusNotFound, err.Error())
+			return
+		}
+		responses.Error(w, http.StatusInternalServerError, "error finding order")
+		return
+	}
+	setETag(w, output.Version)
+	responses.JSON(w, http.StatusOK, output)
+}
+
+func (h *UserHandler) History(w http.ResponseWriter, r *http.Request) {
+	ctx, span := h.o11y.Start(r.Context(), "order_handler.history")
+	defer span.End()
+
+	orderID, err := sharedVos.NewUUIDFromString(chi.URLParam(r, "id"))
+	if err != nil {
+		responses.Error(w, http.StatusUnprocessableEntity, "order id is invalid")
+		return
+	}
+
+	output, err := h.historyUseCase.Execute(ctx, orderID)
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, usecase.ErrOrderNotFound) {
+			responses.Error(w, http.StatusNotFound, err.Error())
+			return
+		}
+		responses.Error(w, http.StatusInternalServerError, "error finding order history")
+		return
+	}
+	responses.JSON(w, http.StatusOK, output)
+}
+
+// List only returns the orders of the customer the gateway authenticated;
+// listing across customers is reserved to the admin API.
+func (h *UserHandler) List(w http.ResponseWriter, r *http.Request) {
+	ctx, span := h.o11y.Start(r.Context(), "order_handler.list")
+	defer span.End()
+
+	customerID := identity.CustomerFromContext(ctx)
+	if customerID == "" {
+		responses.Error(w, http.StatusUnauthorized, "customer is required")
+		return
+	}
+	listOrders(ctx, span, w, r, h.listUseCase, customerID)
+}
+
+func listOrders(ctx context.Context, span o11y.Span, w http.ResponseWriter, r *http.Request, listUseCase usecase.ListOrdersUseCase, customerID string) {
+	query := r.URL.Query()
+	input := &dtos.OrderFilterInput{
+		CustomerID: customerID,
+		Status:     query.Get("status"),
+		Limit:      50,
+	}
+
+	if limit := query.Get("limit"); limit != "" {
+		parsed, err := strconv.Atoi(limit)
+		if err != nil || parsed <= 0 {
+			responses.Error(w, http.StatusUnprocessableEntity, "limit must be a positive integer")
+			return
+		}
+		input.Limit = parsed
+	}
+
+	output, err := listUseCase.Execute(ctx, input)
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, usecase.ErrInvalidOrderStatus) {
+			responses.Error(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		responses.Error(w, http.StatusInternalServerError, "error listing orders")
+		return
+	}
+	responses.JSON(w, http.StatusOK, output)
+}
+
 func (h *UserHandler) MarkAsPaid(w http.ResponseWriter, r *http.Request) {
 	ctx, span := h.o11y.Start(r.Context(), "order_handler.mark_as_paid")
 	defer span.End()
@@ -62,17 +188,48 @@ func (h *UserHandler) MarkAsPaid(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	orderID, err := vos.NewUUIDFromString(orderIDParam)
+	orderID, err := sharedVos.NewUUIDFromString(orderIDParam)
 	if err != nil {
 		responses.Error(w, http.StatusUnprocessableEntity, "order id is invalid")
 		return
 	}
 
-	output, err := h.markAsPaidUseCase.Execute(ctx, orderID)
+	version, err := ifMatch(r)
+	if err != nil {
+		responses.Error(w, http.StatusPreconditionFailed, err.Error())
+		return
+	}
+
+	output, err := h.markAsPaidUseCase.Execute(ctx, orderID, version)
 	if err != nil {
 		span.RecordError(err)
-		responses.Error(w, http.StatusBadRequest, "error updating order")
+		switch {
+		case errors.Is(err, entities.ErrOrderVersionMismatch),
+			errors.Is(err, interfaces.ErrOrderConflict):
+			responses.Error(w, http.StatusPreconditionFailed, err.Error())
+		default:
+			responses.Error(w, http.StatusBadRequest, "error updating order")
+		}
 		return
 	}
+	setETag(w, output.Version)
 	responses.JSON(w, http.StatusOK, output)
 }
+
+func isCouponRejection(err error) bool {
+	for _, rejection := range []error{
+		entities.ErrCouponInactive,
+		entities.ErrCouponNotStarted,
+		entities.ErrCouponExpired,
+		entities.ErrCouponUsageLimitReached,
+		entities.ErrCouponCustomerRequired,
+		entities.ErrCouponCustomerLimitReached,
+		entities.ErrCouponMinimumSpendNotMet,
+		entities.ErrCouponNotApplicable,
+	} {
+		if errors.Is(err, rejection) {
+			return true
+		}
+	}
+	return false
+}
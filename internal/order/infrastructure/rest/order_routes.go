@@ -10,7 +10,16 @@ type (
 	Routes     func(orderRoute *orderRoute)
 	orderRoute struct {
 		CreateOrderHandler func(w http.ResponseWriter, r *http.Request)
+		FindOrderHandler   func(w http.ResponseWriter, r *http.Request)
+		ListOrdersHandler  func(w http.ResponseWriter, r *http.Request)
 		MarkAsPaidHandler  func(w http.ResponseWriter, r *http.Request)
+		ReturnHandler      func(w http.ResponseWriter, r *http.Request)
+		ListReturnsHandler func(w http.ResponseWriter, r *http.Request)
+		AddItemHandler     func(w http.ResponseWriter, r *http.Request)
+		ChangeItemHandler  func(w http.ResponseWriter, r *http.Request)
+		RemoveItemHandler  func(w http.ResponseWriter, r *http.Request)
+		HistoryHandler     func(w http.ResponseWriter, r *http.Request)
+		ShipmentsHandler   func(w http.ResponseWriter, r *http.Request)
 	}
 )
 
@@ -25,8 +34,17 @@ func NewOrderRoute(router *chi.Mux, orderRoutes ...Routes) *orderRoute {
 
 func (u *orderRoute) Register(router *chi.Mux) {
 	router.Route("/api/v1/orders", func(r chi.Router) {
+		r.Get("/", u.ListOrdersHandler)
 		r.Post("/", u.CreateOrderHandler)
+		r.Get("/{id}", u.FindOrderHandler)
+		r.Get("/{id}/history", u.HistoryHandler)
 		r.Patch("/{id}", u.MarkAsPaidHandler)
+		r.Post("/{id}/returns", u.ReturnHandler)
+		r.Get("/{id}/returns", u.ListReturnsHandler)
+		r.Post("/{id}/items", u.AddItemHandler)
+		r.Patch("/{id}/items/{item_id}", u.ChangeItemHandler)
+		r.Delete("/{id}/items/{item_id}", u.RemoveItemHandler)
+		r.Get("/{id}/shipments", u.ShipmentsHandler)
 	})
 }
 
@@ -36,8 +54,62 @@ func WithCreateOrderHandler(handler func(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+func WithFindOrderHandler(handler func(w http.ResponseWriter, r *http.Request)) Routes {
+	return func(orderRoute *orderRoute) {
+		orderRoute.FindOrderHandler = handler
+	}
+}
+
+func WithHistoryHandler(handler func(w http.ResponseWriter, r *http.Request)) Routes {
+	return func(orderRoute *orderRoute) {
+		orderRoute.HistoryHandler = handler
+	}
+}
+
+func WithListOrdersHandler(handler func(w http.ResponseWriter, r *http.Request)) Routes {
+	return func(orderRoute *orderRoute) {
+		orderRoute.ListOrdersHandler = handler
+	}
+}
+
 func WithMarkAsPaidHandler(handler func(w http.ResponseWriter, r *http.Request)) Routes {
 	return func(orderRoute *orderRoute) {
 		orderRoute.MarkAsPaidHandler = handler
 	}
 }
+
+func WithReturnHandler(handler func(w http.ResponseWriter, r *http.Request)) Routes {
+	return func(orderRoute *orderRoute) {
+		orderRoute.ReturnHandler = handler
+	}
+}
+
+func WithListReturnsHandler(handler func(w http.ResponseWriter, r *http.Request)) Routes {
+	return func(orderRoute *orderRoute) {
+		orderRoute.ListReturnsHandler = handler
+	}
+}
+
+func WithAddItemHandler(handler func(w http.ResponseWriter, r *http.Request)) Routes {
+	return func(orderRoute *orderRoute) {
+		orderRoute.AddItemHandler = handler
+	}
+}
+
+func WithChangeItemHandler(handler func(w http.ResponseWriter, r *http.Request)) Routes {
+	return func(orderRoute *orderRoute) {
+		orderRoute.ChangeItemHandler = handler
+	}
+}
+
+func WithRemoveItemHandler(handler func(w http.ResponseWriter, r *http.Request)) Routes {
+	return func(orderRoute *orderRoute) {
+		orderRoute.RemoveItemHandler = handler
+	}
+}
+
+func WithShipmentsHandler(handler func(w http.ResponseWriter, r *http.Request)) Routes {
+	return func(orderRoute *orderRoute) {
+		orderRoute.ShipmentsHandler = handler
+	}
+}
@@ -0,0 +1,179 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/dtos"
+	"github.com/jailtonjunior94/order/internal/order/usecase"
+	"github.com/jailtonjunior94/order/pkg/o11y"
+	"github.com/jailtonjunior94/order/pkg/responses"
+	"github.com/jailtonjunior94/order/pkg/vos"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type OutboxAdminHandler struct {
+	o11y        o11y.Observability
+	outboxAdmin usecase.OutboxAdminUseCase
+}
+
+func NewOutboxAdminHandler(
+	o11y o11y.Observability,
+	outboxAdmin usecase.OutboxAdminUseCase,
+) *OutboxAdminHandler {
+	return &OutboxAdminHandler{
+		o11y:        o11y,
+		outboxAdmin: outboxAdmin,
+	}
+}
+
+func (h *OutboxAdminHandler) List(w http.ResponseWriter, r *http.Request) {
+	ctx, span := h.o11y.Start(r.Context(), "outbox_admin_handler.list")
+	defer span.End()
+
+	query := r.URL.Query()
+	input := &dtos.OutboxFilterInput{
+		Status:    query.Get("status"),
+		EventName: query.Get("event_name"),
+		Limit:     100,
+	}
+
+	if since := query.Get("since"); since != "" {
+		parsed, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			responses.Error(w, http.StatusUnprocessableEntity, "since must be RFC3339")
+			return
+		}
+		input.Since = parsed
+	}
+
+	if limit := query.Get("limit"); limit != "" {
+		parsed, err := strconv.Atoi(limit)
+		if err != nil || parsed <= 0 {
+			responses.Error(w, http.StatusUnprocessableEntity, "limit must be a positive integer")
+			return
+		}
+		input.Limit = parsed
+	}
+
+	output, err := h.outboxAdmin.List(ctx, input)
+	if err != nil {
+		span.RecordError(err)
+		h.error(w, err, "error listing outbox")
+		return
+	}
+	responses.JSON(w, http.StatusOK, output)
+}
+
+func (h *OutboxAdminHandler) Lag(w http.ResponseWriter, r *http.Request) {
+	ctx, span := h.o11y.Start(r.Context(), "outbox_admin_handler.lag")
+	defer span.End()
+
+	output, err := h.outboxAdmin.Lag(ctx)
+	if err != nil {
+		span.RecordError(err)
+		h.error(w, err, "error calculating outbox lag")
+		return
+	}
+	responses.JSON(w, http.StatusOK, output)
+}
+
+func (h *OutboxAdminHandler) Show(w http.ResponseWriter, r *http.Request) {
+	ctx, span := h.o11y.Start(r.Context(), "outbox_admin_handler.show")
+	defer span.End()
+
+	id, ok := h.outboxID(w, r)
+	if !ok {
+		return
+	}
+
+	output, err := h.outboxAdmin.Show(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		h.error(w, err, "error finding outbox")
+		return
+	}
+	responses.JSON(w, http.StatusOK, output)
+}
+
+func (h *OutboxAdminHandler) Republish(w http.ResponseWriter, r *http.Request) {
+	ctx, span := h.o11y.Start(r.Context(), "outbox_admin_handler.republish")
+	defer span.End()
+
+	id, ok := h.outboxID(w, r)
+	if !ok {
+		return
+	}
+
+	output, err := h.outboxAdmin.Requeue(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		h.error(w, err, "error republishing outbox")
+		return
+	}
+	responses.JSON(w, http.StatusAccepted, output)
+}
+
+func (h *OutboxAdminHandler) Replay(w http.ResponseWriter, r *http.Request) {
+	ctx, span := h.o11y.Start(r.Context(), "outbox_admin_handler.replay")
+	defer span.End()
+
+	var input *dtos.OutboxFilterInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		span.RecordError(err)
+		responses.Error(w, http.StatusUnprocessableEntity, "Unprocessable Entity")
+		return
+	}
+
+	output, err := h.outboxAdmin.Replay(ctx, input)
+	if err != nil {
+		span.RecordError(err)
+		h.error(w, err, "error replaying outbox")
+		return
+	}
+	responses.JSON(w, http.StatusOK, output)
+}
+
+func (h *OutboxAdminHandler) Skip(w http.ResponseWriter, r *http.Request) {
+	ctx, span := h.o11y.Start(r.Context(), "outbox_admin_handler.skip")
+	defer span.End()
+
+	id, ok := h.outboxID(w, r)
+	if !ok {
+		return
+	}
+
+	output, err := h.outboxAdmin.Skip(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		h.error(w, err, "error skipping outbox")
+		return
+	}
+	responses.JSON(w, http.StatusOK, output)
+}
+
+func (h *OutboxAdminHandler) outboxID(w http.ResponseWriter, r *http.Request) (vos.UUID, bool) {
+	id, err := vos.NewUUIDFromString(chi.URLParam(r, "id"))
+	if err != nil {
+		responses.Error(w, http.StatusUnprocessableEntity, "outbox id is invalid")
+		return vos.UUID{}, false
+	}
+	return id, true
+}
+
+func (h *OutboxAdminHandler) error(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, usecase.ErrOutboxNotFound):
+		responses.Error(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, usecase.ErrOutboxAlreadyPublished):
+		responses.Error(w, http.StatusConflict, err.Error())
+	case errors.Is(err, usecase.ErrInvalidOutboxStatus), errors.Is(err, usecase.ErrReplayFilterRequired):
+		responses.Error(w, http.StatusUnprocessableEntity, err.Error())
+	default:
+		responses.Error(w, http.StatusInternalServerError, message)
+	}
+}
@@ -0,0 +1,75 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type (
+	OutboxAdminRoutes func(outboxAdminRoute *outboxAdminRoute)
+	outboxAdminRoute  struct {
+		ListHandler      func(w http.ResponseWriter, r *http.Request)
+		LagHandler       func(w http.ResponseWriter, r *http.Request)
+		ShowHandler      func(w http.ResponseWriter, r *http.Request)
+		RepublishHandler func(w http.ResponseWriter, r *http.Request)
+		ReplayHandler    func(w http.ResponseWriter, r *http.Request)
+		SkipHandler      func(w http.ResponseWriter, r *http.Request)
+	}
+)
+
+func NewOutboxAdminRoute(router chi.Router, outboxAdminRoutes ...OutboxAdminRoutes) *outboxAdminRoute {
+	route := &outboxAdminRoute{}
+	for _, outboxAdminRoute := range outboxAdminRoutes {
+		outboxAdminRoute(route)
+	}
+	route.Register(router)
+	return route
+}
+
+func (u *outboxAdminRoute) Register(router chi.Router) {
+	router.Route("/v1/outbox", func(r chi.Router) {
+		r.Get("/", u.ListHandler)
+		r.Get("/lag", u.LagHandler)
+		r.Post("/republish", u.ReplayHandler)
+		r.Get("/{id}", u.ShowHandler)
+		r.Post("/{id}/republish", u.RepublishHandler)
+		r.Post("/{id}/skip", u.SkipHandler)
+	})
+}
+
+func WithListOutboxHandler(handler func(w http.ResponseWriter, r *http.Request)) OutboxAdminRoutes {
+	return func(outboxAdminRoute *outboxAdminRoute) {
+		outboxAdminRoute.ListHandler = handler
+	}
+}
+
+func WithOutboxLagHandler(handler func(w http.ResponseWriter, r *http.Request)) OutboxAdminRoutes {
+	return func(outboxAdminRoute *outboxAdminRoute) {
+		outboxAdminRoute.LagHandler = handler
+	}
+}
+
+func WithShowOutboxHandler(handler func(w http.ResponseWriter, r *http.Request)) OutboxAdminRoutes {
+	return func(outboxAdminRoute *outboxAdminRoute) {
+		outboxAdminRoute.ShowHandler = handler
+	}
+}
+
+func WithRepublishOutboxHandler(handler func(w http.ResponseWriter, r *http.Request)) OutboxAdminRoutes {
+	return func(outboxAdminRoute *outboxAdminRoute) {
+		outboxAdminRoute.RepublishHandler = handler
+	}
+}
+
+func WithReplayOutboxHandler(handler func(w http.ResponseWriter, r *http.Request)) OutboxAdminRoutes {
+	return func(outboxAdminRoute *outboxAdminRoute) {
+		outboxAdminRoute.ReplayHandler = handler
+	}
+}
+
+func WithSkipOutboxHandler(handler func(w http.ResponseWriter, r *http.Request)) OutboxAdminRoutes {
+	return func(outboxAdminRoute *outboxAdminRoute) {
+		outboxAdminRoute.SkipHandler = handler
+	}
+}
@@ -0,0 +1,59 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type (
+	ReturnAdminRoutes func(returnAdminRoute *returnAdminRoute)
+	returnAdminRoute  struct {
+		ShowHandler    func(w http.ResponseWriter, r *http.Request)
+		ApproveHandler func(w http.ResponseWriter, r *http.Request)
+		RejectHandler  func(w http.ResponseWriter, r *http.Request)
+		RefundHandler  func(w http.ResponseWriter, r *http.Request)
+	}
+)
+
+func NewReturnAdminRoute(router chi.Router, returnAdminRoutes ...ReturnAdminRoutes) *returnAdminRoute {
+	route := &returnAdminRoute{}
+	for _, returnAdminRoute := range returnAdminRoutes {
+		returnAdminRoute(route)
+	}
+	route.Register(router)
+	return route
+}
+
+func (u *returnAdminRoute) Register(router chi.Router) {
+	router.Route("/v1/returns", func(r chi.Router) {
+		r.Get("/{id}", u.ShowHandler)
+		r.Post("/{id}/approve", u.ApproveHandler)
+		r.Post("/{id}/reject", u.RejectHandler)
+		r.Post("/{id}/refund", u.RefundHandler)
+	})
+}
+
+func WithShowReturnHandler(handler func(w http.ResponseWriter, r *http.Request)) ReturnAdminRoutes {
+	return func(returnAdminRoute *returnAdminRoute) {
+		returnAdminRoute.ShowHandler = handler
+	}
+}
+
+func WithApproveReturnHandler(handler func(w http.ResponseWriter, r *http.Request)) ReturnAdminRoutes {
+	return func(returnAdminRoute *returnAdminRoute) {
+		returnAdminRoute.ApproveHandler = handler
+	}
+}
+
+func WithRejectReturnHandler(handler func(w http.ResponseWriter, r *http.Request)) ReturnAdminRoutes {
+	return func(returnAdminRoute *returnAdminRoute) {
+		returnAdminRoute.RejectHandler = handler
+	}
+}
+
+func WithRefundReturnHandler(handler func(w http.ResponseWriter, r *http.Request)) ReturnAdminRoutes {
+	return func(returnAdminRoute *returnAdminRoute) {
+		returnAdminRoute.RefundHandler = handler
+	}
+}
@@ -0,0 +1,181 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/dtos"
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	"github.com/jailtonjunior94/order/internal/order/domain/interfaces"
+	"github.com/jailtonjunior94/order/internal/order/usecase"
+	"github.com/jailtonjunior94/order/pkg/o11y"
+	"github.com/jailtonjunior94/order/pkg/responses"
+	"github.com/jailtonjunior94/order/pkg/vos"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type ReturnHandler struct {
+	o11y          o11y.Observability
+	returnUseCase usecase.OrderReturnUseCase
+}
+
+func NewReturnHandler(
+	o11y o11y.Observability,
+	returnUseCase usecase.OrderReturnUseCase,
+) *ReturnHandler {
+	return &ReturnHandler{
+		o11y:          o11y,
+		returnUseCase: returnUseCase,
+	}
+}
+
+func (h *ReturnHandler) Request(w http.ResponseWriter, r *http.Request) {
+	ctx, span := h.o11y.Start(r.Context(), "return_handler.request")
+	defer span.End()
+
+	orderID, ok := h.id(w, r, "order id is invalid")
+	if !ok {
+		return
+	}
+
+	var input *dtos.ReturnInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		span.RecordError(err)
+		responses.Error(w, http.StatusUnprocessableEntity, "Unprocessable Entity")
+		return
+	}
+
+	output, err := h.returnUseCase.Request(ctx, orderID, input)
+	if err != nil {
+		span.RecordError(err)
+		h.error(w, err, "error requesting return")
+		return
+	}
+	responses.JSON(w, http.StatusCreated, output)
+}
+
+func (h *ReturnHandler) List(w http.ResponseWriter, r *http.Request) {
+	ctx, span := h.o11y.Start(r.Context(), "return_handler.list")
+	defer span.End()
+
+	orderID, ok := h.id(w, r, "order id is invalid")
+	if !ok {
+		return
+	}
+
+	output, err := h.returnUseCase.List(ctx, orderID)
+	if err != nil {
+		span.RecordError(err)
+		h.error(w, err, "error listing returns")
+		return
+	}
+	responses.JSON(w, http.StatusOK, output)
+}
+
+func (h *ReturnHandler) Show(w http.ResponseWriter, r *http.Request) {
+	ctx, span := h.o11y.Start(r.Context(), "return_handler.show")
+	defer span.End()
+
+	returnID, ok := h.id(w, r, "return id is invalid")
+	if !ok {
+		return
+	}
+
+	output, err := h.returnUseCase.Show(ctx, returnID)
+	if err != nil {
+		span.RecordError(err)
+		h.error(w, err, "error finding return")
+		return
+	}
+	responses.JSON(w, http.StatusOK, output)
+}
+
+func (h *ReturnHandler) Approve(w http.ResponseWriter, r *http.Request) {
+	ctx, span := h.o11y.Start(r.Context(), "return_handler.approve")
+	defer span.End()
+
+	returnID, ok := h.id(w, r, "return id is invalid")
+	if !ok {
+		return
+	}
+
+	output, err := h.returnUseCase.Approve(ctx, returnID)
+	if err != nil {
+		span.RecordError(err)
+		h.error(w, err, "error approving return")
+		return
+	}
+	responses.JSON(w, http.StatusOK, output)
+}
+
+func (h *ReturnHandler) Reject(w http.ResponseWriter, r *http.Request) {
+	ctx, span := h.o11y.Start(r.Context(), "return_handler.reject")
+	defer span.End()
+
+	returnID, ok := h.id(w, r, "return id is invalid")
+	if !ok {
+		return
+	}
+
+	var input *dtos.RejectReturnInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		span.RecordError(err)
+		responses.Error(w, http.StatusUnprocessableEntity, "Unprocessable Entity")
+		return
+	}
+
+	output, err := h.returnUseCase.Reject(ctx, returnID, input)
+	if err != nil {
+		span.RecordError(err)
+		h.error(w, err, "error rejecting return")
+		return
+	}
+	responses.JSON(w, http.StatusOK, output)
+}
+
+func (h *ReturnHandler) Refund(w http.ResponseWriter, r *http.Request) {
+	ctx, span := h.o11y.Start(r.Context(), "return_handler.refund")
+	defer span.End()
+
+	returnID, ok := h.id(w, r, "return id is invalid")
+	if !ok {
+		return
+	}
+
+	output, err := h.returnUseCase.Refund(ctx, returnID)
+	if err != nil {
+		span.RecordError(err)
+		h.error(w, err, "error refunding return")
+		return
+	}
+	responses.JSON(w, http.StatusOK, output)
+}
+
+func (h *ReturnHandler) id(w http.ResponseWriter, r *http.Request, message string) (vos.UUID, bool) {
+	id, err := vos.NewUUIDFromString(chi.URLParam(r, "id"))
+	if err != nil {
+		responses.Error(w, http.StatusUnprocessableEntity, message)
+		return vos.UUID{}, false
+	}
+	return id, true
+}
+
+func (h *ReturnHandler) error(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, usecase.ErrOrderNotFound), errors.Is(err, usecase.ErrReturnNotFound):
+		responses.Error(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, entities.ErrReturnWithoutLines), errors.Is(err, entities.ErrInvalidReturnLine):
+		responses.Error(w, http.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, entities.ErrReturnQuantityExceeded),
+		errors.Is(err, entities.ErrReturnStepMismatch),
+		errors.Is(err, entities.ErrOrderNotRefundable),
+		errors.Is(err, entities.ErrRefundExceedsPaid):
+		responses.Error(w, http.StatusConflict, err.Error())
+	case errors.Is(err, interfaces.ErrOrderConflict):
+		responses.Error(w, http.StatusPreconditionFailed, err.Error())
+	default:
+		responses.Error(w, http.StatusInternalServerError, message)
+	}
+}
@@ -0,0 +1,41 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type (
+	ShipmentAdminRoutes func(shipmentAdminRoute *shipmentAdminRoute)
+	shipmentAdminRoute  struct {
+		CreateHandler func(w http.ResponseWriter, r *http.Request)
+		ShowHandler   func(w http.ResponseWriter, r *http.Request)
+	}
+)
+
+func NewShipmentAdminRoute(router chi.Router, shipmentAdminRoutes ...ShipmentAdminRoutes) *shipmentAdminRoute {
+	route := &shipmentAdminRoute{}
+	for _, shipmentAdminRoute := range shipmentAdminRoutes {
+		shipmentAdminRoute(route)
+	}
+	route.Register(router)
+	return route
+}
+
+func (u *shipmentAdminRoute) Register(router chi.Router) {
+	router.Post("/v1/orders/{id}/shipments", u.CreateHandler)
+	router.Get("/v1/shipments/{id}", u.ShowHandler)
+}
+
+func WithCreateShipmentHandler(handler func(w http.ResponseWriter, r *http.Request)) ShipmentAdminRoutes {
+	return func(shipmentAdminRoute *shipmentAdminRoute) {
+		shipmentAdminRoute.CreateHandler = handler
+	}
+}
+
+func WithShowShipmentHandler(handler func(w http.ResponseWriter, r *http.Request)) ShipmentAdminRoutes {
+	return func(shipmentAdminRoute *shipmentAdminRoute) {
+		shipmentAdminRoute.ShowHandler = handler
+	}
+}
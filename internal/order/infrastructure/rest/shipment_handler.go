@@ -0,0 +1,141 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/dtos"
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	"github.com/jailtonjunior94/order/internal/order/domain/interfaces"
+	"github.com/jailtonjunior94/order/internal/order/usecase"
+	"github.com/jailtonjunior94/order/pkg/o11y"
+	"github.com/jailtonjunior94/order/pkg/responses"
+	"github.com/jailtonjunior94/order/pkg/vos"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type ShipmentHandler struct {
+	o11y            o11y.Observability
+	shipmentUseCase usecase.ShipmentUseCase
+}
+
+func NewShipmentHandler(
+	o11y o11y.Observability,
+	shipmentUseCase usecase.ShipmentUseCase,
+) *ShipmentHandler {
+	return &ShipmentHandler{
+		o11y:            o11y,
+		shipmentUseCase: shipmentUseCase,
+	}
+}
+
+func (h *ShipmentHandler) Create(w http.ResponseWriter, r *http.Request) {
+	ctx, span := h.o11y.Start(r.Context(), "shipment_handler.create")
+	defer span.End()
+
+	orderID, ok := h.id(w, r, "order id is invalid")
+	if !ok {
+		return
+	}
+
+	var input *dtos.ShipmentInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		span.RecordError(err)
+		responses.Error(w, http.StatusUnprocessableEntity, "Unprocessable Entity")
+		return
+	}
+
+	output, err := h.shipmentUseCase.Create(ctx, orderID, input)
+	if err != nil {
+		span.RecordError(err)
+		h.error(w, err, "error creating shipment")
+		return
+	}
+	responses.JSON(w, http.StatusCreated, output)
+}
+
+func (h *ShipmentHandler) List(w http.ResponseWriter, r *http.Request) {
+	ctx, span := h.o11y.Start(r.Context(), "shipment_handler.list")
+	defer span.End()
+
+	orderID, ok := h.id(w, r, "order id is invalid")
+	if !ok {
+		return
+	}
+
+	output, err := h.shipmentUseCase.List(ctx, orderID)
+	if err != nil {
+		span.RecordError(err)
+		h.error(w, err, "error listing shipments")
+		return
+	}
+	responses.JSON(w, http.StatusOK, output)
+}
+
+func (h *ShipmentHandler) Show(w http.ResponseWriter, r *http.Request) {
+	ctx, span := h.o11y.Start(r.Context(), "shipment_handler.show")
+	defer span.End()
+
+	shipmentID, ok := h.id(w, r, "shipment id is invalid")
+	if !ok {
+		return
+	}
+
+	output, err := h.shipmentUseCase.Show(ctx, shipmentID)
+	if err != nil {
+		span.RecordError(err)
+		h.error(w, err, "error finding shipment")
+		return
+	}
+	responses.JSON(w, http.StatusOK, output)
+}
+
+func (h *ShipmentHandler) CarrierUpdate(w http.ResponseWriter, r *http.Request) {
+	ctx, span := h.o11y.Start(r.Context(), "shipment_handler.carrier_update")
+	defer span.End()
+
+	var input *dtos.CarrierUpdateInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		span.RecordError(err)
+		responses.Error(w, http.StatusUnprocessableEntity, "Unprocessable Entity")
+		return
+	}
+
+	output, err := h.shipmentUseCase.CarrierUpdate(ctx, input)
+	if err != nil {
+		span.RecordError(err)
+		h.error(w, err, "error applying carrier update")
+		return
+	}
+	responses.JSON(w, http.StatusOK, output)
+}
+
+func (h *ShipmentHandler) id(w http.ResponseWriter, r *http.Request, message string) (vos.UUID, bool) {
+	id, err := vos.NewUUIDFromString(chi.URLParam(r, "id"))
+	if err != nil {
+		responses.Error(w, http.StatusUnprocessableEntity, message)
+		return vos.UUID{}, false
+	}
+	return id, true
+}
+
+func (h *ShipmentHandler) error(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, usecase.ErrOrderNotFound), errors.Is(err, usecase.ErrShipmentNotFound):
+		responses.Error(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, entities.ErrInvalidShipment),
+		errors.Is(err, entities.ErrShipmentWithoutLines),
+		errors.Is(err, entities.ErrInvalidShipmentLine),
+		errors.Is(err, entities.ErrInvalidShipmentStatus):
+		responses.Error(w, http.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, entities.ErrOrderNotShippable),
+		errors.Is(err, entities.ErrShipmentQuantityExceeded):
+		responses.Error(w, http.StatusConflict, err.Error())
+	case errors.Is(err, interfaces.ErrOrderConflict):
+		responses.Error(w, http.StatusPreconditionFailed, err.Error())
+	default:
+		responses.Error(w, http.StatusInternalServerError, message)
+	}
+}
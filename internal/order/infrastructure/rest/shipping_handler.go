@@ -0,0 +1,61 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/dtos"
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	"github.com/jailtonjunior94/order/internal/order/domain/factories"
+	"github.com/jailtonjunior94/order/internal/order/domain/vos"
+	"github.com/jailtonjunior94/order/internal/order/usecase"
+	"github.com/jailtonjunior94/order/pkg/o11y"
+	"github.com/jailtonjunior94/order/pkg/responses"
+)
+
+type ShippingHandler struct {
+	o11y         o11y.Observability
+	quoteUseCase usecase.QuoteShippingUseCase
+}
+
+func NewShippingHandler(
+	o11y o11y.Observability,
+	quoteUseCase usecase.QuoteShippingUseCase,
+) *ShippingHandler {
+	return &ShippingHandler{
+		o11y:         o11y,
+		quoteUseCase: quoteUseCase,
+	}
+}
+
+func (h *ShippingHandler) Quote(w http.ResponseWriter, r *http.Request) {
+	ctx, span := h.o11y.Start(r.Context(), "shipping_handler.quote")
+	defer span.End()
+
+	var input *dtos.ShippingQuoteInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		span.RecordError(err)
+		responses.Error(w, http.StatusUnprocessableEntity, "Unprocessable Entity")
+		return
+	}
+
+	output, err := h.quoteUseCase.Execute(ctx, input)
+	if err != nil {
+		span.RecordError(err)
+		switch {
+		case errors.Is(err, factories.ErrOrderWithoutItems),
+			errors.Is(err, factories.ErrInvalidOrderItem),
+			errors.Is(err, factories.ErrUnknownProduct),
+			errors.Is(err, factories.ErrInvalidCurrency),
+			errors.Is(err, factories.ErrCurrencyMismatch),
+			errors.Is(err, vos.ErrInvalidAddress),
+			errors.Is(err, entities.ErrShippingAddressRequired):
+			responses.Error(w, http.StatusUnprocessableEntity, err.Error())
+		default:
+			responses.Error(w, http.StatusInternalServerError, "error quoting shipping")
+		}
+		return
+	}
+	responses.JSON(w, http.StatusOK, output)
+}
@@ -0,0 +1,35 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type (
+	ShippingRoutes func(shippingRoute *shippingRoute)
+	shippingRoute  struct {
+		QuoteHandler func(w http.ResponseWriter, r *http.Request)
+	}
+)
+
+func NewShippingRoute(router chi.Router, shippingRoutes ...ShippingRoutes) *shippingRoute {
+	route := &shippingRoute{}
+	for _, shippingRoute := range shippingRoutes {
+		shippingRoute(route)
+	}
+	route.Register(router)
+	return route
+}
+
+func (u *shippingRoute) Register(router chi.Router) {
+	router.Route("/api/v1/shipping", func(r chi.Router) {
+		r.Post("/quotes", u.QuoteHandler)
+	})
+}
+
+func WithQuoteShippingHandler(handler func(w http.ResponseWriter, r *http.Request)) ShippingRoutes {
+	return func(shippingRoute *shippingRoute) {
+		shippingRoute.QuoteHandler = handler
+	}
+}
@@ -0,0 +1,165 @@
+package shipping
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	"github.com/jailtonjunior94/order/internal/order/domain/interfaces"
+	"github.com/jailtonjunior94/order/internal/order/domain/vos"
+	httpclient "github.com/jailtonjunior94/order/pkg/http-client"
+	"github.com/jailtonjunior94/order/pkg/o11y"
+)
+
+const defaultTimeout = 5 * time.Second
+
+type (
+	httpRates struct {
+		baseURL string
+		timeout time.Duration
+		client  httpclient.HTTPClient
+		o11y    o11y.Observability
+	}
+
+	quoteRequest struct {
+		Destination *destination    `json:"destination"`
+		Currency    string          `json:"currency"`
+		Subtotal    float64         `json:"subtotal"`
+		Packages    []*quotePackage `json:"packages"`
+	}
+
+	destination struct {
+		City       string `json:"city"`
+		State      string `json:"state,omitempty"`
+		PostalCode string `json:"postal_code"`
+		Country    string `json:"country"`
+	}
+
+	quotePackage struct {
+		SKU      string  `json:"sku"`
+		Quantity uint    `json:"quantity"`
+		Weight   float64 `json:"weight"`
+		Length   float64 `json:"length"`
+		Width    float64 `json:"width"`
+		Height   float64 `json:"height"`
+	}
+
+	optionResponse struct {
+		Code          string  `json:"code"`
+		Carrier       string  `json:"carrier"`
+		Service       string  `json:"service"`
+		Amount        float64 `json:"amount"`
+		Currency      string  `json:"currency"`
+		EstimatedDays int     `json:"estimated_days"`
+	}
+
+	errorResponse struct {
+		Message string `json:"message"`
+	}
+)
+
+// NewHTTPRates asks a rating service for options; weight is sent in
+// kilograms and dimensions in centimeters, per unit.
+func NewHTTPRates(baseURL string, timeout time.Duration, client httpclient.HTTPClient, o11y o11y.Observability) interfaces.ShippingRateProvider {
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	return &httpRates{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		timeout: timeout,
+		client:  client,
+		o11y:    o11y,
+	}
+}
+
+func (h *httpRates) Quote(ctx context.Context, request *entities.ShippingRequest) ([]*entities.ShippingOption, error) {
+	ctx, span := h.o11y.Start(ctx, "http_rates.quote")
+	defer span.End()
+
+	if request.Destination == nil {
+		return nil, entities.ErrShippingAddressRequired
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	body := &quoteRequest{
+		Destination: &destination{
+			City:       request.Destination.City,
+			State:      request.Destination.State,
+			PostalCode: request.Destination.PostalCode,
+			Country:    request.Destination.Country,
+		},
+		Currency: request.Currency.String(),
+		Subtotal: request.Subtotal,
+		Packages: make([]*quotePackage, 0, len(request.Packages)),
+	}
+
+	for _, pkg := range request.Packages {
+		body.Packages = append(body.Packages, &quotePackage{
+			SKU:      pkg.SKU,
+			Quantity: pkg.Quantity,
+			Weight:   pkg.Weight,
+			Length:   pkg.Length,
+			Width:    pkg.Width,
+			Height:   pkg.Height,
+		})
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	status, response, failure, err := httpclient.MakeRequest[[]*optionResponse, errorResponse](
+		ctx,
+		h.client,
+		http.MethodPost,
+		h.baseURL+"/quotes",
+		map[string]string{"Content-Type": "application/json", "Accept": "application/json"},
+		bytes.NewReader(payload),
+	)
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error quote shipping", o11y.Attributes{Key: "error", Value: err})
+		return nil, err
+	}
+
+	if failure != nil || response == nil {
+		err := fmt.Errorf("shipping quote failed with status %d: %s", status, failure.message())
+		span.AddAttributes(ctx, o11y.Error, "error quote shipping", o11y.Attributes{Key: "error", Value: err})
+		return nil, err
+	}
+
+	options := make([]*entities.ShippingOption, 0, len(*response))
+	for _, option := range *response {
+		currency := vos.NewCurrency(option.Currency)
+		if currency == "" {
+			currency = request.Currency
+		}
+
+		options = append(options, &entities.ShippingOption{
+			Code:          option.Code,
+			Carrier:       option.Carrier,
+			Service:       option.Service,
+			Amount:        option.Amount,
+			Currency:      currency,
+			EstimatedDays: option.EstimatedDays,
+		})
+	}
+
+	sortOptions(options)
+	return options, nil
+}
+
+func (e *errorResponse) message() string {
+	if e == nil {
+		return ""
+	}
+	return e.Message
+}
@@ -0,0 +1,39 @@
+package shipping
+
+import (
+	"math"
+	"sort"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+)
+
+const (
+	SourceTable = "table"
+	SourceHTTP  = "http"
+
+	// volumetricDivisor converts cubic centimeters into the kilograms
+	// carriers charge for bulky, light parcels.
+	volumetricDivisor = 5000
+)
+
+// billableWeight sums, per unit, the greater of the actual and the
+// volumetric weight of every package.
+func billableWeight(packages []*entities.ShippingPackage) float64 {
+	var weight float64
+	for _, pkg := range packages {
+		volumetric := pkg.Length * pkg.Width * pkg.Height / volumetricDivisor
+		weight += math.Max(pkg.Weight, volumetric) * float64(pkg.Quantity)
+	}
+	return weight
+}
+
+// sortOptions lists the cheapest option first, then by code so equal prices
+// keep a stable order.
+func sortOptions(options []*entities.ShippingOption) {
+	sort.SliceStable(options, func(i, j int) bool {
+		if options[i].Amount != options[j].Amount {
+			return options[i].Amount < options[j].Amount
+		}
+		return options[i].Code < options[j].Code
+	})
+}
@@ -0,0 +1,134 @@
+package shipping
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	"github.com/jailtonjunior94/order/internal/order/domain/interfaces"
+	"github.com/jailtonjunior94/order/internal/order/domain/vos"
+)
+
+type (
+	tableRates struct {
+		path string
+		once sync.Once
+		rows []*tableRate
+		err  error
+	}
+
+	tableRate struct {
+		Code          string   `json:"code"`
+		Carrier       string   `json:"carrier"`
+		Service       string   `json:"service"`
+		Countries     []string `json:"countries"`
+		Currency      string   `json:"currency"`
+		MaxWeight     float64  `json:"max_weight"`
+		Base          float64  `json:"base"`
+		PerKg         float64  `json:"per_kg"`
+		FreeAbove     float64  `json:"free_above"`
+		EstimatedDays int      `json:"estimated_days"`
+	}
+)
+
+// NewTableRates quotes from a JSON array of rate rows read once on first use.
+// Rows sharing a code are weight bands of one option: the band with the
+// smallest max_weight that fits the parcel applies, and a max_weight of zero
+// has no limit. A row without countries ships anywhere. The amount is base
+// plus per_kg for every started kilogram, and nothing once the subtotal
+// reaches free_above.
+func NewTableRates(path string) interfaces.ShippingRateProvider {
+	return &tableRates{path: path}
+}
+
+func (t *tableRates) Quote(_ context.Context, request *entities.ShippingRequest) ([]*entities.ShippingOption, error) {
+	t.once.Do(t.load)
+	if t.err != nil {
+		return nil, t.err
+	}
+
+	if request.Destination == nil {
+		return nil, entities.ErrShippingAddressRequired
+	}
+
+	weight := billableWeight(request.Packages)
+	quoted := make(map[string]struct{})
+	options := make([]*entities.ShippingOption, 0)
+	for _, row := range t.rows {
+		if _, ok := quoted[row.Code]; ok || !row.matches(request, weight) {
+			continue
+		}
+		quoted[row.Code] = struct{}{}
+
+		amount := row.Base + row.PerKg*math.Ceil(weight)
+		if row.FreeAbove > 0 && request.Subtotal >= row.FreeAbove {
+			amount = 0
+		}
+
+		options = append(options, &entities.ShippingOption{
+			Code:          row.Code,
+			Carrier:       row.Carrier,
+			Service:       row.Service,
+			Amount:        math.Round(amount*100) / 100,
+			Currency:      request.Currency,
+			EstimatedDays: row.EstimatedDays,
+		})
+	}
+
+	sortOptions(options)
+	return options, nil
+}
+
+func (t *tableRates) load() {
+	content, err := os.ReadFile(t.path)
+	if err != nil {
+		t.err = err
+		return
+	}
+
+	if err := json.Unmarshal(content, &t.rows); err != nil {
+		t.err = err
+		return
+	}
+
+	for _, row := range t.rows {
+		row.Code = strings.TrimSpace(row.Code)
+		row.Currency = vos.NewCurrency(row.Currency).String()
+		for i, country := range row.Countries {
+			row.Countries[i] = strings.ToUpper(strings.TrimSpace(country))
+		}
+	}
+
+	sort.SliceStable(t.rows, func(i, j int) bool {
+		return t.rows[i].band() < t.rows[j].band()
+	})
+}
+
+func (r *tableRate) matches(request *entities.ShippingRequest, weight float64) bool {
+	if r.Currency != request.Currency.String() || (r.MaxWeight > 0 && weight > r.MaxWeight) {
+		return false
+	}
+
+	if len(r.Countries) == 0 {
+		return true
+	}
+
+	for _, country := range r.Countries {
+		if country == request.Destination.Country {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *tableRate) band() float64 {
+	if r.MaxWeight <= 0 {
+		return math.Inf(1)
+	}
+	return r.MaxWeight
+}
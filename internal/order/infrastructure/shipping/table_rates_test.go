@@ -0,0 +1,109 @@
+package shipping
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	"github.com/jailtonjunior94/order/internal/order/domain/vos"
+)
+
+const tableRatesFixture = `[
+	{"code": "standard", "carrier": "Correios", "currency": "BRL", "max_weight": 0, "base": 60, "per_kg": 5, "estimated_days": 10},
+	{"code": "standard", "carrier": "Correios", "currency": "brl", "max_weight": 1, "base": 10, "per_kg": 0, "free_above": 200, "estimated_days": 7},
+	{"code": "standard", "carrier": "Correios", "currency": "BRL", "max_weight": 5, "base": 20, "per_kg": 2, "estimated_days": 8},
+	{"code": "express", "carrier": "Loggi", "countries": [" br "], "currency": "BRL", "max_weight": 10, "base": 30, "per_kg": 3, "estimated_days": 2},
+	{"code": "international", "carrier": "DHL", "countries": ["US"], "currency": "USD", "base": 50, "estimated_days": 5}
+]`
+
+func newTableRatesFixture(t *testing.T) *tableRates {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "rates.json")
+	if err := os.WriteFile(path, []byte(tableRatesFixture), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return NewTableRates(path).(*tableRates)
+}
+
+func TestTableRatesQuote(t *testing.T) {
+	tests := []struct {
+		name     string
+		country  string
+		currency vos.Currency
+		subtotal float64
+		packages []*entities.ShippingPackage
+		expected map[string]float64
+	}{
+		{
+			name:     "lightest band",
+			country:  "BR",
+			currency: vos.CurrencyBRL,
+			subtotal: 100,
+			packages: []*entities.ShippingPackage{{Quantity: 2, Weight: 0.4}},
+			expected: map[string]float64{"standard": 10, "express": 33},
+		},
+		{
+			name:     "middle band per started kilogram",
+			country:  "BR",
+			currency: vos.CurrencyBRL,
+			subtotal: 100,
+			packages: []*entities.ShippingPackage{{Quantity: 1, Weight: 2.5}},
+			expected: map[string]float64{"standard": 26, "express": 39},
+		},
+		{
+			name:     "volumetric weight",
+			country:  "BR",
+			currency: vos.CurrencyBRL,
+			subtotal: 100,
+			packages: []*entities.ShippingPackage{{Quantity: 1, Weight: 0.1, Length: 50, Width: 40, Height: 10}},
+			expected: map[string]float64{"standard": 28, "express": 42},
+		},
+		{
+			name:     "country restricted options",
+			country:  "AR",
+			currency: vos.CurrencyBRL,
+			subtotal: 100,
+			packages: []*entities.ShippingPackage{{Quantity: 1, Weight: 0.5}},
+			expected: map[string]float64{"standard": 10},
+		},
+	}
+
+	rates := newTableRatesFixture(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options, err := rates.Quote(context.Background(), &entities.ShippingRequest{
+				Destination: &vos.Address{Country: tt.country},
+				Currency:    tt.currency,
+				Subtotal:    tt.subtotal,
+				Packages:    tt.packages,
+			})
+			if err != nil {
+				t.Fatalf("Quote() error = %v", err)
+			}
+
+			if len(options) != len(tt.expected) {
+				t.Fatalf("options = %d, want %d", len(options), len(tt.expected))
+			}
+
+			for i, option := range options {
+				if amount, ok := tt.expected[option.Code]; !ok || option.Amount != amount {
+					t.Errorf("option %s = %v, want %v", option.Code, option.Amount, tt.expected[option.Code])
+				}
+
+				if i > 0 && options[i-1].Amount > option.Amount {
+					t.Errorf("options are not sorted by amount: %v before %v", options[i-1].Amount, option.Amount)
+				}
+			}
+		})
+	}
+}
+
+func TestTableRatesQuoteRequiresADestination(t *testing.T) {
+	request := &entities.ShippingRequest{Currency: vos.CurrencyBRL}
+	if _, err := newTableRatesFixture(t).Quote(context.Background(), request); err == nil {
+		t.Error("Quote() error = nil, want an error")
+	}
+}
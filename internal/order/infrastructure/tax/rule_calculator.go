@@ -0,0 +1,183 @@
+package tax
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	"github.com/jailtonjunior94/order/internal/order/domain/interfaces"
+	"github.com/jailtonjunior94/order/pkg/cache"
+)
+
+const (
+	RoundingPerLine  = "line"
+	RoundingPerOrder = "order"
+)
+
+type (
+	Options struct {
+		Inclusive bool
+		Rounding  string
+		CacheTTL  time.Duration
+	}
+
+	ruleCalculator struct {
+		options Options
+		rules   interfaces.TaxRuleRepository
+		cache   *cache.TTLCache[string, []*entities.TaxRule]
+	}
+
+	rawTax struct {
+		item         *entities.OrderItem
+		jurisdiction string
+		rate         float64
+		amount       float64
+	}
+)
+
+// NewRuleCalculator taxes each line with the rules of the order region: per
+// jurisdiction, a rule for the item tax category wins over the catch-all rule
+// with an empty category.
+func NewRuleCalculator(rules interfaces.TaxRuleRepository, options Options) interfaces.TaxCalculator {
+	calculator := &ruleCalculator{
+		options: options,
+		rules:   rules,
+	}
+
+	if options.CacheTTL > 0 {
+		calculator.cache = cache.NewTTLCache[string, []*entities.TaxRule](options.CacheTTL)
+	}
+	return calculator
+}
+
+func (c *ruleCalculator) Calculate(ctx context.Context, order *entities.Order) (*entities.TaxAssessment, error) {
+	assessment := &entities.TaxAssessment{Inclusive: c.options.Inclusive}
+	if order.TaxRegion == "" {
+		return assessment, nil
+	}
+
+	rules, err := c.regionRules(ctx, order.TaxRegion)
+	if err != nil {
+		return nil, err
+	}
+
+	var raws []*rawTax
+	for _, item := range order.Items {
+		raws = append(raws, c.lineTaxes(item, rules)...)
+	}
+
+	if c.options.Rounding == RoundingPerOrder {
+		roundPerOrder(raws)
+	} else {
+		for _, raw := range raws {
+			raw.amount = math.Round(raw.amount*100) / 100
+		}
+	}
+
+	for _, raw := range raws {
+		assessment.Lines = append(assessment.Lines, entities.NewOrderItemTax(order.ID, raw.item.ID, raw.jurisdiction, raw.rate, raw.amount))
+	}
+	return assessment, nil
+}
+
+func (c *ruleCalculator) regionRules(ctx context.Context, region string) ([]*entities.TaxRule, error) {
+	if c.cache != nil {
+		if rules, ok := c.cache.Get(region); ok {
+			return rules, nil
+		}
+	}
+
+	rules, err := c.rules.FindByRegion(ctx, region)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.cache != nil {
+		c.cache.Set(region, rules)
+	}
+	return rules, nil
+}
+
+func (c *ruleCalculator) lineTaxes(item *entities.OrderItem, rules []*entities.TaxRule) []*rawTax {
+	selected := make(map[string]*entities.TaxRule)
+	for _, rule := range rules {
+		if rule.Category != "" && rule.Category != item.TaxCategory {
+			continue
+		}
+
+		current, ok := selected[rule.Jurisdiction]
+		if !ok || (current.Category == "" && rule.Category != "") {
+			selected[rule.Jurisdiction] = rule
+		}
+	}
+
+	jurisdictions := make([]string, 0, len(selected))
+	var combinedRate float64
+	for jurisdiction, rule := range selected {
+		jurisdictions = append(jurisdictions, jurisdiction)
+		combinedRate += rule.Rate
+	}
+	sort.Strings(jurisdictions)
+
+	base := item.Total()
+	if c.options.Inclusive {
+		base = base / (1 + combinedRate)
+	}
+
+	raws := make([]*rawTax, 0, len(jurisdictions))
+	for _, jurisdiction := range jurisdictions {
+		rule := selected[jurisdiction]
+		raws = append(raws, &rawTax{
+			item:         item,
+			jurisdiction: jurisdiction,
+			rate:         rule.Rate,
+			amount:       base * rule.Rate,
+		})
+	}
+	return raws
+}
+
+// roundPerOrder rounds each jurisdiction total once and hands out the cents
+// to the lines with the largest remainders, so the lines still add up.
+func roundPerOrder(raws []*rawTax) {
+	byJurisdiction := make(map[string][]*rawTax)
+	for _, raw := range raws {
+		byJurisdiction[raw.jurisdiction] = append(byJurisdiction[raw.jurisdiction], raw)
+	}
+
+	for _, lines := range byJurisdiction {
+		var total float64
+		for _, line := range lines {
+			total += line.amount
+		}
+		targetCents := int64(math.Round(total * 100))
+
+		remainders := make([]float64, len(lines))
+		var allocated int64
+		for i, line := range lines {
+			cents := math.Floor(line.amount * 100)
+			remainders[i] = line.amount*100 - cents
+			line.amount = cents
+			allocated += int64(cents)
+		}
+
+		order := make([]int, len(lines))
+		for i := range order {
+			order[i] = i
+		}
+		sort.SliceStable(order, func(a, b int) bool {
+			return remainders[order[a]] > remainders[order[b]]
+		})
+
+		for i := 0; allocated < targetCents && i < len(order); i++ {
+			lines[order[i]].amount++
+			allocated++
+		}
+
+		for _, line := range lines {
+			line.amount = line.amount / 100
+		}
+	}
+}
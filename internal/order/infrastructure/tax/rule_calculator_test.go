@@ -0,0 +1,156 @@
+package tax
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	sharedVos "github.com/jailtonjunior94/order/pkg/vos"
+)
+
+type (
+	fakeTaxRuleRepository struct {
+		rules []*entities.TaxRule
+		calls int
+	}
+
+	itemFixture struct {
+		price    float64
+		quantity uint
+		category string
+	}
+)
+
+func (r *fakeTaxRuleRepository) FindByRegion(_ context.Context, region string) ([]*entities.TaxRule, error) {
+	r.calls++
+	var rules []*entities.TaxRule
+	for _, rule := range r.rules {
+		if rule.Region == region {
+			rules = append(rules, rule)
+		}
+	}
+	return rules, nil
+}
+
+func newOrder(t *testing.T, region string, items ...itemFixture) *entities.Order {
+	t.Helper()
+
+	order := entities.NewOrder()
+	order.TaxRegion = region
+	for _, fixture := range items {
+		itemID, err := sharedVos.NewUUID()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		item := entities.NewOrderItem(order.ID, "SKU", "Product", fixture.price, fixture.quantity)
+		item.ID = itemID
+		item.TaxCategory = fixture.category
+		order.Items = append(order.Items, item)
+	}
+	return order
+}
+
+func TestRuleCalculatorCalculate(t *testing.T) {
+	rules := []*entities.TaxRule{
+		{Region: "SP", Jurisdiction: "BR", Rate: 0.10},
+		{Region: "SP", Jurisdiction: "SP", Rate: 0.18},
+		{Region: "SP", Jurisdiction: "SP", Category: "electronics", Rate: 0.05},
+		{Region: "RJ", Jurisdiction: "RJ", Rate: 0.25},
+		{Region: "MG", Jurisdiction: "MG", Rate: 0.05},
+	}
+
+	tests := []struct {
+		name     string
+		options  Options
+		region   string
+		items    []itemFixture
+		expected [][]float64
+	}{
+		{
+			name:     "category rule wins over the catch-all per jurisdiction",
+			region:   "SP",
+			items:    []itemFixture{{price: 100, quantity: 2, category: "electronics"}, {price: 50, quantity: 1}},
+			expected: [][]float64{{20, 10}, {5, 9}},
+		},
+		{
+			name:     "inclusive prices",
+			options:  Options{Inclusive: true},
+			region:   "RJ",
+			items:    []itemFixture{{price: 125, quantity: 1}},
+			expected: [][]float64{{25}},
+		},
+		{
+			name:     "rounded per line",
+			options:  Options{Rounding: RoundingPerLine},
+			region:   "MG",
+			items:    []itemFixture{{price: 0.10, quantity: 1}, {price: 0.10, quantity: 1}, {price: 0.10, quantity: 1}},
+			expected: [][]float64{{0.01}, {0.01}, {0.01}},
+		},
+		{
+			name:     "rounded per order",
+			options:  Options{Rounding: RoundingPerOrder},
+			region:   "MG",
+			items:    []itemFixture{{price: 0.10, quantity: 1}, {price: 0.10, quantity: 1}, {price: 0.10, quantity: 1}},
+			expected: [][]float64{{0.01}, {0.01}, {0}},
+		},
+		{
+			name:     "region without rules",
+			region:   "BA",
+			items:    []itemFixture{{price: 100, quantity: 1}},
+			expected: [][]float64{nil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := newOrder(t, tt.region, tt.items...)
+			calculator := NewRuleCalculator(&fakeTaxRuleRepository{rules: rules}, tt.options)
+
+			assessment, err := calculator.Calculate(context.Background(), order)
+			if err != nil {
+				t.Fatalf("Calculate() error = %v", err)
+			}
+
+			if assessment.Inclusive != tt.options.Inclusive {
+				t.Errorf("inclusive = %v, want %v", assessment.Inclusive, tt.options.Inclusive)
+			}
+
+			for i, item := range order.Items {
+				var amounts []float64
+				for _, line := range assessment.Lines {
+					if line.OrderItemID == item.ID {
+						amounts = append(amounts, line.Amount)
+					}
+				}
+
+				if len(amounts) != len(tt.expected[i]) {
+					t.Fatalf("item %d taxes = %v, want %v", i, amounts, tt.expected[i])
+				}
+
+				for j := range amounts {
+					if math.Abs(amounts[j]-tt.expected[i][j]) > 1e-9 {
+						t.Errorf("item %d taxes = %v, want %v", i, amounts, tt.expected[i])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestRuleCalculatorCachesRules(t *testing.T) {
+	repository := &fakeTaxRuleRepository{rules: []*entities.TaxRule{{Region: "SP", Jurisdiction: "BR", Rate: 0.1}}}
+	calculator := NewRuleCalculator(repository, Options{CacheTTL: time.Minute})
+
+	for range 2 {
+		if _, err := calculator.Calculate(context.Background(), newOrder(t, "SP", itemFixture{price: 10, quantity: 1})); err != nil {
+			t.Fatalf("Calculate() error = %v", err)
+		}
+	}
+
+	if repository.calls != 1 {
+		t.Errorf("repository calls = %d, want 1", repository.calls)
+	}
+}
@@ -1,20 +1,133 @@
 package order
 
 import (
+	"context"
 	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/jailtonjunior94/order/internal/order/domain/interfaces"
+	"github.com/jailtonjunior94/order/internal/order/infrastructure/catalog"
+	"github.com/jailtonjunior94/order/internal/order/infrastructure/fx"
+	"github.com/jailtonjunior94/order/internal/order/infrastructure/inventory"
 	"github.com/jailtonjunior94/order/internal/order/infrastructure/job"
+	"github.com/jailtonjunior94/order/internal/order/infrastructure/messaging"
 	"github.com/jailtonjunior94/order/internal/order/infrastructure/repositories"
 	"github.com/jailtonjunior94/order/internal/order/infrastructure/rest"
+	"github.com/jailtonjunior94/order/internal/order/infrastructure/shipping"
+	"github.com/jailtonjunior94/order/internal/order/infrastructure/tax"
 	"github.com/jailtonjunior94/order/internal/order/usecase"
 	"github.com/jailtonjunior94/order/pkg/bundle"
+	"github.com/jailtonjunior94/order/pkg/database/postgres"
 	unitOfWork "github.com/jailtonjunior94/order/pkg/database/uow"
+	httpclient "github.com/jailtonjunior94/order/pkg/http-client"
+	"github.com/jailtonjunior94/order/pkg/jobs"
 	"github.com/jailtonjunior94/order/pkg/messaging/kafka"
 
 	"github.com/go-chi/chi/v5"
 )
 
-func RegisterOrderModule(ioc *bundle.Container, router *chi.Mux) {
+var (
+	inventoryOnce   sync.Once
+	inventoryClient interfaces.InventoryClient
+)
+
+// RegisterInventoryClient returns nil when no mode is set, in which case the
+// order saga reserves stock asynchronously through the outbox. Every module
+// shares the same client, so reservations made by one can be released by another.
+func RegisterInventoryClient(ioc *bundle.Container) interfaces.InventoryClient {
+	inventoryOnce.Do(func() {
+		switch ioc.Config.InventoryConfig.Mode {
+		case inventory.ModeHTTP:
+			inventoryClient = inventory.NewHTTPInventory(
+				ioc.Config.InventoryConfig.URL,
+				ioc.Config.InventoryConfig.Timeout,
+				httpclient.NewHTTPClient(),
+				ioc.Observability,
+			)
+		case inventory.ModeMemory:
+			inventoryClient = inventory.NewMemoryInventory(nil)
+		}
+	})
+	return inventoryClient
+}
+
+// RegisterCatalogClient fails when CATALOG_URL is not an absolute URL, since
+// orders can no longer be priced without the catalog.
+func RegisterCatalogClient(ioc *bundle.Container) (interfaces.CatalogClient, error) {
+	if err := validateURL("CATALOG_URL", ioc.Config.CatalogConfig.URL); err != nil {
+		return nil, err
+	}
+
+	httpCatalog := catalog.NewHTTPCatalog(
+		ioc.Config.CatalogConfig.URL,
+		ioc.Config.CatalogConfig.Timeout,
+		httpclient.NewHTTPClient(),
+		ioc.Observability,
+	)
+	return catalog.NewCachedCatalog(httpCatalog, ioc.Config.CatalogConfig.CacheTTL), nil
+}
+
+func RegisterTaxCalculator(ioc *bundle.Container) interfaces.TaxCalculator {
+	return tax.NewRuleCalculator(
+		repositories.NewTaxRuleRepository(ioc.DB, ioc.Observability),
+		tax.Options{
+			Inclusive: ioc.Config.TaxConfig.PricesIncludeTax,
+			Rounding:  ioc.Config.TaxConfig.Rounding,
+			CacheTTL:  ioc.Config.TaxConfig.RulesCacheTTL,
+		},
+	)
+}
+
+// RegisterExchangeRateProvider returns nil when no source is set, in which
+// case orders report in their own currency.
+func RegisterExchangeRateProvider(ioc *bundle.Container) interfaces.ExchangeRateProvider {
+	switch ioc.Config.FXConfig.Source {
+	case fx.SourceFile:
+		return fx.NewFileRates(ioc.Config.FXConfig.RatesFile)
+	case fx.SourceDatabase:
+		return fx.NewDatabaseRates(
+			repositories.NewExchangeRateRepository(ioc.DB, ioc.Observability),
+			ioc.Config.FXConfig.CacheTTL,
+		)
+	default:
+		return nil
+	}
+}
+
+// RegisterShippingRateProvider returns nil when no source is set, in which
+// case no shipping options are quoted and orders ship for free. An unknown
+// source or one missing its file or URL fails at startup instead.
+func RegisterShippingRateProvider(ioc *bundle.Container) (interfaces.ShippingRateProvider, error) {
+	switch ioc.Config.ShippingConfig.Source {
+	case "":
+		return nil, nil
+	case shipping.SourceTable:
+		if strings.TrimSpace(ioc.Config.ShippingConfig.RatesFile) == "" {
+			return nil, errors.New("SHIPPING_RATES_FILE is required")
+		}
+		return shipping.NewTableRates(ioc.Config.ShippingConfig.RatesFile), nil
+	case shipping.SourceHTTP:
+		if err := validateURL("SHIPPING_RATES_URL", ioc.Config.ShippingConfig.URL); err != nil {
+			return nil, err
+		}
+
+		return shipping.NewHTTPRates(
+			ioc.Config.ShippingConfig.URL,
+			ioc.Config.ShippingConfig.Timeout,
+			httpclient.NewHTTPClient(),
+			ioc.Observability,
+		), nil
+	default:
+		return nil, fmt.Errorf("SHIPPING_RATES_SOURCE %q is not supported", ioc.Config.ShippingConfig.Source)
+	}
+}
+
+func RegisterOrderModule(ioc *bundle.Container, router *chi.Mux) error {
 	uow := unitOfWork.NewUnitOfWork(ioc.DB)
 	uow.Register("OrderRepository", func(tx *sql.Tx) unitOfWork.Repository {
 		return repositories.NewOrderRepository(ioc.DB, tx, ioc.Observability)
@@ -24,19 +137,92 @@ func RegisterOrderModule(ioc *bundle.Container, router *chi.Mux) {
 		return repositories.NewOutboxRepository(ioc.DB, tx, ioc.Observability)
 	})
 
-	createOrderUseCase := usecase.NewCreateOrderUseCase(uow, ioc.Observability)
+	uow.Register("OrderSagaRepository", func(tx *sql.Tx) unitOfWork.Repository {
+		return repositories.NewOrderSagaRepository(ioc.DB, tx, ioc.Observability)
+	})
+
+	uow.Register("CouponRepository", func(tx *sql.Tx) unitOfWork.Repository {
+		return repositories.NewCouponRepository(ioc.DB, tx, ioc.Observability)
+	})
+
+	uow.Register("OrderReturnRepository", func(tx *sql.Tx) unitOfWork.Repository {
+		return repositories.NewOrderReturnRepository(ioc.DB, tx, ioc.Observability)
+	})
+
+	uow.Register("ShipmentRepository", func(tx *sql.Tx) unitOfWork.Repository {
+		return repositories.NewShipmentRepository(ioc.DB, tx, ioc.Observability)
+	})
+	uow.RegisterEventFlusher(usecase.NewOutboxEventFlusher(ioc.Observability))
+
+	catalogClient, err := RegisterCatalogClient(ioc)
+	if err != nil {
+		return err
+	}
+	inventoryClient := RegisterInventoryClient(ioc)
+	taxCalculator := RegisterTaxCalculator(ioc)
+	shippingRates, err := RegisterShippingRateProvider(ioc)
+	if err != nil {
+		return err
+	}
+
+	createOrderUseCase := usecase.NewCreateOrderUseCase(
+		ioc.Config,
+		uow,
+		catalogClient,
+		inventoryClient,
+		taxCalculator,
+		RegisterExchangeRateProvider(ioc),
+		shippingRates,
+		ioc.Observability,
+	)
+	findOrderUseCase := usecase.NewFindOrderUseCase(uow, ioc.Observability)
+	listOrdersUseCase := usecase.NewListOrdersUseCase(uow, ioc.Observability)
 	markAsPaidUseCaseUseCase := usecase.NewMarkAsPaidUseCase(uow, ioc.Observability)
+	orderReturnUseCase := usecase.NewOrderReturnUseCase(uow, ioc.Observability)
+	amendOrderUseCase := usecase.NewAmendOrderUseCase(ioc.Config, uow, catalogClient, inventoryClient, taxCalculator, shippingRates, ioc.Observability)
+	orderHistoryUseCase := usecase.NewOrderHistoryUseCase(uow, ioc.Observability)
+	shipmentUseCase := usecase.NewShipmentUseCase(uow, ioc.Observability)
+	quoteShippingUseCase := usecase.NewQuoteShippingUseCase(ioc.Config, catalogClient, shippingRates, ioc.Observability)
 
 	orderHandler := rest.NewUserHandler(
 		ioc.Observability,
 		createOrderUseCase,
+		findOrderUseCase,
+		listOrdersUseCase,
 		markAsPaidUseCaseUseCase,
+		orderHistoryUseCase,
 	)
+	returnHandler := rest.NewReturnHandler(ioc.Observability, orderReturnUseCase)
+	amendOrderHandler := rest.NewAmendOrderHandler(ioc.Observability, amendOrderUseCase)
+	shipmentHandler := rest.NewShipmentHandler(ioc.Observability, shipmentUseCase)
+	shippingHandler := rest.NewShippingHandler(ioc.Observability, quoteShippingUseCase)
 
 	rest.NewOrderRoute(router,
 		rest.WithCreateOrderHandler(orderHandler.Create),
+		rest.WithFindOrderHandler(orderHandler.Find),
+		rest.WithHistoryHandler(orderHandler.History),
+		rest.WithListOrdersHandler(orderHandler.List),
 		rest.WithMarkAsPaidHandler(orderHandler.MarkAsPaid),
+		rest.WithReturnHandler(returnHandler.Request),
+		rest.WithListReturnsHandler(returnHandler.List),
+		rest.WithAddItemHandler(amendOrderHandler.AddItem),
+		rest.WithChangeItemHandler(amendOrderHandler.ChangeItemQuantity),
+		rest.WithRemoveItemHandler(amendOrderHandler.RemoveItem),
+		rest.WithShipmentsHandler(shipmentHandler.List),
+	)
+
+	rest.NewShippingRoute(router,
+		rest.WithQuoteShippingHandler(shippingHandler.Quote),
 	)
+	return nil
+}
+
+func validateURL(key, raw string) error {
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return fmt.Errorf("%s must be an absolute URL, got %q", key, raw)
+	}
+	return nil
 }
 
 func RegisterPublishEventHandler(ioc *bundle.Container) *job.PublishEventHandler {
@@ -49,3 +235,354 @@ func RegisterPublishEventHandler(ioc *bundle.Container) *job.PublishEventHandler
 	publishEventUseCase := usecase.NewPublishEventUseCase(ioc.Config, uow, brokeClient, ioc.Observability)
 	return job.NewPublishEventHandler(ioc.Observability, publishEventUseCase)
 }
+
+// RegisterOutboxListenerHandler requires PostgreSQL with the outbox_notify
+// migration applied; CockroachDB has no LISTEN/NOTIFY.
+func RegisterOutboxListenerHandler(ctx context.Context, ioc *bundle.Container, drain func(ctx context.Context) error) (*job.OutboxListenerHandler, error) {
+	if ioc.Config.DBConfig.Engine != postgres.EnginePostgres {
+		return nil, fmt.Errorf("relay mode %s requires DB_ENGINE=%s", job.RelayModeNotify, postgres.EnginePostgres)
+	}
+
+	installed, err := postgres.HasTrigger(ctx, ioc.DB, "outbox", job.NotifyTrigger)
+	if err != nil {
+		return nil, err
+	}
+
+	if !installed {
+		return nil, fmt.Errorf("trigger %s is missing, apply the postgres migrations", job.NotifyTrigger)
+	}
+
+	listener, err := postgres.NewListener(ioc.Config, job.NotifyChannel)
+	if err != nil {
+		return nil, err
+	}
+	return job.NewOutboxListenerHandler(ioc.Observability, listener, drain), nil
+}
+
+// RegisterOutboxChangefeedHandler takes the poll publisher as drain, used once
+// on cut-over to publish the rows the poll relay left behind.
+func RegisterOutboxChangefeedHandler(ioc *bundle.Container, drain func(ctx context.Context) error) *job.OutboxChangefeedHandler {
+	uow := unitOfWork.NewUnitOfWork(ioc.DB)
+	uow.Register("OutboxRepository", func(tx *sql.Tx) unitOfWork.Repository {
+		return repositories.NewOutboxRepository(ioc.DB, tx, ioc.Observability)
+	})
+
+	brokeClient := kafka.NewKafkaClient(ioc.Config.KafkaConfig.Brokers[0], ioc.Observability)
+	publishOutboxChangeUseCase := usecase.NewPublishOutboxChangeUseCase(ioc.Config, uow, brokeClient, ioc.Observability)
+	checkpointRepository := repositories.NewRelayCheckpointRepository(ioc.DB, ioc.Observability)
+	return job.NewOutboxChangefeedHandler(ioc.DB, ioc.Observability, checkpointRepository, publishOutboxChangeUseCase, drain)
+}
+
+func RegisterPurgeOutboxHandler(ioc *bundle.Container) *job.PurgeOutboxHandler {
+	uow := unitOfWork.NewUnitOfWork(ioc.DB)
+	uow.Register("OutboxRepository", func(tx *sql.Tx) unitOfWork.Repository {
+		return repositories.NewOutboxRepository(ioc.DB, tx, ioc.Observability)
+	})
+
+	purgeOutboxUseCase := usecase.NewPurgeOutboxUseCase(ioc.Config, uow, ioc.Observability)
+	return job.NewPurgeOutboxHandler(ioc.Observability, purgeOutboxUseCase, ioc.Config.OutboxConfig.Archive)
+}
+
+func RegisterOutboxAdmin(ioc *bundle.Container) usecase.OutboxAdminUseCase {
+	uow := unitOfWork.NewUnitOfWork(ioc.DB)
+	uow.Register("OutboxRepository", func(tx *sql.Tx) unitOfWork.Repository {
+		return repositories.NewOutboxRepository(ioc.DB, tx, ioc.Observability)
+	})
+
+	brokeClient := kafka.NewKafkaClient(ioc.Config.KafkaConfig.Brokers[0], ioc.Observability)
+	return usecase.NewOutboxAdminUseCase(ioc.Config, uow, brokeClient, ioc.Observability)
+}
+
+func RegisterOutboxAdminModule(ioc *bundle.Container, router chi.Router) {
+	outboxAdminHandler := rest.NewOutboxAdminHandler(ioc.Observability, RegisterOutboxAdmin(ioc))
+
+	rest.NewOutboxAdminRoute(router,
+		rest.WithListOutboxHandler(outboxAdminHandler.List),
+		rest.WithOutboxLagHandler(outboxAdminHandler.Lag),
+		rest.WithShowOutboxHandler(outboxAdminHandler.Show),
+		rest.WithRepublishOutboxHandler(outboxAdminHandler.Republish),
+		rest.WithReplayOutboxHandler(outboxAdminHandler.Replay),
+		rest.WithSkipOutboxHandler(outboxAdminHandler.Skip),
+	)
+}
+
+func RegisterCouponAdminModule(ioc *bundle.Container, router chi.Router) {
+	uow := unitOfWork.NewUnitOfWork(ioc.DB)
+	uow.Register("CouponRepository", func(tx *sql.Tx) unitOfWork.Repository {
+		return repositories.NewCouponRepository(ioc.DB, tx, ioc.Observability)
+	})
+
+	couponAdminUseCase := usecase.NewCouponAdminUseCase(uow, ioc.Observability)
+	couponAdminHandler := rest.NewCouponAdminHandler(ioc.Observability, couponAdminUseCase)
+
+	rest.NewCouponAdminRoute(router,
+		rest.WithCreateCouponHandler(couponAdminHandler.Create),
+		rest.WithShowCouponHandler(couponAdminHandler.Show),
+		rest.WithDeactivateCouponHandler(couponAdminHandler.Deactivate),
+	)
+}
+
+func RegisterReturnAdminModule(ioc *bundle.Container, router chi.Router) {
+	uow := unitOfWork.NewUnitOfWork(ioc.DB)
+	uow.Register("OrderRepository", func(tx *sql.Tx) unitOfWork.Repository {
+		return repositories.NewOrderRepository(ioc.DB, tx, ioc.Observability)
+	})
+
+	uow.Register("OrderReturnRepository", func(tx *sql.Tx) unitOfWork.Repository {
+		return repositories.NewOrderReturnRepository(ioc.DB, tx, ioc.Observability)
+	})
+
+	uow.Register("OutboxRepository", func(tx *sql.Tx) unitOfWork.Repository {
+		return repositories.NewOutboxRepository(ioc.DB, tx, ioc.Observability)
+	})
+	uow.RegisterEventFlusher(usecase.NewOutboxEventFlusher(ioc.Observability))
+
+	orderReturnUseCase := usecase.NewOrderReturnUseCase(uow, ioc.Observability)
+	returnHandler := rest.NewReturnHandler(ioc.Observability, orderReturnUseCase)
+
+	rest.NewReturnAdminRoute(router,
+		rest.WithShowReturnHandler(returnHandler.Show),
+		rest.WithApproveReturnHandler(returnHandler.Approve),
+		rest.WithRejectReturnHandler(returnHandler.Reject),
+		rest.WithRefundReturnHandler(returnHandler.Refund),
+	)
+}
+
+func RegisterShipmentUseCase(ioc *bundle.Container) usecase.ShipmentUseCase {
+	uow := unitOfWork.NewUnitOfWork(ioc.DB)
+	uow.Register("OrderRepository", func(tx *sql.Tx) unitOfWork.Repository {
+		return repositories.NewOrderRepository(ioc.DB, tx, ioc.Observability)
+	})
+
+	uow.Register("ShipmentRepository", func(tx *sql.Tx) unitOfWork.Repository {
+		return repositories.NewShipmentRepository(ioc.DB, tx, ioc.Observability)
+	})
+
+	uow.Register("OutboxRepository", func(tx *sql.Tx) unitOfWork.Repository {
+		return repositories.NewOutboxRepository(ioc.DB, tx, ioc.Observability)
+	})
+	uow.RegisterEventFlusher(usecase.NewOutboxEventFlusher(ioc.Observability))
+
+	return usecase.NewShipmentUseCase(uow, ioc.Observability)
+}
+
+func RegisterShipmentAdminModule(ioc *bundle.Container, router chi.Router) {
+	shipmentHandler := rest.NewShipmentHandler(ioc.Observability, RegisterShipmentUseCase(ioc))
+
+	rest.NewShipmentAdminRoute(router,
+		rest.WithCreateShipmentHandler(shipmentHandler.Create),
+		rest.WithShowShipmentHandler(shipmentHandler.Show),
+	)
+}
+
+func RegisterOrderAdminModule(ioc *bundle.Container, router chi.Router) {
+	uow := unitOfWork.NewUnitOfWork(ioc.DB)
+	uow.Register("OrderRepository", func(tx *sql.Tx) unitOfWork.Repository {
+		return repositories.NewOrderRepository(ioc.DB, tx, ioc.Observability)
+	})
+
+	uow.Register("OutboxRepository", func(tx *sql.Tx) unitOfWork.Repository {
+		return repositories.NewOutboxRepository(ioc.DB, tx, ioc.Observability)
+	})
+	uow.RegisterEventFlusher(usecase.NewOutboxEventFlusher(ioc.Observability))
+
+	listOrdersUseCase := usecase.NewListOrdersUseCase(uow, ioc.Observability)
+	registerPaymentUseCase := usecase.NewRegisterPaymentUseCase(uow, ioc.Observability)
+	orderAdminHandler := rest.NewOrderAdminHandler(ioc.Observability, listOrdersUseCase, registerPaymentUseCase)
+
+	rest.NewOrderAdminRoute(router,
+		rest.WithListOrdersAdminHandler(orderAdminHandler.List),
+		rest.WithPaymentHandler(orderAdminHandler.RegisterPayment),
+	)
+}
+
+func RegisterCarrierWebhookModule(ioc *bundle.Container, router chi.Router) {
+	shipmentHandler := rest.NewShipmentHandler(ioc.Observability, RegisterShipmentUseCase(ioc))
+
+	rest.NewCarrierWebhookRoute(router,
+		rest.WithCarrierUpdateHandler(shipmentHandler.CarrierUpdate),
+	)
+}
+
+func RegisterExpireOrdersHandler(ioc *bundle.Container) *job.ExpireOrdersHandler {
+	uow := unitOfWork.NewUnitOfWork(ioc.DB)
+	uow.Register("OrderRepository", func(tx *sql.Tx) unitOfWork.Repository {
+		return repositories.NewOrderRepository(ioc.DB, tx, ioc.Observability)
+	})
+
+	uow.Register("OutboxRepository", func(tx *sql.Tx) unitOfWork.Repository {
+		return repositories.NewOutboxRepository(ioc.DB, tx, ioc.Observability)
+	})
+
+	uow.Register("OrderSagaRepository", func(tx *sql.Tx) unitOfWork.Repository {
+		return repositories.NewOrderSagaRepository(ioc.DB, tx, ioc.Observability)
+	})
+
+	uow.Register("CouponRepository", func(tx *sql.Tx) unitOfWork.Repository {
+		return repositories.NewCouponRepository(ioc.DB, tx, ioc.Observability)
+	})
+	uow.RegisterEventFlusher(usecase.NewOutboxEventFlusher(ioc.Observability))
+
+	expireOrdersUseCase := usecase.NewExpireOrdersUseCase(ioc.Config, uow, RegisterInventoryClient(ioc), ioc.Observability)
+	return job.NewExpireOrdersHandler(ioc.Observability, expireOrdersUseCase)
+}
+
+func RegisterWorkerModule(ctx context.Context, ioc *bundle.Container, registry jobs.Registry) error {
+	if ioc.Config.WorkerConfig.RelayMode == job.RelayModeCDC {
+		outboxChangefeedHandler := RegisterOutboxChangefeedHandler(ioc, RegisterPublishEventHandler(ioc).Handle)
+		if err := registry.RegisterDaemon(jobs.Daemon{
+			Name:  "outbox_changefeed",
+			Scope: jobs.Singleton,
+			Run:   outboxChangefeedHandler.Run,
+		}); err != nil {
+			return err
+		}
+	} else {
+		publishEventHandler := RegisterPublishEventHandler(ioc)
+		publishEvents := jobs.Job{
+			Name:     "publish_events",
+			Schedule: ioc.Config.WorkerConfig.CronExpression,
+			Scope:    jobs.Singleton,
+			Timeout:  time.Minute,
+			Overlap:  jobs.OverlapSkip,
+			Handle:   publishEventHandler.Handle,
+		}
+
+		if err := registry.Register(publishEvents); err != nil {
+			return err
+		}
+
+		if ioc.Config.WorkerConfig.RelayMode == job.RelayModeNotify {
+			outboxListenerHandler, err := RegisterOutboxListenerHandler(ctx, ioc, registry.Guard(publishEvents))
+			if err != nil {
+				return err
+			}
+
+			if err := registry.RegisterDaemon(jobs.Daemon{
+				Name:  "outbox_listener",
+				Scope: jobs.PerReplica,
+				Run:   outboxListenerHandler.Run,
+			}); err != nil {
+				return err
+			}
+		}
+	}
+
+	if ioc.Config.WorkerConfig.ExpireCron != "" {
+		expireOrdersHandler := RegisterExpireOrdersHandler(ioc)
+		if err := registry.Register(jobs.Job{
+			Name:     "expire_orders",
+			Schedule: ioc.Config.WorkerConfig.ExpireCron,
+			Scope:    jobs.Singleton,
+			Timeout:  5 * time.Minute,
+			Overlap:  jobs.OverlapSkip,
+			Handle:   expireOrdersHandler.Handle,
+		}); err != nil {
+			return err
+		}
+	}
+
+	if ioc.Config.WorkerConfig.SagaTimeoutCron != "" {
+		timeoutOrderSagasHandler := RegisterTimeoutOrderSagasHandler(ioc)
+		if err := registry.Register(jobs.Job{
+			Name:     "timeout_order_sagas",
+			Schedule: ioc.Config.WorkerConfig.SagaTimeoutCron,
+			Scope:    jobs.Singleton,
+			Timeout:  5 * time.Minute,
+			Overlap:  jobs.OverlapSkip,
+			Handle:   timeoutOrderSagasHandler.Handle,
+		}); err != nil {
+			return err
+		}
+	}
+
+	if ioc.Config.WorkerConfig.PurgeCron != "" {
+		purgeOutboxHandler := RegisterPurgeOutboxHandler(ioc)
+		if err := registry.Register(jobs.Job{
+			Name:     "purge_outbox",
+			Schedule: ioc.Config.WorkerConfig.PurgeCron,
+			Scope:    jobs.Singleton,
+			Timeout:  10 * time.Minute,
+			Overlap:  jobs.OverlapSkip,
+			Handle:   purgeOutboxHandler.Handle,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func RegisterPaymentConsumer(ioc *bundle.Container) *messaging.PaymentHandler {
+	uow := unitOfWork.NewUnitOfWork(ioc.DB)
+	uow.Register("OrderRepository", func(tx *sql.Tx) unitOfWork.Repository {
+		return repositories.NewOrderRepository(ioc.DB, tx, ioc.Observability)
+	})
+
+	uow.Register("OutboxRepository", func(tx *sql.Tx) unitOfWork.Repository {
+		return repositories.NewOutboxRepository(ioc.DB, tx, ioc.Observability)
+	})
+
+	uow.Register("ProcessedMessageRepository", func(tx *sql.Tx) unitOfWork.Repository {
+		return repositories.NewProcessedMessageRepository(ioc.DB, tx, ioc.Observability)
+	})
+
+	uow.Register("OrderSagaRepository", func(tx *sql.Tx) unitOfWork.Repository {
+		return repositories.NewOrderSagaRepository(ioc.DB, tx, ioc.Observability)
+	})
+
+	uow.Register("CouponRepository", func(tx *sql.Tx) unitOfWork.Repository {
+		return repositories.NewCouponRepository(ioc.DB, tx, ioc.Observability)
+	})
+	uow.RegisterEventFlusher(usecase.NewOutboxEventFlusher(ioc.Observability))
+
+	orderSagaUseCase := usecase.NewOrderSagaUseCase(ioc.Config, uow, RegisterInventoryClient(ioc), ioc.Observability)
+	markAsPaidUseCase := usecase.NewMarkAsPaidUseCase(uow, ioc.Observability)
+	declinePaymentUseCase := usecase.NewDeclinePaymentUseCase(uow, ioc.Observability)
+	return messaging.NewPaymentHandler(ioc.Observability, orderSagaUseCase, markAsPaidUseCase, declinePaymentUseCase)
+}
+
+func RegisterInventoryConsumer(ioc *bundle.Container) *messaging.InventoryHandler {
+	uow := unitOfWork.NewUnitOfWork(ioc.DB)
+	uow.Register("OrderRepository", func(tx *sql.Tx) unitOfWork.Repository {
+		return repositories.NewOrderRepository(ioc.DB, tx, ioc.Observability)
+	})
+
+	uow.Register("OutboxRepository", func(tx *sql.Tx) unitOfWork.Repository {
+		return repositories.NewOutboxRepository(ioc.DB, tx, ioc.Observability)
+	})
+
+	uow.Register("OrderSagaRepository", func(tx *sql.Tx) unitOfWork.Repository {
+		return repositories.NewOrderSagaRepository(ioc.DB, tx, ioc.Observability)
+	})
+
+	uow.Register("CouponRepository", func(tx *sql.Tx) unitOfWork.Repository {
+		return repositories.NewCouponRepository(ioc.DB, tx, ioc.Observability)
+	})
+	uow.RegisterEventFlusher(usecase.NewOutboxEventFlusher(ioc.Observability))
+
+	orderSagaUseCase := usecase.NewOrderSagaUseCase(ioc.Config, uow, RegisterInventoryClient(ioc), ioc.Observability)
+	return messaging.NewInventoryHandler(ioc.Observability, orderSagaUseCase)
+}
+
+func RegisterTimeoutOrderSagasHandler(ioc *bundle.Container) *job.TimeoutOrderSagasHandler {
+	uow := unitOfWork.NewUnitOfWork(ioc.DB)
+	uow.Register("OrderRepository", func(tx *sql.Tx) unitOfWork.Repository {
+		return repositories.NewOrderRepository(ioc.DB, tx, ioc.Observability)
+	})
+
+	uow.Register("OutboxRepository", func(tx *sql.Tx) unitOfWork.Repository {
+		return repositories.NewOutboxRepository(ioc.DB, tx, ioc.Observability)
+	})
+
+	uow.Register("OrderSagaRepository", func(tx *sql.Tx) unitOfWork.Repository {
+		return repositories.NewOrderSagaRepository(ioc.DB, tx, ioc.Observability)
+	})
+
+	uow.Register("CouponRepository", func(tx *sql.Tx) unitOfWork.Repository {
+		return repositories.NewCouponRepository(ioc.DB, tx, ioc.Observability)
+	})
+	uow.RegisterEventFlusher(usecase.NewOutboxEventFlusher(ioc.Observability))
+
+	timeoutOrderSagasUseCase := usecase.NewTimeoutOrderSagasUseCase(ioc.Config, uow, RegisterInventoryClient(ioc), ioc.Observability)
+	return job.NewTimeoutOrderSagasHandler(ioc.Observability, timeoutOrderSagasUseCase)
+}
@@ -0,0 +1,275 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/jailtonjunior94/order/configs"
+	"github.com/jailtonjunior94/order/internal/order/domain/dtos"
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	"github.com/jailtonjunior94/order/internal/order/domain/factories"
+	"github.com/jailtonjunior94/order/internal/order/domain/interfaces"
+	"github.com/jailtonjunior94/order/internal/order/domain/vos"
+	"github.com/jailtonjunior94/order/pkg/database/uow"
+	"github.com/jailtonjunior94/order/pkg/o11y"
+	sharedVos "github.com/jailtonjunior94/order/pkg/vos"
+)
+
+type (
+	AmendOrderUseCase interface {
+		AddItem(ctx context.Context, orderID sharedVos.UUID, version int, input *dtos.OrderItemInput) (*dtos.OrderDetailOutput, error)
+		ChangeItemQuantity(ctx context.Context, orderID, orderItemID sharedVos.UUID, version int, input *dtos.OrderItemQuantityInput) (*dtos.OrderDetailOutput, error)
+		RemoveItem(ctx context.Context, orderID, orderItemID sharedVos.UUID, version int) (*dtos.OrderDetailOutput, error)
+	}
+
+	amendOrderUseCase struct {
+		config    *configs.Config
+		uow       uow.UnitOfWork
+		catalog   interfaces.CatalogClient
+		inventory interfaces.InventoryClient
+		taxes     interfaces.TaxCalculator
+		rates     interfaces.ShippingRateProvider
+		o11y      o11y.Observability
+	}
+)
+
+func NewAmendOrderUseCase(
+	config *configs.Config,
+	uow uow.UnitOfWork,
+	catalog interfaces.CatalogClient,
+	inventory interfaces.InventoryClient,
+	taxes interfaces.TaxCalculator,
+	rates interfaces.ShippingRateProvider,
+	o11y o11y.Observability,
+) AmendOrderUseCase {
+	return &amendOrderUseCase{
+		config:    config,
+		uow:       uow,
+		catalog:   catalog,
+		inventory: inventory,
+		taxes:     taxes,
+		rates:     rates,
+		o11y:      o11y,
+	}
+}
+
+func (u *amendOrderUseCase) AddItem(ctx context.Context, orderID sharedVos.UUID, version int, input *dtos.OrderItemInput) (*dtos.OrderDetailOutput, error) {
+	ctx, span := u.o11y.Start(ctx, "amend_order_usecase.add_item")
+	defer span.End()
+
+	if err := factories.ValidateOrderItemInput(input); err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error validate order item", o11y.Attributes{Key: "error", Value: err})
+		return nil, err
+	}
+
+	products, err := u.catalog.FindProducts(ctx, []string{input.SKU})
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error find products", o11y.Attributes{Key: "error", Value: err})
+		return nil, err
+	}
+
+	return u.amend(ctx, span, orderID, version, func(order *entities.Order) error {
+		item, err := factories.CreateOrderItem(order, input, products, defaultCurrency(u.config))
+		if err != nil {
+			return err
+		}
+		return order.AddItem(item)
+	})
+}
+
+func (u *amendOrderUseCase) ChangeItemQuantity(ctx context.Context, orderID, orderItemID sharedVos.UUID, version int, input *dtos.OrderItemQuantityInput) (*dtos.OrderDetailOutput, error) {
+	ctx, span := u.o11y.Start(ctx, "amend_order_usecase.change_item_quantity")
+	defer span.End()
+
+	if input == nil || input.Quantity == 0 {
+		span.AddAttributes(ctx, o11y.Error, "error validate order item", o11y.Attributes{Key: "error", Value: factories.ErrInvalidOrderItem})
+		return nil, factories.ErrInvalidOrderItem
+	}
+
+	return u.amend(ctx, span, orderID, version, func(order *entities.Order) error {
+		return order.ChangeItemQuantity(orderItemID, input.Quantity)
+	})
+}
+
+func (u *amendOrderUseCase) RemoveItem(ctx context.Context, orderID, orderItemID sharedVos.UUID, version int) (*dtos.OrderDetailOutput, error) {
+	ctx, span := u.o11y.Start(ctx, "amend_order_usecase.remove_item")
+	defer span.End()
+
+	return u.amend(ctx, span, orderID, version, func(order *entities.Order) error {
+		return order.RemoveItem(orderItemID)
+	})
+}
+
+// amend applies change to a pending order still at version and prices it
+// again the way it was created: the redeemed coupon first, then shipping and
+// taxes on the discounted lines. The catalog, rate, tax and inventory calls run
+// between two short transactions; the second one fails with ErrOrderConflict
+// when the order changed in the meantime.
+func (u *amendOrderUseCase) amend(
+	ctx context.Context,
+	span o11y.Span,
+	orderID sharedVos.UUID,
+	version int,
+	change func(order *entities.Order) error,
+) (*dtos.OrderDetailOutput, error) {
+	var (
+		order *entities.Order
+		saga  *entities.OrderSaga
+	)
+
+	err := u.uow.Do(ctx, func(ctx context.Context, tx uow.TX) error {
+		found, err := findOrder(ctx, tx, orderID)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error find order", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+		order = found
+
+		if err := order.CheckVersion(version); err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error check order version", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		if err := change(order); err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error amend order", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		if err := u.reapplyCoupon(ctx, tx, order); err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error reapply coupon", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		orderSagaRepository, err := GetOrderSagaRepository(tx)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error get order saga repository", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		saga, err = orderSagaRepository.FindByOrder(ctx, order.ID)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error find order saga", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+		return nil
+	})
+
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error amend order", o11y.Attributes{Key: "error", Value: err})
+		return nil, err
+	}
+
+	if err := u.price(ctx, span, order); err != nil {
+		return nil, err
+	}
+
+	reservationID, err := u.reserveStock(ctx, order, saga)
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error reserve stock", o11y.Attributes{Key: "error", Value: err})
+		return nil, err
+	}
+
+	var previousReservation string
+	err = u.uow.Do(ctx, func(ctx context.Context, tx uow.TX) error {
+		orderRepository, err := GetOrderRepository(tx)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error get order repository", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		if err := orderRepository.ReplaceItems(ctx, order); err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error replace items", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		if err := orderRepository.Update(ctx, order); err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error update order", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		orderSagaRepository, err := GetOrderSagaRepository(tx)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error get order saga repository", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		saga, err = orderSagaRepository.FindByOrder(ctx, order.ID)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error find order saga", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		if saga == nil || saga.Status.IsTerminal() || saga.Status == vos.SagaCompensating {
+			return nil
+		}
+		previousReservation = saga.ReservationID
+
+		if err := saga.Amend(order.Items, reservationID, order.Total(), order.Currency, sagaDeadline(u.config)); err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error amend order saga", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+		return orderSagaRepository.Update(ctx, saga)
+	})
+
+	if err != nil || reservationID == "" {
+		previousReservation = ""
+	}
+
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error amend order", o11y.Attributes{Key: "error", Value: err})
+		releaseStock(ctx, u.inventory, u.o11y, reservationID)
+		return nil, err
+	}
+	releaseStock(ctx, u.inventory, u.o11y, previousReservation)
+	return toOrderDetailOutput(order), nil
+}
+
+// price quotes the shipping option the order was placed with again and
+// assesses taxes for the amended lines.
+func (u *amendOrderUseCase) price(ctx context.Context, span o11y.Span, order *entities.Order) error {
+	if err := applyShipping(ctx, u.catalog, u.rates, order, nil, order.ShippingMethod); err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error apply shipping", o11y.Attributes{Key: "error", Value: err})
+		return err
+	}
+
+	if err := u.applyTaxes(ctx, order); err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error apply taxes", o11y.Attributes{Key: "error", Value: err})
+		return err
+	}
+	order.ItemsChanged()
+	return nil
+}
+
+// reserveStock reserves the amended items again when the saga holds a
+// reservation made through the inventory port; the previous one is released
+// once the amendment commits.
+func (u *amendOrderUseCase) reserveStock(ctx context.Context, order *entities.Order, saga *entities.OrderSaga) (string, error) {
+	if u.inventory == nil || saga == nil || saga.ReservationID == "" || saga.Status != vos.SagaAuthorizingPayment {
+		return "", nil
+	}
+	return u.inventory.Reserve(ctx, order.ID, order.Items)
+}
+
+func (u *amendOrderUseCase) reapplyCoupon(ctx context.Context, tx uow.TX, order *entities.Order) error {
+	couponRepository, err := GetCouponRepository(tx)
+	if err != nil {
+		return err
+	}
+
+	coupon, err := couponRepository.FindRedeemedBy(ctx, order.ID)
+	if err != nil {
+		return err
+	}
+	return factories.ReapplyCoupon(order, coupon)
+}
+
+func (u *amendOrderUseCase) applyTaxes(ctx context.Context, order *entities.Order) error {
+	if u.taxes == nil {
+		return nil
+	}
+
+	assessment, err := u.taxes.Calculate(ctx, order)
+	if err != nil {
+		return err
+	}
+	return factories.ApplyTaxes(order, assessment)
+}
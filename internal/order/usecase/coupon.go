@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	"github.com/jailtonjunior94/order/internal/order/domain/factories"
+	"github.com/jailtonjunior94/order/pkg/database/uow"
+	"github.com/jailtonjunior94/order/pkg/identity"
+)
+
+// applyCoupon prices order with the coupon identified by code while holding
+// the coupon row lock; the caller persists the redemption with redeemCoupon
+// once the order exists. Per-customer limits count the authenticated
+// customer only, never the customer_id a client sent.
+func applyCoupon(ctx context.Context, tx uow.TX, order *entities.Order, code string) (*entities.Coupon, error) {
+	code = entities.NormalizeCouponCode(code)
+	if code == "" {
+		return nil, nil
+	}
+
+	couponRepository, err := GetCouponRepository(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	coupon, err := couponRepository.FindByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	if coupon == nil {
+		return nil, ErrCouponNotFound
+	}
+
+	var customerUses int
+	customerID := identity.CustomerFromContext(ctx)
+	if customerID != "" && coupon.MaxUsesPerCustomer > 0 {
+		customerUses, err = couponRepository.CountRedemptions(ctx, coupon.ID, customerID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := factories.ApplyCoupon(order, coupon, customerID, customerUses, time.Now().UTC()); err != nil {
+		return nil, err
+	}
+	return coupon, nil
+}
+
+func redeemCoupon(ctx context.Context, tx uow.TX, coupon *entities.Coupon, order *entities.Order) error {
+	if coupon == nil {
+		return nil
+	}
+
+	couponRepository, err := GetCouponRepository(tx)
+	if err != nil {
+		return err
+	}
+
+	if err := couponRepository.InsertRedemption(ctx, coupon.ID, order.ID, identity.CustomerFromContext(ctx)); err != nil {
+		return err
+	}
+	return couponRepository.Update(ctx, coupon)
+}
+
+// returnCoupon gives back the use and the redemption of a canceled order, so
+// neither the coupon nor the customer limit stays consumed by it.
+func returnCoupon(ctx context.Context, tx uow.TX, order *entities.Order) error {
+	couponRepository, err := GetCouponRepository(tx)
+	if err != nil {
+		return err
+	}
+
+	coupon, err := couponRepository.FindRedeemedBy(ctx, order.ID)
+	if err != nil {
+		return err
+	}
+
+	if coupon == nil {
+		return nil
+	}
+
+	if err := couponRepository.DeleteRedemption(ctx, coupon.ID, order.ID); err != nil {
+		return err
+	}
+
+	coupon.Return()
+	return couponRepository.Update(ctx, coupon)
+}
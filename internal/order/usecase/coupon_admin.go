@@ -0,0 +1,142 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/dtos"
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	"github.com/jailtonjunior94/order/internal/order/domain/factories"
+	"github.com/jailtonjunior94/order/pkg/database/uow"
+	"github.com/jailtonjunior94/order/pkg/o11y"
+)
+
+type (
+	CouponAdminUseCase interface {
+		Create(ctx context.Context, input *dtos.CouponInput) (*dtos.CouponOutput, error)
+		Show(ctx context.Context, code string) (*dtos.CouponOutput, error)
+		Deactivate(ctx context.Context, code string) (*dtos.CouponOutput, error)
+	}
+
+	couponAdminUseCase struct {
+		uow  uow.UnitOfWork
+		o11y o11y.Observability
+	}
+)
+
+func NewCouponAdminUseCase(
+	uow uow.UnitOfWork,
+	o11y o11y.Observability,
+) CouponAdminUseCase {
+	return &couponAdminUseCase{
+		uow:  uow,
+		o11y: o11y,
+	}
+}
+
+func (u *couponAdminUseCase) Create(ctx context.Context, input *dtos.CouponInput) (*dtos.CouponOutput, error) {
+	ctx, span := u.o11y.Start(ctx, "coupon_admin_usecase.create")
+	defer span.End()
+
+	coupon, err := factories.CreateCoupon(input)
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error create coupon", o11y.Attributes{Key: "error", Value: err})
+		return nil, err
+	}
+
+	err = u.uow.Do(ctx, func(ctx context.Context, tx uow.TX) error {
+		couponRepository, err := GetCouponRepository(tx)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error get coupon repository", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+		return couponRepository.Insert(ctx, coupon)
+	})
+
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error insert coupon", o11y.Attributes{Key: "error", Value: err})
+		return nil, err
+	}
+	return toCouponOutput(coupon), nil
+}
+
+func (u *couponAdminUseCase) Show(ctx context.Context, code string) (*dtos.CouponOutput, error) {
+	ctx, span := u.o11y.Start(ctx, "coupon_admin_usecase.show")
+	defer span.End()
+
+	var coupon *entities.Coupon
+	err := u.uow.Do(ctx, func(ctx context.Context, tx uow.TX) error {
+		found, err := u.find(ctx, tx, code)
+		coupon = found
+		return err
+	})
+
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error find coupon", o11y.Attributes{Key: "error", Value: err})
+		return nil, err
+	}
+	return toCouponOutput(coupon), nil
+}
+
+func (u *couponAdminUseCase) Deactivate(ctx context.Context, code string) (*dtos.CouponOutput, error) {
+	ctx, span := u.o11y.Start(ctx, "coupon_admin_usecase.deactivate")
+	defer span.End()
+
+	var coupon *entities.Coupon
+	err := u.uow.Do(ctx, func(ctx context.Context, tx uow.TX) error {
+		found, err := u.find(ctx, tx, code)
+		if err != nil {
+			return err
+		}
+
+		couponRepository, err := GetCouponRepository(tx)
+		if err != nil {
+			return err
+		}
+
+		found.Deactivate()
+		coupon = found
+		return couponRepository.Update(ctx, found)
+	})
+
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error deactivate coupon", o11y.Attributes{Key: "error", Value: err})
+		return nil, err
+	}
+	return toCouponOutput(coupon), nil
+}
+
+func (u *couponAdminUseCase) find(ctx context.Context, tx uow.TX, code string) (*entities.Coupon, error) {
+	couponRepository, err := GetCouponRepository(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	coupon, err := couponRepository.FindByCode(ctx, entities.NormalizeCouponCode(code))
+	if err != nil {
+		return nil, err
+	}
+
+	if coupon == nil {
+		return nil, ErrCouponNotFound
+	}
+	return coupon, nil
+}
+
+func toCouponOutput(coupon *entities.Coupon) *dtos.CouponOutput {
+	return &dtos.CouponOutput{
+		ID:                 coupon.ID.String(),
+		Code:               coupon.Code,
+		Kind:               coupon.Kind.String(),
+		Value:              coupon.Value,
+		SKU:                coupon.SKU,
+		BuyQuantity:        coupon.BuyQuantity,
+		GetQuantity:        coupon.GetQuantity,
+		MinimumSpend:       coupon.MinimumSpend,
+		MaxUses:            coupon.MaxUses,
+		MaxUsesPerCustomer: coupon.MaxUsesPerCustomer,
+		Uses:               coupon.Uses,
+		Active:             coupon.Active,
+		StartsAt:           coupon.StartsAt,
+		EndsAt:             coupon.EndsAt.Time,
+	}
+}
@@ -3,9 +3,14 @@ package usecase
 import (
 	"context"
 
+	"github.com/jailtonjunior94/order/configs"
 	"github.com/jailtonjunior94/order/internal/order/domain/dtos"
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
 	"github.com/jailtonjunior94/order/internal/order/domain/factories"
+	"github.com/jailtonjunior94/order/internal/order/domain/interfaces"
+	"github.com/jailtonjunior94/order/internal/order/domain/vos"
 	"github.com/jailtonjunior94/order/pkg/database/uow"
+	"github.com/jailtonjunior94/order/pkg/identity"
 	"github.com/jailtonjunior94/order/pkg/o11y"
 )
 
@@ -15,18 +20,36 @@ type (
 	}
 
 	createOrderUseCase struct {
-		uow  uow.UnitOfWork
-		o11y o11y.Observability
+		config    *configs.Config
+		uow       uow.UnitOfWork
+		catalog   interfaces.CatalogClient
+		inventory interfaces.InventoryClient
+		taxes     interfaces.TaxCalculator
+		fx        interfaces.ExchangeRateProvider
+		rates     interfaces.ShippingRateProvider
+		o11y      o11y.Observability
 	}
 )
 
 func NewCreateOrderUseCase(
+	config *configs.Config,
 	uow uow.UnitOfWork,
+	catalog interfaces.CatalogClient,
+	inventory interfaces.InventoryClient,
+	taxes interfaces.TaxCalculator,
+	fx interfaces.ExchangeRateProvider,
+	rates interfaces.ShippingRateProvider,
 	o11y o11y.Observability,
 ) CreateOrderUseCase {
 	return &createOrderUseCase{
-		uow:  uow,
-		o11y: o11y,
+		uow:       uow,
+		o11y:      o11y,
+		config:    config,
+		catalog:   catalog,
+		inventory: inventory,
+		taxes:     taxes,
+		fx:        fx,
+		rates:     rates,
 	}
 }
 
@@ -34,12 +57,45 @@ func (c *createOrderUseCase) Execute(ctx context.Context, input *dtos.OrderInput
 	ctx, span := c.o11y.Start(ctx, "create_order_usecase.execute")
 	defer span.End()
 
-	newOrder, err := factories.CreateOrder(input)
+	if err := factories.ValidateOrderInput(input); err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error validate order", o11y.Attributes{Key: "error", Value: err})
+		return nil, err
+	}
+
+	products, err := c.catalog.FindProducts(ctx, factories.SKUs(input))
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error find products", o11y.Attributes{Key: "error", Value: err})
+		return nil, err
+	}
+
+	newOrder, err := factories.CreateOrder(input, products, defaultCurrency(c.config))
 	if err != nil {
 		span.AddAttributes(ctx, o11y.Error, "error create order", o11y.Attributes{Key: "error", Value: err})
 		return nil, err
 	}
 
+	newOrder.CustomerID = identity.CustomerFromContext(ctx)
+
+	if newOrder.TaxRegion == "" {
+		newOrder.TaxRegion = c.config.TaxConfig.DefaultRegion
+	}
+
+	if err := c.snapshotExchangeRate(ctx, newOrder); err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error snapshot exchange rate", o11y.Attributes{Key: "error", Value: err})
+		return nil, err
+	}
+
+	if err := applyShipping(ctx, c.catalog, c.rates, newOrder, products, input.ShippingOption); err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error apply shipping", o11y.Attributes{Key: "error", Value: err})
+		return nil, err
+	}
+
+	reservationID, err := c.reserveStock(ctx, newOrder)
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error reserve stock", o11y.Attributes{Key: "error", Value: err})
+		return nil, err
+	}
+
 	err = c.uow.Do(ctx, func(ctx context.Context, tx uow.TX) error {
 		orderRepository, err := GetOrderRepository(tx)
 		if err != nil {
@@ -47,6 +103,17 @@ func (c *createOrderUseCase) Execute(ctx context.Context, input *dtos.OrderInput
 			return err
 		}
 
+		coupon, err := applyCoupon(ctx, tx, newOrder, input.CouponCode)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error apply coupon", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		if err := c.applyTaxes(ctx, newOrder); err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error apply taxes", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
 		if err := orderRepository.Insert(ctx, newOrder); err != nil {
 			span.AddAttributes(ctx, o11y.Error, "error insert order", o11y.Attributes{Key: "error", Value: err})
 			return err
@@ -56,12 +123,94 @@ func (c *createOrderUseCase) Execute(ctx context.Context, input *dtos.OrderInput
 			span.AddAttributes(ctx, o11y.Error, "error insert items", o11y.Attributes{Key: "error", Value: err})
 			return err
 		}
+
+		if err := orderRepository.InsertAddresses(ctx, newOrder); err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error insert addresses", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		if err := orderRepository.InsertDiscounts(ctx, newOrder.Discounts); err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error insert discounts", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		if err := orderRepository.InsertTaxes(ctx, newOrder.TaxLines()); err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error insert taxes", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		if err := redeemCoupon(ctx, tx, coupon, newOrder); err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error redeem coupon", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		if err := startOrderSaga(ctx, tx, c.config, newOrder, reservationID); err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error start order saga", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
 		return nil
 	})
 
 	if err != nil {
 		span.AddAttributes(ctx, o11y.Error, "error create order", o11y.Attributes{Key: "error", Value: err})
+		releaseStock(ctx, c.inventory, c.o11y, reservationID)
 		return nil, err
 	}
-	return dtos.NewOrderOutput(newOrder.ID.String(), newOrder.Status.String()), nil
+	output := dtos.NewOrderOutput(newOrder.ID.String(), newOrder.Status.String(), newOrder.Version)
+	return output.WithTotals(newOrder.Currency.String(), newOrder.Subtotal(), newOrder.DiscountTotal(), newOrder.TaxTotal(), newOrder.ShippingAmount, newOrder.Total()), nil
+}
+
+// snapshotExchangeRate settles the order currency, defaulting to the
+// configured one, and freezes its rate into the reporting currency.
+func (c *createOrderUseCase) snapshotExchangeRate(ctx context.Context, order *entities.Order) error {
+	if order.Currency == "" {
+		order.Currency = defaultCurrency(c.config)
+	}
+
+	if !order.Currency.IsValid() {
+		return factories.ErrInvalidCurrency
+	}
+
+	reporting := vos.NewCurrency(c.config.FXConfig.ReportingCurrency)
+	if reporting == "" || c.fx == nil {
+		order.SnapshotExchangeRate(entities.NewIdentityRate(order.Currency, order.CreatedAt))
+		return nil
+	}
+
+	rate, err := c.fx.Rate(ctx, order.Currency, reporting)
+	if err != nil {
+		return err
+	}
+	order.SnapshotExchangeRate(rate)
+	return nil
+}
+
+// applyTaxes runs after the coupon so tax is assessed on the discounted lines.
+func (c *createOrderUseCase) applyTaxes(ctx context.Context, order *entities.Order) error {
+	if c.taxes == nil {
+		return nil
+	}
+
+	assessment, err := c.taxes.Calculate(ctx, order)
+	if err != nil {
+		return err
+	}
+	return factories.ApplyTaxes(order, assessment)
+}
+
+// reserveStock returns an empty reservation when no inventory client is
+// configured, leaving the reservation to the order saga.
+func (c *createOrderUseCase) reserveStock(ctx context.Context, order *entities.Order) (string, error) {
+	if c.inventory == nil {
+		return "", nil
+	}
+	return c.inventory.Reserve(ctx, order.ID, order.Items)
+}
+
+// defaultCurrency prices catalog products that report no currency.
+func defaultCurrency(config *configs.Config) vos.Currency {
+	if currency := vos.NewCurrency(config.OrderConfig.DefaultCurrency); currency != "" {
+		return currency
+	}
+	return vos.CurrencyBRL
 }
@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/dtos"
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	"github.com/jailtonjunior94/order/internal/order/domain/vos"
+	"github.com/jailtonjunior94/order/pkg/database/uow"
+	"github.com/jailtonjunior94/order/pkg/o11y"
+	sharedVos "github.com/jailtonjunior94/order/pkg/vos"
+)
+
+type (
+	DeclinePaymentUseCase interface {
+		Execute(ctx context.Context, orderID sharedVos.UUID, paymentID, reason string) (*dtos.OrderOutput, error)
+	}
+
+	declinePaymentUseCase struct {
+		uow  uow.UnitOfWork
+		o11y o11y.Observability
+	}
+)
+
+func NewDeclinePaymentUseCase(
+	uow uow.UnitOfWork,
+	o11y o11y.Observability,
+) DeclinePaymentUseCase {
+	return &declinePaymentUseCase{
+		uow:  uow,
+		o11y: o11y,
+	}
+}
+
+func (u *declinePaymentUseCase) Execute(ctx context.Context, orderID sharedVos.UUID, paymentID, reason string) (*dtos.OrderOutput, error) {
+	ctx, span := u.o11y.Start(ctx, "decline_payment_usecase.execute")
+	defer span.End()
+
+	var orderUpdated *entities.Order
+	err := u.uow.Do(ctx, func(ctx context.Context, tx uow.TX) error {
+		orderRepository, err := GetOrderRepository(tx)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error get order repository", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		order, err := orderRepository.Find(ctx, orderID)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error find order", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		if order == nil {
+			span.AddAttributes(ctx, o11y.Error, "error order not found", o11y.Attributes{Key: "order_id", Value: orderID.String()})
+			return ErrOrderNotFound
+		}
+		orderUpdated = order
+
+		registered, err := registerProcessedMessage(ctx, tx, PaymentConsumer, paymentMessageID(paymentID, vos.PaymentDeclined))
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error register payment", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		if !registered {
+			return nil
+		}
+
+		if err := order.DeclinePayment(paymentID, reason); err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error decline payment", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		if err := orderRepository.Update(ctx, order); err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error update order", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+		return nil
+	})
+
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error decline payment", o11y.Attributes{Key: "error", Value: err})
+		return nil, err
+	}
+	return dtos.NewOrderOutput(orderUpdated.ID.String(), orderUpdated.Status.String(), orderUpdated.Version), nil
+}
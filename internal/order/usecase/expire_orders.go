@@ -0,0 +1,138 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/jailtonjunior94/order/configs"
+	"github.com/jailtonjunior94/order/internal/order/domain/interfaces"
+	"github.com/jailtonjunior94/order/internal/order/domain/vos"
+	"github.com/jailtonjunior94/order/pkg/database/uow"
+	"github.com/jailtonjunior94/order/pkg/o11y"
+)
+
+const (
+	ExpiredOrderReason         = "payment_timeout"
+	defaultExpirationBatchSize = 100
+)
+
+type (
+	ExpireOrdersUseCase interface {
+		Execute(ctx context.Context) (int64, error)
+	}
+
+	expireOrdersUseCase struct {
+		config    *configs.Config
+		uow       uow.UnitOfWork
+		inventory interfaces.InventoryClient
+		o11y      o11y.Observability
+	}
+)
+
+func NewExpireOrdersUseCase(
+	config *configs.Config,
+	uow uow.UnitOfWork,
+	inventory interfaces.InventoryClient,
+	o11y o11y.Observability,
+) ExpireOrdersUseCase {
+	return &expireOrdersUseCase{
+		uow:       uow,
+		o11y:      o11y,
+		config:    config,
+		inventory: inventory,
+	}
+}
+
+func (u *expireOrdersUseCase) Execute(ctx context.Context) (int64, error) {
+	ctx, span := u.o11y.Start(ctx, "expire_orders_usecase.execute")
+	defer span.End()
+
+	if u.config.OrderConfig.PendingTTL <= 0 {
+		return 0, nil
+	}
+
+	batchSize := u.config.OrderConfig.ExpirationBatchSize
+	if batchSize <= 0 {
+		batchSize = defaultExpirationBatchSize
+	}
+	before := time.Now().UTC().Add(-u.config.OrderConfig.PendingTTL)
+
+	var expired int64
+	for {
+		var (
+			canceled       int
+			reservationIDs []string
+		)
+		err := u.uow.Do(ctx, func(ctx context.Context, tx uow.TX) error {
+			orderRepository, err := GetOrderRepository(tx)
+			if err != nil {
+				span.AddAttributes(ctx, o11y.Error, "error get order repository", o11y.Attributes{Key: "error", Value: err})
+				return err
+			}
+
+			orderSagaRepository, err := GetOrderSagaRepository(tx)
+			if err != nil {
+				span.AddAttributes(ctx, o11y.Error, "error get order saga repository", o11y.Attributes{Key: "error", Value: err})
+				return err
+			}
+
+			orders, err := orderRepository.FindStale(ctx, vos.StatusPending, before, batchSize)
+			if err != nil {
+				span.AddAttributes(ctx, o11y.Error, "error find stale orders", o11y.Attributes{Key: "error", Value: err})
+				return err
+			}
+
+			for _, order := range orders {
+				if err := order.Cancel(ExpiredOrderReason); err != nil {
+					span.AddAttributes(ctx, o11y.Error, "error cancel order", o11y.Attributes{Key: "error", Value: err})
+					return err
+				}
+
+				if err := orderRepository.Update(ctx, order); err != nil {
+					span.AddAttributes(ctx, o11y.Error, "error update order", o11y.Attributes{Key: "error", Value: err})
+					return err
+				}
+
+				if err := returnCoupon(ctx, tx, order); err != nil {
+					span.AddAttributes(ctx, o11y.Error, "error return coupon", o11y.Attributes{Key: "error", Value: err})
+					return err
+				}
+
+				saga, err := orderSagaRepository.FindByOrder(ctx, order.ID)
+				if err != nil {
+					span.AddAttributes(ctx, o11y.Error, "error find order saga", o11y.Attributes{Key: "error", Value: err})
+					return err
+				}
+
+				if saga == nil || saga.Status.IsTerminal() || saga.Status == vos.SagaCompensating {
+					continue
+				}
+
+				if err := compensateSaga(u.inventory, saga, ExpiredOrderReason, sagaDeadline(u.config)); err != nil {
+					span.AddAttributes(ctx, o11y.Error, "error compensate order saga", o11y.Attributes{Key: "error", Value: err})
+					return err
+				}
+
+				if err := orderSagaRepository.Update(ctx, saga); err != nil {
+					span.AddAttributes(ctx, o11y.Error, "error update order saga", o11y.Attributes{Key: "error", Value: err})
+					return err
+				}
+				reservationIDs = append(reservationIDs, pendingRelease(u.inventory, saga))
+			}
+
+			canceled = len(orders)
+			return nil
+		})
+
+		if err != nil {
+			return expired, err
+		}
+		releaseStock(ctx, u.inventory, u.o11y, reservationIDs...)
+
+		expired += int64(canceled)
+		if canceled < batchSize {
+			span.AddAttributes(ctx, o11y.Ok, "", o11y.Attributes{Key: "expired", Value: expired})
+			return expired, nil
+		}
+	}
+}
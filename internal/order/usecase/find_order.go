@@ -0,0 +1,148 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/dtos"
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	"github.com/jailtonjunior94/order/internal/order/domain/vos"
+	"github.com/jailtonjunior94/order/pkg/database/uow"
+	"github.com/jailtonjunior94/order/pkg/o11y"
+	sharedVos "github.com/jailtonjunior94/order/pkg/vos"
+)
+
+type (
+	FindOrderUseCase interface {
+		Execute(ctx context.Context, orderID sharedVos.UUID) (*dtos.OrderDetailOutput, error)
+	}
+
+	findOrderUseCase struct {
+		uow  uow.UnitOfWork
+		o11y o11y.Observability
+	}
+)
+
+func NewFindOrderUseCase(
+	uow uow.UnitOfWork,
+	o11y o11y.Observability,
+) FindOrderUseCase {
+	return &findOrderUseCase{
+		uow:  uow,
+		o11y: o11y,
+	}
+}
+
+func (u *findOrderUseCase) Execute(ctx context.Context, orderID sharedVos.UUID) (*dtos.OrderDetailOutput, error) {
+	ctx, span := u.o11y.Start(ctx, "find_order_usecase.execute")
+	defer span.End()
+
+	var order *entities.Order
+	err := u.uow.Do(ctx, func(ctx context.Context, tx uow.TX) error {
+		orderRepository, err := GetOrderRepository(tx)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error get order repository", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		found, err := orderRepository.Find(ctx, orderID)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error find order", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		if found == nil {
+			return ErrOrderNotFound
+		}
+		order = found
+		return nil
+	})
+
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error find order", o11y.Attributes{Key: "error", Value: err})
+		return nil, err
+	}
+	return toOrderDetailOutput(order), nil
+}
+
+func toOrderDetailOutput(order *entities.Order) *dtos.OrderDetailOutput {
+	output := &dtos.OrderDetailOutput{
+		ID:              order.ID.String(),
+		CustomerID:      order.CustomerID,
+		Status:          order.Status.String(),
+		Currency:        order.Currency.String(),
+		ShippingAddress: toAddressOutput(order.ShippingAddress),
+		BillingAddress:  toAddressOutput(order.BillingAddress),
+		TaxRegion:       order.TaxRegion,
+		TaxInclusive:    order.TaxInclusive,
+		Subtotal:        order.Subtotal(),
+		Discount:        order.DiscountTotal(),
+		Tax:             order.TaxTotal(),
+		ShippingMethod:  order.ShippingMethod,
+		ShippingCarrier: order.ShippingCarrier,
+		Shipping:        order.ShippingAmount,
+		Total:           order.Total(),
+		Reporting:       toReportingOutput(order),
+		PaidAmount:      order.PaidAmount(),
+		Balance:         order.Balance(),
+		Items:           make([]*dtos.OrderItemOutput, 0, len(order.Items)),
+		Version:         order.Version,
+		CreatedAt:       order.CreatedAt,
+	}
+
+	for _, item := range order.Items {
+		itemOutput := &dtos.OrderItemOutput{
+			ID:          item.ID.String(),
+			SKU:         item.SKU,
+			ProductName: item.ProductName,
+			Price:       item.Price,
+			Quantity:    item.Quantity,
+			Discount:    item.Discount,
+			TaxCategory: item.TaxCategory,
+			Tax:         item.Tax,
+		}
+
+		for _, tax := range item.Taxes {
+			itemOutput.Taxes = append(itemOutput.Taxes, &dtos.OrderItemTaxOutput{
+				Jurisdiction: tax.Jurisdiction,
+				Rate:         tax.Rate,
+				Amount:       tax.Amount,
+			})
+		}
+		output.Items = append(output.Items, itemOutput)
+	}
+
+	for _, payment := range order.Payments {
+		output.Payments = append(output.Payments, toPaymentOutput(payment))
+	}
+	return output
+}
+
+func toReportingOutput(order *entities.Order) *dtos.ReportingOutput {
+	if order.ReportingCurrency == "" {
+		return nil
+	}
+
+	return &dtos.ReportingOutput{
+		Currency:       order.ReportingCurrency.String(),
+		ExchangeRate:   order.ExchangeRate,
+		ExchangeRateAt: order.ExchangeRateAt,
+		Total:          order.ReportingTotal(),
+	}
+}
+
+func toAddressOutput(address *vos.Address) *dtos.Address {
+	if address == nil {
+		return nil
+	}
+
+	return &dtos.Address{
+		Street:     address.Street,
+		Number:     address.Number,
+		Complement: address.Complement,
+		District:   address.District,
+		City:       address.City,
+		State:      address.State,
+		PostalCode: address.PostalCode,
+		Country:    address.Country,
+	}
+}
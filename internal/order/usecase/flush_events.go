@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	"github.com/jailtonjunior94/order/pkg/database/uow"
+	"github.com/jailtonjunior94/order/pkg/events"
+	"github.com/jailtonjunior94/order/pkg/o11y"
+	"github.com/jailtonjunior94/order/pkg/vos"
+)
+
+func NewOutboxEventFlusher(observability o11y.Observability) uow.EventFlusher {
+	return func(ctx context.Context, tx uow.TX, domainEvents []events.Event) error {
+		ctx, span := observability.Start(ctx, "outbox_event_flusher.flush")
+		defer span.End()
+
+		outboxRepository, err := GetOutboxRepository(tx)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error get outbox repository", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		for _, event := range domainEvents {
+			aggregateID, err := vos.NewUUIDFromString(string(event.GetKey()))
+			if err != nil {
+				span.AddAttributes(ctx, o11y.Error, "error parse aggregate id", o11y.Attributes{Key: "error", Value: err})
+				return err
+			}
+
+			outbox, err := entities.NewOutbox(aggregateID, event.GetEventType(), event.GetPayload())
+			if err != nil {
+				span.AddAttributes(ctx, o11y.Error, "error create outbox", o11y.Attributes{Key: "error", Value: err})
+				return err
+			}
+
+			if err := outboxRepository.Insert(ctx, outbox); err != nil {
+				span.AddAttributes(ctx, o11y.Error, "error insert outbox", o11y.Attributes{Key: "error", Value: err})
+				return err
+			}
+		}
+		return nil
+	}
+}
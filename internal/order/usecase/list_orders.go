@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/dtos"
+	"github.com/jailtonjunior94/order/internal/order/domain/interfaces"
+	"github.com/jailtonjunior94/order/internal/order/domain/vos"
+	"github.com/jailtonjunior94/order/pkg/database/uow"
+	"github.com/jailtonjunior94/order/pkg/o11y"
+)
+
+const (
+	defaultListLimit = 50
+	MaxListLimit     = 100
+)
+
+var ErrInvalidOrderStatus = errors.New("invalid order status")
+
+type (
+	ListOrdersUseCase interface {
+		Execute(ctx context.Context, input *dtos.OrderFilterInput) ([]*dtos.OrderDetailOutput, error)
+	}
+
+	listOrdersUseCase struct {
+		uow  uow.UnitOfWork
+		o11y o11y.Observability
+	}
+)
+
+func NewListOrdersUseCase(
+	uow uow.UnitOfWork,
+	o11y o11y.Observability,
+) ListOrdersUseCase {
+	return &listOrdersUseCase{
+		uow:  uow,
+		o11y: o11y,
+	}
+}
+
+func (u *listOrdersUseCase) Execute(ctx context.Context, input *dtos.OrderFilterInput) ([]*dtos.OrderDetailOutput, error) {
+	ctx, span := u.o11y.Start(ctx, "list_orders_usecase.execute")
+	defer span.End()
+
+	filter := &interfaces.OrderFilter{
+		CustomerID: strings.TrimSpace(input.CustomerID),
+		Status:     vos.Status(strings.ToUpper(strings.TrimSpace(input.Status))),
+		Limit:      input.Limit,
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultListLimit
+	}
+	filter.Limit = min(filter.Limit, MaxListLimit)
+
+	if filter.Status != "" && !filter.Status.IsValid() {
+		span.AddAttributes(ctx, o11y.Error, "error invalid filter", o11y.Attributes{Key: "error", Value: ErrInvalidOrderStatus})
+		return nil, ErrInvalidOrderStatus
+	}
+
+	output := make([]*dtos.OrderDetailOutput, 0)
+	err := u.uow.Do(ctx, func(ctx context.Context, tx uow.TX) error {
+		orderRepository, err := GetOrderRepository(tx)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error get order repository", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		orders, err := orderRepository.List(ctx, filter)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error list orders", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		for _, order := range orders {
+			output = append(output, toOrderDetailOutput(order))
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
@@ -5,20 +5,18 @@ import (
 
 	"github.com/jailtonjunior94/order/internal/order/domain/dtos"
 	"github.com/jailtonjunior94/order/internal/order/domain/entities"
-	"github.com/jailtonjunior94/order/internal/order/domain/events"
+	"github.com/jailtonjunior94/order/internal/order/domain/vos"
 	"github.com/jailtonjunior94/order/pkg/database/uow"
 	"github.com/jailtonjunior94/order/pkg/o11y"
-	"github.com/jailtonjunior94/order/pkg/vos"
+	sharedVos "github.com/jailtonjunior94/order/pkg/vos"
 )
 
-const (
-	OrderPaidEvent   = "order_paid"
-	OutboxRepository = "OutboxRepository"
-)
+const PaymentConsumer = "payment_events"
 
 type (
 	MarkAsPaidUseCase interface {
-		Execute(ctx context.Context, orderID vos.UUID) (*dtos.OrderOutput, error)
+		Execute(ctx context.Context, orderID sharedVos.UUID, version int) (*dtos.OrderOutput, error)
+		ExecuteForPayment(ctx context.Context, orderID sharedVos.UUID, paymentID string, amount float64) (*dtos.OrderOutput, error)
 	}
 
 	markAsPaidUseCase struct {
@@ -37,8 +35,20 @@ func NewMarkAsPaidUseCase(
 	}
 }
 
-func (u *markAsPaidUseCase) Execute(ctx context.Context, orderID vos.UUID) (*dtos.OrderOutput, error) {
-	ctx, span := u.o11y.Start(ctx, "create_order_usecase.execute")
+// Execute settles the balance of the order with an external payment when it is
+// still at version; zero skips the check.
+func (u *markAsPaidUseCase) Execute(ctx context.Context, orderID sharedVos.UUID, version int) (*dtos.OrderOutput, error) {
+	return u.execute(ctx, orderID, version, "", 0)
+}
+
+// ExecuteForPayment marks the order as paid at most once per approved payment;
+// a redelivered approval returns the current order without changing it.
+func (u *markAsPaidUseCase) ExecuteForPayment(ctx context.Context, orderID sharedVos.UUID, paymentID string, amount float64) (*dtos.OrderOutput, error) {
+	return u.execute(ctx, orderID, 0, paymentID, amount)
+}
+
+func (u *markAsPaidUseCase) execute(ctx context.Context, orderID sharedVos.UUID, version int, paymentID string, amount float64) (*dtos.OrderOutput, error) {
+	ctx, span := u.o11y.Start(ctx, "mark_as_paid_usecase.execute")
 	defer span.End()
 
 	var orderUpdated *entities.Order
@@ -49,12 +59,6 @@ func (u *markAsPaidUseCase) Execute(ctx context.Context, orderID vos.UUID) (*dto
 			return err
 		}
 
-		outboxRepository, err := GetOutboxRepository(tx)
-		if err != nil {
-			span.AddAttributes(ctx, o11y.Error, "error get outbox repository", o11y.Attributes{Key: "error", Value: err})
-			return err
-		}
-
 		order, err := orderRepository.Find(ctx, orderID)
 		if err != nil {
 			span.AddAttributes(ctx, o11y.Error, "error find order", o11y.Attributes{Key: "error", Value: err})
@@ -62,27 +66,37 @@ func (u *markAsPaidUseCase) Execute(ctx context.Context, orderID vos.UUID) (*dto
 		}
 
 		if order == nil {
-			span.AddAttributes(ctx, o11y.Error, "error order not found", o11y.Attributes{Key: "error", Value: err})
-			return err
+			span.AddAttributes(ctx, o11y.Error, "error order not found", o11y.Attributes{Key: "order_id", Value: orderID.String()})
+			return ErrOrderNotFound
 		}
+		orderUpdated = order
 
-		orderUpdated = order.MarkAsPaid()
-		if err := orderRepository.Update(ctx, order); err != nil {
-			span.AddAttributes(ctx, o11y.Error, "error update order", o11y.Attributes{Key: "error", Value: err})
+		if err := order.CheckVersion(version); err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error check order version", o11y.Attributes{Key: "error", Value: err})
 			return err
 		}
 
-		outbox, err := entities.NewOutbox(order.ID, OrderPaidEvent, events.NewOrderPaid(order.ID.String(), order.Total()))
-		if err != nil {
-			span.AddAttributes(ctx, o11y.Error, "error create outbox", o11y.Attributes{Key: "error", Value: err})
-			return err
+		if paymentID != "" {
+			registered, err := registerProcessedMessage(ctx, tx, PaymentConsumer, paymentMessageID(paymentID, vos.PaymentApproved))
+			if err != nil {
+				span.AddAttributes(ctx, o11y.Error, "error register payment", o11y.Attributes{Key: "error", Value: err})
+				return err
+			}
+
+			if !registered {
+				return nil
+			}
 		}
 
-		if err := outboxRepository.Insert(ctx, outbox); err != nil {
-			span.AddAttributes(ctx, o11y.Error, "error insert outbox", o11y.Attributes{Key: "error", Value: err})
+		if err := capturePayment(ctx, tx, order, amount, paymentID); err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error capture payment", o11y.Attributes{Key: "error", Value: err})
 			return err
 		}
 
+		if err := orderRepository.Update(ctx, order); err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error update order", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
 		return nil
 	})
 
@@ -90,5 +104,5 @@ func (u *markAsPaidUseCase) Execute(ctx context.Context, orderID vos.UUID) (*dto
 		span.AddAttributes(ctx, o11y.Error, "error mark as paid order", o11y.Attributes{Key: "error", Value: err})
 		return nil, err
 	}
-	return dtos.NewOrderOutput(orderUpdated.ID.String(), orderUpdated.Status.String()), nil
+	return dtos.NewOrderOutput(orderUpdated.ID.String(), orderUpdated.Status.String(), orderUpdated.Version), nil
 }
@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/dtos"
+	"github.com/jailtonjunior94/order/pkg/database/uow"
+	"github.com/jailtonjunior94/order/pkg/o11y"
+	sharedVos "github.com/jailtonjunior94/order/pkg/vos"
+)
+
+type (
+	OrderHistoryUseCase interface {
+		Execute(ctx context.Context, orderID sharedVos.UUID) ([]*dtos.OrderStatusChangeOutput, error)
+	}
+
+	orderHistoryUseCase struct {
+		uow  uow.UnitOfWork
+		o11y o11y.Observability
+	}
+)
+
+func NewOrderHistoryUseCase(
+	uow uow.UnitOfWork,
+	o11y o11y.Observability,
+) OrderHistoryUseCase {
+	return &orderHistoryUseCase{
+		uow:  uow,
+		o11y: o11y,
+	}
+}
+
+func (u *orderHistoryUseCase) Execute(ctx context.Context, orderID sharedVos.UUID) ([]*dtos.OrderStatusChangeOutput, error) {
+	ctx, span := u.o11y.Start(ctx, "order_history_usecase.execute")
+	defer span.End()
+
+	output := make([]*dtos.OrderStatusChangeOutput, 0)
+	err := u.uow.Do(ctx, func(ctx context.Context, tx uow.TX) error {
+		orderRepository, err := GetOrderRepository(tx)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error get order repository", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		order, err := orderRepository.Find(ctx, orderID)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error find order", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		if order == nil {
+			return ErrOrderNotFound
+		}
+
+		history, err := orderRepository.FindStatusHistory(ctx, orderID)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error find status history", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		for _, change := range history {
+			output = append(output, &dtos.OrderStatusChangeOutput{
+				From:      change.From.String(),
+				To:        change.To.String(),
+				Actor:     change.Actor,
+				Reason:    change.Reason,
+				RequestID: change.RequestID,
+				CreatedAt: change.CreatedAt,
+			})
+		}
+		return nil
+	})
+
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error find order history", o11y.Attributes{Key: "error", Value: err})
+		return nil, err
+	}
+	return output, nil
+}
@@ -0,0 +1,314 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/dtos"
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	"github.com/jailtonjunior94/order/internal/order/domain/factories"
+	"github.com/jailtonjunior94/order/internal/order/domain/interfaces"
+	"github.com/jailtonjunior94/order/pkg/database/uow"
+	"github.com/jailtonjunior94/order/pkg/identity"
+	"github.com/jailtonjunior94/order/pkg/o11y"
+	sharedVos "github.com/jailtonjunior94/order/pkg/vos"
+)
+
+type (
+	OrderReturnUseCase interface {
+		Request(ctx context.Context, orderID sharedVos.UUID, input *dtos.ReturnInput) (*dtos.ReturnOutput, error)
+		List(ctx context.Context, orderID sharedVos.UUID) ([]*dtos.ReturnOutput, error)
+		Show(ctx context.Context, returnID sharedVos.UUID) (*dtos.ReturnOutput, error)
+		Approve(ctx context.Context, returnID sharedVos.UUID) (*dtos.ReturnOutput, error)
+		Reject(ctx context.Context, returnID sharedVos.UUID, input *dtos.RejectReturnInput) (*dtos.ReturnOutput, error)
+		Refund(ctx context.Context, returnID sharedVos.UUID) (*dtos.ReturnOutput, error)
+	}
+
+	orderReturnUseCase struct {
+		uow  uow.UnitOfWork
+		o11y o11y.Observability
+	}
+)
+
+func NewOrderReturnUseCase(
+	uow uow.UnitOfWork,
+	o11y o11y.Observability,
+) OrderReturnUseCase {
+	return &orderReturnUseCase{
+		uow:  uow,
+		o11y: o11y,
+	}
+}
+
+// Request claims lines of an order owned by the customer the gateway
+// authenticated. Saving the order bumps its version, so concurrent requests
+// for the same order conflict instead of claiming the same quantities twice.
+func (u *orderReturnUseCase) Request(ctx context.Context, orderID sharedVos.UUID, input *dtos.ReturnInput) (*dtos.ReturnOutput, error) {
+	ctx, span := u.o11y.Start(ctx, "order_return_usecase.request")
+	defer span.End()
+
+	var orderReturn *entities.OrderReturn
+	err := u.uow.Do(ctx, func(ctx context.Context, tx uow.TX) error {
+		order, err := findCustomerOrder(ctx, tx, orderID)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error find order", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		orderReturnRepository, err := GetOrderReturnRepository(tx)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error get order return repository", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		returns, err := orderReturnRepository.FindByOrder(ctx, order.ID)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error find order returns", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		orderReturn, err = factories.CreateOrderReturn(order, returns, input)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error create order return", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		if err := orderReturnRepository.Insert(ctx, orderReturn); err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error insert order return", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		orderRepository, err := GetOrderRepository(tx)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error get order repository", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+		return orderRepository.Update(ctx, order)
+	})
+
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error request order return", o11y.Attributes{Key: "error", Value: err})
+		return nil, err
+	}
+	return toReturnOutput(orderReturn), nil
+}
+
+func (u *orderReturnUseCase) List(ctx context.Context, orderID sharedVos.UUID) ([]*dtos.ReturnOutput, error) {
+	ctx, span := u.o11y.Start(ctx, "order_return_usecase.list")
+	defer span.End()
+
+	output := make([]*dtos.ReturnOutput, 0)
+	err := u.uow.Do(ctx, func(ctx context.Context, tx uow.TX) error {
+		if _, err := findCustomerOrder(ctx, tx, orderID); err != nil {
+			return err
+		}
+
+		orderReturnRepository, err := GetOrderReturnRepository(tx)
+		if err != nil {
+			return err
+		}
+
+		returns, err := orderReturnRepository.FindByOrder(ctx, orderID)
+		if err != nil {
+			return err
+		}
+
+		for _, orderReturn := range returns {
+			output = append(output, toReturnOutput(orderReturn))
+		}
+		return nil
+	})
+
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error list order returns", o11y.Attributes{Key: "error", Value: err})
+		return nil, err
+	}
+	return output, nil
+}
+
+func (u *orderReturnUseCase) Show(ctx context.Context, returnID sharedVos.UUID) (*dtos.ReturnOutput, error) {
+	ctx, span := u.o11y.Start(ctx, "order_return_usecase.show")
+	defer span.End()
+
+	var orderReturn *entities.OrderReturn
+	err := u.uow.Do(ctx, func(ctx context.Context, tx uow.TX) error {
+		_, found, err := u.find(ctx, tx, returnID)
+		orderReturn = found
+		return err
+	})
+
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error find order return", o11y.Attributes{Key: "error", Value: err})
+		return nil, err
+	}
+	return toReturnOutput(orderReturn), nil
+}
+
+func (u *orderReturnUseCase) Approve(ctx context.Context, returnID sharedVos.UUID) (*dtos.ReturnOutput, error) {
+	ctx, span := u.o11y.Start(ctx, "order_return_usecase.approve")
+	defer span.End()
+
+	return u.transition(ctx, span, returnID, func(ctx context.Context, tx uow.TX, orderReturn *entities.OrderReturn) error {
+		return orderReturn.Approve()
+	})
+}
+
+func (u *orderReturnUseCase) Reject(ctx context.Context, returnID sharedVos.UUID, input *dtos.RejectReturnInput) (*dtos.ReturnOutput, error) {
+	ctx, span := u.o11y.Start(ctx, "order_return_usecase.reject")
+	defer span.End()
+
+	var reason string
+	if input != nil {
+		reason = strings.TrimSpace(input.Reason)
+	}
+
+	return u.transition(ctx, span, returnID, func(ctx context.Context, tx uow.TX, orderReturn *entities.OrderReturn) error {
+		return orderReturn.Reject(reason)
+	})
+}
+
+// Refund records the approved return amount against the order payments and
+// settles the order as PARTIALLY_REFUNDED or REFUNDED.
+func (u *orderReturnUseCase) Refund(ctx context.Context, returnID sharedVos.UUID) (*dtos.ReturnOutput, error) {
+	ctx, span := u.o11y.Start(ctx, "order_return_usecase.refund")
+	defer span.End()
+
+	return u.transition(ctx, span, returnID, func(ctx context.Context, tx uow.TX, orderReturn *entities.OrderReturn) error {
+		order, err := findOrder(ctx, tx, orderReturn.OrderID)
+		if err != nil {
+			return err
+		}
+
+		refunds, err := factories.RefundReturn(order, orderReturn)
+		if err != nil {
+			return err
+		}
+
+		orderRepository, err := GetOrderRepository(tx)
+		if err != nil {
+			return err
+		}
+
+		if err := orderRepository.InsertRefunds(ctx, refunds); err != nil {
+			return err
+		}
+
+		for _, payment := range order.Payments {
+			for _, refund := range refunds {
+				if refund.PaymentID == payment.ID {
+					if err := orderRepository.UpdatePayment(ctx, payment); err != nil {
+						return err
+					}
+					break
+				}
+			}
+		}
+		return orderRepository.Update(ctx, order)
+	})
+}
+
+func (u *orderReturnUseCase) transition(
+	ctx context.Context,
+	span o11y.Span,
+	returnID sharedVos.UUID,
+	apply func(ctx context.Context, tx uow.TX, orderReturn *entities.OrderReturn) error,
+) (*dtos.ReturnOutput, error) {
+	var orderReturn *entities.OrderReturn
+	err := u.uow.Do(ctx, func(ctx context.Context, tx uow.TX) error {
+		orderReturnRepository, found, err := u.find(ctx, tx, returnID)
+		if err != nil {
+			return err
+		}
+		orderReturn = found
+
+		if err := apply(ctx, tx, orderReturn); err != nil {
+			return err
+		}
+		return orderReturnRepository.Update(ctx, orderReturn)
+	})
+
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error update order return", o11y.Attributes{Key: "error", Value: err})
+		return nil, err
+	}
+	return toReturnOutput(orderReturn), nil
+}
+
+func (u *orderReturnUseCase) find(ctx context.Context, tx uow.TX, returnID sharedVos.UUID) (interfaces.OrderReturnRepository, *entities.OrderReturn, error) {
+	orderReturnRepository, err := GetOrderReturnRepository(tx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	orderReturn, err := orderReturnRepository.Find(ctx, returnID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if orderReturn == nil {
+		return nil, nil, ErrReturnNotFound
+	}
+	return orderReturnRepository, orderReturn, nil
+}
+
+func findOrder(ctx context.Context, tx uow.TX, orderID sharedVos.UUID) (*entities.Order, error) {
+	orderRepository, err := GetOrderRepository(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := orderRepository.Find(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if order == nil {
+		return nil, ErrOrderNotFound
+	}
+	return order, nil
+}
+
+// findCustomerOrder reports the orders of other customers, and every order to
+// anonymous callers, as not found.
+func findCustomerOrder(ctx context.Context, tx uow.TX, orderID sharedVos.UUID) (*entities.Order, error) {
+	order, err := findOrder(ctx, tx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if customerID := identity.CustomerFromContext(ctx); customerID == "" || order.CustomerID != customerID {
+		return nil, ErrOrderNotFound
+	}
+	return order, nil
+}
+
+func toReturnOutput(orderReturn *entities.OrderReturn) *dtos.ReturnOutput {
+	output := &dtos.ReturnOutput{
+		ID:              orderReturn.ID.String(),
+		OrderID:         orderReturn.OrderID.String(),
+		Status:          orderReturn.Status.String(),
+		Reason:          orderReturn.Reason,
+		RejectionReason: orderReturn.RejectionReason,
+		Amount:          orderReturn.Amount(),
+		Lines:           make([]*dtos.ReturnLineOutput, 0, len(orderReturn.Lines)),
+		CreatedAt:       orderReturn.CreatedAt,
+	}
+
+	for _, line := range orderReturn.Lines {
+		output.Lines = append(output.Lines, &dtos.ReturnLineOutput{
+			OrderItemID: line.OrderItemID.String(),
+			Quantity:    line.Quantity,
+			Amount:      line.Amount,
+		})
+	}
+
+	for _, refund := range orderReturn.Refunds {
+		output.Refunds = append(output.Refunds, &dtos.RefundOutput{
+			ID:        refund.ID.String(),
+			PaymentID: refund.PaymentID.String(),
+			Amount:    refund.Amount,
+			CreatedAt: refund.CreatedAt,
+		})
+	}
+	return output
+}
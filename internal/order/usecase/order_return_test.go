@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/dtos"
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	"github.com/jailtonjunior94/order/internal/order/domain/interfaces"
+	"github.com/jailtonjunior94/order/internal/order/domain/vos"
+	"github.com/jailtonjunior94/order/pkg/database/uow"
+	"github.com/jailtonjunior94/order/pkg/identity"
+	sharedVos "github.com/jailtonjunior94/order/pkg/vos"
+)
+
+type fakeOrderReturnRepository struct {
+	interfaces.OrderReturnRepository
+	returns []*entities.OrderReturn
+}
+
+func (r *fakeOrderReturnRepository) Insert(_ context.Context, orderReturn *entities.OrderReturn) error {
+	r.returns = append(r.returns, orderReturn)
+	return nil
+}
+
+func (r *fakeOrderReturnRepository) FindByOrder(context.Context, sharedVos.UUID) ([]*entities.OrderReturn, error) {
+	return r.returns, nil
+}
+
+// newReturnFixture builds a paid order of customer-1 whose items can be
+// returned.
+func newReturnFixture(t *testing.T) *entities.Order {
+	t.Helper()
+
+	order, _ := newSagaFixture(t)
+	order.CustomerID = "customer-1"
+
+	payment := entities.NewPayment(order.ID, order.Total(), order.Currency, vos.PaymentPix, "", vos.PaymentApproved)
+	if err := order.RegisterPayment(payment); err != nil {
+		t.Fatal(err)
+	}
+	return order
+}
+
+func TestOrderReturnRequest(t *testing.T) {
+	tests := []struct {
+		name            string
+		customerID      string
+		updateErr       error
+		expectedErr     error
+		expectedListErr error
+	}{
+		{name: "order owner", customerID: "customer-1"},
+		{name: "another customer", customerID: "customer-2", expectedErr: ErrOrderNotFound, expectedListErr: ErrOrderNotFound},
+		{name: "anonymous caller", expectedErr: ErrOrderNotFound, expectedListErr: ErrOrderNotFound},
+		{name: "concurrent request", customerID: "customer-1", updateErr: interfaces.ErrOrderConflict, expectedErr: interfaces.ErrOrderConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := newReturnFixture(t)
+			unitOfWork := &fakeUnitOfWork{repositories: map[uow.RepositoryName]uow.Repository{
+				OrderRepository:       &fakeOrderRepository{order: order, updateErr: tt.updateErr},
+				OrderReturnRepository: &fakeOrderReturnRepository{},
+			}}
+			returnUseCase := NewOrderReturnUseCase(unitOfWork, fakeObservability{})
+			ctx := identity.WithCustomer(context.Background(), tt.customerID)
+
+			input := &dtos.ReturnInput{Lines: []*dtos.ReturnLineInput{{OrderItemID: order.Items[0].ID.String(), Quantity: 1}}}
+			if _, err := returnUseCase.Request(ctx, order.ID, input); !errors.Is(err, tt.expectedErr) {
+				t.Errorf("Request() error = %v, want %v", err, tt.expectedErr)
+			}
+
+			if _, err := returnUseCase.List(ctx, order.ID); !errors.Is(err, tt.expectedListErr) {
+				t.Errorf("List() error = %v, want %v", err, tt.expectedListErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,304 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/jailtonjunior94/order/configs"
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	"github.com/jailtonjunior94/order/internal/order/domain/factories"
+	"github.com/jailtonjunior94/order/internal/order/domain/interfaces"
+	"github.com/jailtonjunior94/order/internal/order/domain/vos"
+	"github.com/jailtonjunior94/order/pkg/database/uow"
+	"github.com/jailtonjunior94/order/pkg/o11y"
+	sharedVos "github.com/jailtonjunior94/order/pkg/vos"
+)
+
+const (
+	OrderNotPendingReason   = "order_not_pending"
+	PaymentNotAwaitedReason = "payment_not_awaited"
+	defaultSagaStepTimeout  = 5 * time.Minute
+)
+
+type (
+	OrderSagaUseCase interface {
+		StockReserved(ctx context.Context, orderID sharedVos.UUID, step int, reservationID string) error
+		StockRejected(ctx context.Context, orderID sharedVos.UUID, reason string) error
+		StockReleased(ctx context.Context, orderID sharedVos.UUID, reservationID string) error
+		PaymentAuthorized(ctx context.Context, orderID sharedVos.UUID, paymentID string, step int, amount float64) error
+		PaymentDeclined(ctx context.Context, orderID sharedVos.UUID, paymentID string, step int, reason string) error
+	}
+
+	orderSagaUseCase struct {
+		config    *configs.Config
+		uow       uow.UnitOfWork
+		inventory interfaces.InventoryClient
+		o11y      o11y.Observability
+	}
+
+	sagaStep func(ctx context.Context, tx uow.TX, saga *entities.OrderSaga, order *entities.Order) error
+)
+
+func NewOrderSagaUseCase(
+	config *configs.Config,
+	uow uow.UnitOfWork,
+	inventory interfaces.InventoryClient,
+	o11y o11y.Observability,
+) OrderSagaUseCase {
+	return &orderSagaUseCase{
+		uow:       uow,
+		o11y:      o11y,
+		config:    config,
+		inventory: inventory,
+	}
+}
+
+// StockReserved advances the saga to the payment authorization. A reservation
+// made for a superseded ReserveStock command, e.g. one sent before the order
+// was amended, is released instead.
+func (u *orderSagaUseCase) StockReserved(ctx context.Context, orderID sharedVos.UUID, step int, reservationID string) error {
+	return u.step(ctx, "order_saga_usecase.stock_reserved", orderID, "", func(ctx context.Context, tx uow.TX, saga *entities.OrderSaga, order *entities.Order) error {
+		if !saga.Awaits(vos.SagaReservingStock, step) {
+			return saga.DiscardReservation(reservationID)
+		}
+
+		if order.Status != vos.StatusPending {
+			return compensateSaga(u.inventory, saga, OrderNotPendingReason, sagaDeadline(u.config))
+		}
+		return saga.StockReserved(step, reservationID, order.Total(), order.Currency, sagaDeadline(u.config))
+	})
+}
+
+func (u *orderSagaUseCase) StockRejected(ctx context.Context, orderID sharedVos.UUID, reason string) error {
+	return u.step(ctx, "order_saga_usecase.stock_rejected", orderID, "", func(ctx context.Context, tx uow.TX, saga *entities.OrderSaga, order *entities.Order) error {
+		if err := saga.StockRejected(reason); err != nil {
+			return err
+		}
+		return cancelOrder(order, reason)
+	})
+}
+
+func (u *orderSagaUseCase) StockReleased(ctx context.Context, orderID sharedVos.UUID, reservationID string) error {
+	return u.step(ctx, "order_saga_usecase.stock_released", orderID, "", func(_ context.Context, _ uow.TX, saga *entities.OrderSaga, _ *entities.Order) error {
+		return saga.StockReleased(reservationID)
+	})
+}
+
+// PaymentAuthorized completes the saga. An authorization the saga no longer
+// waits for, e.g. because the order was canceled or amended in the meantime,
+// or one for another amount than the order total, is voided; the reservation
+// of a canceled order is released.
+func (u *orderSagaUseCase) PaymentAuthorized(ctx context.Context, orderID sharedVos.UUID, paymentID string, step int, amount float64) error {
+	messageID := paymentMessageID(paymentID, vos.PaymentApproved)
+	return u.step(ctx, "order_saga_usecase.payment_authorized", orderID, messageID, func(ctx context.Context, tx uow.TX, saga *entities.OrderSaga, order *entities.Order) error {
+		if order.Status != vos.StatusPending {
+			if err := saga.VoidPayment(paymentID, OrderNotPendingReason); err != nil {
+				return err
+			}
+
+			if saga.Status.IsTerminal() || saga.Status == vos.SagaCompensating {
+				return nil
+			}
+			return compensateSaga(u.inventory, saga, OrderNotPendingReason, sagaDeadline(u.config))
+		}
+
+		if !saga.Awaits(vos.SagaAuthorizingPayment, step) || math.Round(amount*100) != math.Round(order.Total()*100) {
+			return saga.VoidPayment(paymentID, PaymentNotAwaitedReason)
+		}
+
+		if err := saga.PaymentAuthorized(step, paymentID); err != nil {
+			return err
+		}
+		return capturePayment(ctx, tx, order, amount, paymentID)
+	})
+}
+
+func (u *orderSagaUseCase) PaymentDeclined(ctx context.Context, orderID sharedVos.UUID, paymentID string, step int, reason string) error {
+	messageID := paymentMessageID(paymentID, vos.PaymentDeclined)
+	return u.step(ctx, "order_saga_usecase.payment_declined", orderID, messageID, func(ctx context.Context, tx uow.TX, saga *entities.OrderSaga, order *entities.Order) error {
+		if err := saga.PaymentDeclined(step, paymentID); err != nil {
+			return err
+		}
+
+		if err := compensateSaga(u.inventory, saga, reason, sagaDeadline(u.config)); err != nil {
+			return err
+		}
+		return cancelOrder(order, reason)
+	})
+}
+
+// step applies a saga transition in one transaction; a non-empty messageID is
+// recorded as processed first, so redelivered messages are skipped.
+func (u *orderSagaUseCase) step(ctx context.Context, name string, orderID sharedVos.UUID, messageID string, apply sagaStep) error {
+	ctx, span := u.o11y.Start(ctx, name)
+	defer span.End()
+
+	var reservationID string
+	err := u.uow.Do(ctx, func(ctx context.Context, tx uow.TX) error {
+		orderSagaRepository, err := GetOrderSagaRepository(tx)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error get order saga repository", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		orderRepository, err := GetOrderRepository(tx)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error get order repository", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		saga, err := orderSagaRepository.FindByOrder(ctx, orderID)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error find order saga", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		if saga == nil {
+			return ErrSagaNotFound
+		}
+
+		if messageID != "" {
+			registered, err := registerProcessedMessage(ctx, tx, PaymentConsumer, messageID)
+			if err != nil {
+				span.AddAttributes(ctx, o11y.Error, "error register processed message", o11y.Attributes{Key: "error", Value: err})
+				return err
+			}
+
+			if !registered {
+				return nil
+			}
+		}
+
+		order, err := orderRepository.Find(ctx, orderID)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error find order", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		if order == nil {
+			return ErrOrderNotFound
+		}
+
+		status, sagaStatus := order.Status, saga.Status
+		if err := apply(ctx, tx, saga, order); err != nil {
+			return err
+		}
+
+		if sagaStatus != vos.SagaAborted {
+			reservationID = pendingRelease(u.inventory, saga)
+		}
+
+		if err := orderSagaRepository.Update(ctx, saga); err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error update order saga", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		if order.Status == status {
+			return nil
+		}
+
+		if err := orderRepository.Update(ctx, order); err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error update order", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		if order.Status != vos.StatusCanceled {
+			return nil
+		}
+
+		if err := returnCoupon(ctx, tx, order); err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error return coupon", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+		return nil
+	})
+
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error advance order saga",
+			o11y.Attributes{Key: "order_id", Value: orderID.String()},
+			o11y.Attributes{Key: "error", Value: err},
+		)
+		return err
+	}
+	releaseStock(ctx, u.inventory, u.o11y, reservationID)
+	return nil
+}
+
+func startOrderSaga(ctx context.Context, tx uow.TX, config *configs.Config, order *entities.Order, reservationID string) error {
+	orderSagaRepository, err := GetOrderSagaRepository(tx)
+	if err != nil {
+		return err
+	}
+
+	saga, err := factories.CreateOrderSaga(order, reservationID, sagaDeadline(config))
+	if err != nil {
+		return err
+	}
+	return orderSagaRepository.Insert(ctx, saga)
+}
+
+// compensateSaga aborts the saga when the inventory port is configured, leaving
+// the reservation to be released once the transaction commits; otherwise the
+// saga emits a release command and waits for the reply.
+func compensateSaga(inventory interfaces.InventoryClient, saga *entities.OrderSaga, reason string, deadline time.Time) error {
+	if inventory == nil || saga.ReservationID == "" {
+		return saga.Compensate(reason, deadline)
+	}
+
+	if saga.Status.IsTerminal() || saga.Status == vos.SagaCompensating {
+		return entities.ErrSagaStepMismatch
+	}
+	return saga.Abort(reason)
+}
+
+// pendingRelease returns the reservation compensateSaga left for the inventory
+// port to release, or an empty string when there is none.
+func pendingRelease(inventory interfaces.InventoryClient, saga *entities.OrderSaga) string {
+	if inventory == nil || saga.Status != vos.SagaAborted {
+		return ""
+	}
+	return saga.ReservationID
+}
+
+// releaseStock runs outside of any transaction so a slow inventory never holds
+// database locks; failures are traced and do not undo the committed change.
+func releaseStock(ctx context.Context, inventory interfaces.InventoryClient, observability o11y.Observability, reservationIDs ...string) {
+	if inventory == nil {
+		return
+	}
+
+	ctx, span := observability.Start(context.WithoutCancel(ctx), "inventory.release_stock")
+	defer span.End()
+
+	for _, reservationID := range reservationIDs {
+		if reservationID == "" {
+			continue
+		}
+
+		if err := inventory.Release(ctx, reservationID); err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error release stock",
+				o11y.Attributes{Key: "reservation_id", Value: reservationID},
+				o11y.Attributes{Key: "error", Value: err},
+			)
+		}
+	}
+}
+
+// cancelOrder tolerates orders that already left PENDING, e.g. canceled by
+// the expiration job while the saga was still running.
+func cancelOrder(order *entities.Order, reason string) error {
+	if err := order.Cancel(reason); err != nil && !errors.Is(err, entities.ErrOrderNotPending) {
+		return err
+	}
+	return nil
+}
+
+func sagaDeadline(config *configs.Config) time.Time {
+	timeout := config.OrderConfig.SagaStepTimeout
+	if timeout <= 0 {
+		timeout = defaultSagaStepTimeout
+	}
+	return time.Now().UTC().Add(timeout)
+}
@@ -0,0 +1,335 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jailtonjunior94/order/configs"
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	"github.com/jailtonjunior94/order/internal/order/domain/events"
+	"github.com/jailtonjunior94/order/internal/order/domain/interfaces"
+	"github.com/jailtonjunior94/order/internal/order/domain/vos"
+	"github.com/jailtonjunior94/order/pkg/database/uow"
+	"github.com/jailtonjunior94/order/pkg/o11y"
+	sharedVos "github.com/jailtonjunior94/order/pkg/vos"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+type (
+	fakeObservability struct {
+		o11y.Observability
+	}
+
+	fakeSpan struct {
+		trace.Span
+	}
+
+	fakeUnitOfWork struct {
+		uow.UnitOfWork
+		repositories map[uow.RepositoryName]uow.Repository
+	}
+
+	fakeOrderRepository struct {
+		interfaces.OrderRepository
+		order     *entities.Order
+		payments  []*entities.Payment
+		updateErr error
+	}
+
+	fakeOrderSagaRepository struct {
+		interfaces.OrderSagaRepository
+		saga *entities.OrderSaga
+	}
+
+	fakeProcessedMessageRepository struct {
+		processed map[string]bool
+	}
+
+	fakeCouponRepository struct {
+		interfaces.CouponRepository
+	}
+)
+
+func (fakeObservability) Start(ctx context.Context, _ string, _ ...trace.SpanStartOption) (context.Context, o11y.Span) {
+	return ctx, fakeSpan{Span: trace.SpanFromContext(ctx)}
+}
+
+func (fakeSpan) AddStatus(context.Context, o11y.Code, string) {}
+
+func (fakeSpan) AddAttributes(context.Context, o11y.Code, string, ...o11y.Attributes) {}
+
+func (f *fakeUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context, tx uow.TX) error) error {
+	return fn(ctx, f)
+}
+
+func (f *fakeUnitOfWork) Get(name uow.RepositoryName) (uow.Repository, error) {
+	repository, ok := f.repositories[name]
+	if !ok {
+		return nil, uow.ErrRepositoryNotRegistered
+	}
+	return repository, nil
+}
+
+func (r *fakeOrderRepository) Find(_ context.Context, orderID sharedVos.UUID) (*entities.Order, error) {
+	if r.order == nil || r.order.ID != orderID {
+		return nil, nil
+	}
+	return r.order, nil
+}
+
+func (r *fakeOrderRepository) Update(context.Context, *entities.Order) error {
+	return r.updateErr
+}
+
+func (r *fakeOrderRepository) InsertPayment(_ context.Context, payment *entities.Payment) error {
+	r.payments = append(r.payments, payment)
+	return nil
+}
+
+func (r *fakeOrderSagaRepository) FindByOrder(_ context.Context, orderID sharedVos.UUID) (*entities.OrderSaga, error) {
+	if r.saga == nil || r.saga.OrderID != orderID {
+		return nil, nil
+	}
+	return r.saga, nil
+}
+
+func (r *fakeOrderSagaRepository) Update(context.Context, *entities.OrderSaga) error {
+	return nil
+}
+
+func (r *fakeProcessedMessageRepository) Register(_ context.Context, consumer, messageID string) (bool, error) {
+	key := consumer + "/" + messageID
+	if r.processed[key] {
+		return false, nil
+	}
+	r.processed[key] = true
+	return true, nil
+}
+
+func (fakeCouponRepository) FindRedeemedBy(context.Context, sharedVos.UUID) (*entities.Coupon, error) {
+	return nil, nil
+}
+
+func newSagaFixture(t *testing.T) (*entities.Order, *entities.OrderSaga) {
+	t.Helper()
+
+	orderID, err := sharedVos.NewUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	order := entities.NewOrder()
+	order.ID = orderID
+	order.Currency = vos.CurrencyBRL
+	order.AddItems([]*entities.OrderItem{
+		entities.NewOrderItem(orderID, "SKU-1", "Keyboard", 100, 2),
+		entities.NewOrderItem(orderID, "SKU-2", "Mouse", 50, 1),
+	})
+
+	for _, item := range order.Items {
+		if item.ID, err = sharedVos.NewUUID(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	saga := entities.NewOrderSaga(orderID)
+	saga.StartReserved("reservation-1", order.Total(), order.Currency, time.Now().Add(time.Minute))
+	saga.ClearEvents()
+	return order, saga
+}
+
+func newSagaUseCase(order *entities.Order, saga *entities.OrderSaga) (OrderSagaUseCase, *fakeOrderRepository) {
+	orderRepository := &fakeOrderRepository{order: order}
+	unitOfWork := &fakeUnitOfWork{repositories: map[uow.RepositoryName]uow.Repository{
+		OrderRepository:            orderRepository,
+		OrderSagaRepository:        &fakeOrderSagaRepository{saga: saga},
+		ProcessedMessageRepository: &fakeProcessedMessageRepository{processed: make(map[string]bool)},
+		CouponRepository:           fakeCouponRepository{},
+	}}
+	return NewOrderSagaUseCase(&configs.Config{}, unitOfWork, nil, fakeObservability{}), orderRepository
+}
+
+func TestOrderSagaPaymentAuthorizedAllowsRefunds(t *testing.T) {
+	tests := []struct {
+		name           string
+		deliveries     int
+		refund         float64
+		expectedStatus vos.Status
+		expectedErr    error
+	}{
+		{name: "full refund", deliveries: 1, refund: 250, expectedStatus: vos.StatusRefunded},
+		{name: "partial refund", deliveries: 1, refund: 100, expectedStatus: vos.StatusPartiallyRefunded},
+		{name: "redelivered authorization", deliveries: 2, refund: 250, expectedStatus: vos.StatusRefunded},
+		{name: "refund above paid amount", deliveries: 1, refund: 250.01, expectedStatus: vos.StatusPaid, expectedErr: entities.ErrRefundExceedsPaid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order, saga := newSagaFixture(t)
+			sagaUseCase, orderRepository := newSagaUseCase(order, saga)
+
+			for range tt.deliveries {
+				if err := sagaUseCase.PaymentAuthorized(context.Background(), order.ID, "payment-1", saga.Step, order.Total()); err != nil {
+					t.Fatalf("PaymentAuthorized() error = %v", err)
+				}
+			}
+
+			if order.Status != vos.StatusPaid || saga.Status != vos.SagaCompleted {
+				t.Fatalf("order status = %s, saga status = %s; want PAID, COMPLETED", order.Status, saga.Status)
+			}
+
+			if len(orderRepository.payments) != 1 || orderRepository.payments[0].Amount != 250 {
+				t.Fatalf("inserted payments = %v, want one payment of 250", orderRepository.payments)
+			}
+
+			returnID, _ := sharedVos.NewUUID()
+			_, err := order.Refund(returnID, tt.refund)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("Refund() error = %v, want %v", err, tt.expectedErr)
+			}
+
+			if order.Status != tt.expectedStatus {
+				t.Errorf("order status = %s, want %s", order.Status, tt.expectedStatus)
+			}
+		})
+	}
+}
+
+func TestOrderSagaPaymentAuthorizedVoidsUnawaitedPayments(t *testing.T) {
+	tests := []struct {
+		name         string
+		prepare      func(order *entities.Order, saga *entities.OrderSaga)
+		expectedSaga vos.SagaStatus
+	}{
+		{
+			name: "order canceled",
+			prepare: func(order *entities.Order, _ *entities.OrderSaga) {
+				_ = order.Cancel(ExpiredOrderReason)
+			},
+			expectedSaga: vos.SagaCompensating,
+		},
+		{
+			name: "saga aborted",
+			prepare: func(_ *entities.Order, saga *entities.OrderSaga) {
+				_ = saga.Abort(entities.SagaTimeoutReason)
+			},
+			expectedSaga: vos.SagaAborted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order, saga := newSagaFixture(t)
+			tt.prepare(order, saga)
+			sagaUseCase, orderRepository := newSagaUseCase(order, saga)
+
+			if err := sagaUseCase.PaymentAuthorized(context.Background(), order.ID, "payment-1", saga.Step, order.Total()); err != nil {
+				t.Fatalf("PaymentAuthorized() error = %v", err)
+			}
+
+			if len(orderRepository.payments) != 0 {
+				t.Errorf("inserted payments = %d, want none", len(orderRepository.payments))
+			}
+
+			if saga.Status != tt.expectedSaga {
+				t.Errorf("saga status = %s, want %s", saga.Status, tt.expectedSaga)
+			}
+
+			var voided bool
+			for _, event := range saga.Events() {
+				voided = voided || event.GetEventType() == events.VoidPaymentCommand
+			}
+
+			if !voided {
+				t.Error("expected a void_payment command")
+			}
+		})
+	}
+}
+
+func TestOrderSagaVoidsSupersededAuthorizations(t *testing.T) {
+	tests := []struct {
+		name   string
+		stale  bool
+		amount float64
+	}{
+		{name: "authorization requested before the amendment", stale: true, amount: 150},
+		{name: "authorization of the amount before the amendment", amount: 250},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order, saga := newSagaFixture(t)
+			sagaUseCase, orderRepository := newSagaUseCase(order, saga)
+
+			// The order is amended to 150 while the first authorization is
+			// still in flight.
+			step := saga.Step
+			if err := order.ChangeItemQuantity(order.Items[0].ID, 1); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := saga.Amend(order.Items, "reservation-2", order.Total(), order.Currency, time.Now().Add(time.Minute)); err != nil {
+				t.Fatal(err)
+			}
+			saga.ClearEvents()
+
+			if !tt.stale {
+				step = saga.Step
+			}
+
+			if err := sagaUseCase.PaymentAuthorized(context.Background(), order.ID, "payment-1", step, tt.amount); err != nil {
+				t.Fatalf("PaymentAuthorized() error = %v", err)
+			}
+
+			if saga.Status != vos.SagaAuthorizingPayment || order.Status != vos.StatusPending || len(orderRepository.payments) != 0 {
+				t.Fatalf("saga = %s, order = %s with %d payments; want the saga still awaiting its authorization", saga.Status, order.Status, len(orderRepository.payments))
+			}
+
+			if len(saga.Events()) != 1 || saga.Events()[0].GetEventType() != events.VoidPaymentCommand {
+				t.Errorf("events = %v, want a void_payment command", saga.Events())
+			}
+		})
+	}
+}
+
+func TestOrderSagaReleasesSupersededReservations(t *testing.T) {
+	order, _ := newSagaFixture(t)
+	saga := entities.NewOrderSaga(order.ID)
+	saga.Start(order.Items, time.Now().Add(time.Minute))
+	sagaUseCase, _ := newSagaUseCase(order, saga)
+
+	// The order is amended while the first reservation is still in flight.
+	step := saga.Step
+	if err := saga.Amend(order.Items, "", order.Total(), order.Currency, time.Now().Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	saga.ClearEvents()
+
+	if err := sagaUseCase.StockReserved(context.Background(), order.ID, step, "reservation-1"); err != nil {
+		t.Fatalf("StockReserved() error = %v", err)
+	}
+
+	if saga.Status != vos.SagaReservingStock || saga.ReservationID != "" {
+		t.Fatalf("saga = %s/%q, want it still reserving stock", saga.Status, saga.ReservationID)
+	}
+
+	if len(saga.Events()) != 1 {
+		t.Fatalf("events = %v, want only a release_stock command", saga.Events())
+	}
+
+	if release, ok := saga.Events()[0].GetPayload().(*events.ReleaseStock); !ok || release.ReservationID != "reservation-1" {
+		t.Fatalf("payload = %v, want reservation-1 released", saga.Events()[0].GetPayload())
+	}
+
+	if err := sagaUseCase.StockReserved(context.Background(), order.ID, saga.Step, "reservation-2"); err != nil {
+		t.Fatalf("StockReserved() error = %v", err)
+	}
+
+	if saga.Status != vos.SagaAuthorizingPayment || saga.ReservationID != "reservation-2" {
+		t.Errorf("saga = %s/%q, want the current reservation accepted", saga.Status, saga.ReservationID)
+	}
+}
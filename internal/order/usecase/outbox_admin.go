@@ -0,0 +1,355 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/jailtonjunior94/order/configs"
+	"github.com/jailtonjunior94/order/internal/order/domain/dtos"
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	"github.com/jailtonjunior94/order/internal/order/domain/interfaces"
+	"github.com/jailtonjunior94/order/pkg/database/uow"
+	"github.com/jailtonjunior94/order/pkg/messaging/kafka"
+	"github.com/jailtonjunior94/order/pkg/o11y"
+	"github.com/jailtonjunior94/order/pkg/vos"
+)
+
+const (
+	OutboxStatusAll       = "all"
+	OutboxStatusPending   = "pending"
+	OutboxStatusPublished = "published"
+	OutboxStatusSkipped   = "skipped"
+
+	replayBatchSize = 500
+)
+
+var (
+	ErrOutboxNotFound         = errors.New("outbox not found")
+	ErrInvalidOutboxStatus    = errors.New("invalid outbox status")
+	ErrOutboxAlreadyPublished = errors.New("outbox already published")
+	ErrReplayFilterRequired   = errors.New("replay requires since or event name")
+)
+
+type (
+	OutboxAdminUseCase interface {
+		List(ctx context.Context, input *dtos.OutboxFilterInput) ([]*dtos.OutboxOutput, error)
+		Show(ctx context.Context, id vos.UUID) (*dtos.OutboxOutput, error)
+		Replay(ctx context.Context, input *dtos.OutboxFilterInput) (*dtos.OutboxReplayOutput, error)
+		Requeue(ctx context.Context, id vos.UUID) (*dtos.OutboxOutput, error)
+		Skip(ctx context.Context, id vos.UUID) (*dtos.OutboxOutput, error)
+		Lag(ctx context.Context) (*dtos.OutboxLagOutput, error)
+	}
+
+	outboxAdminUseCase struct {
+		config       *configs.Config
+		uow          uow.UnitOfWork
+		brokerClient kafka.KafkaClient
+		o11y         o11y.Observability
+	}
+)
+
+func NewOutboxAdminUseCase(
+	config *configs.Config,
+	uow uow.UnitOfWork,
+	brokerClient kafka.KafkaClient,
+	o11y o11y.Observability,
+) OutboxAdminUseCase {
+	return &outboxAdminUseCase{
+		uow:          uow,
+		o11y:         o11y,
+		config:       config,
+		brokerClient: brokerClient,
+	}
+}
+
+func (u *outboxAdminUseCase) List(ctx context.Context, input *dtos.OutboxFilterInput) ([]*dtos.OutboxOutput, error) {
+	ctx, span := u.o11y.Start(ctx, "outbox_admin_usecase.list")
+	defer span.End()
+
+	filter, err := newOutboxFilter(input)
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error invalid filter", o11y.Attributes{Key: "error", Value: err})
+		return nil, err
+	}
+
+	var output []*dtos.OutboxOutput
+	err = u.uow.Do(ctx, func(ctx context.Context, tx uow.TX) error {
+		outboxRepository, err := GetOutboxRepository(tx)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error get outbox repository", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		outboxes, err := outboxRepository.List(ctx, filter)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error list outbox", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		output = make([]*dtos.OutboxOutput, len(outboxes))
+		for i, outbox := range outboxes {
+			output[i] = newOutboxOutput(outbox)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+func (u *outboxAdminUseCase) Show(ctx context.Context, id vos.UUID) (*dtos.OutboxOutput, error) {
+	ctx, span := u.o11y.Start(ctx, "outbox_admin_usecase.show")
+	defer span.End()
+
+	var output *dtos.OutboxOutput
+	err := u.uow.Do(ctx, func(ctx context.Context, tx uow.TX) error {
+		outbox, err := u.find(ctx, tx, id)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error find outbox", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		output = newOutboxOutput(outbox)
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+// Replay publishes the matching rows again in batches of replayBatchSize, each
+// in its own transaction, up to input.Limit rows. Skipped rows are only
+// replayed when the skipped status is asked for.
+func (u *outboxAdminUseCase) Replay(ctx context.Context, input *dtos.OutboxFilterInput) (*dtos.OutboxReplayOutput, error) {
+	ctx, span := u.o11y.Start(ctx, "outbox_admin_usecase.replay")
+	defer span.End()
+
+	if input.Since.IsZero() && input.EventName == "" {
+		return nil, ErrReplayFilterRequired
+	}
+
+	filter, err := newOutboxFilter(input)
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error invalid filter", o11y.Attributes{Key: "error", Value: err})
+		return nil, err
+	}
+
+	if filter.Skipped == nil {
+		skipped := false
+		filter.Skipped = &skipped
+	}
+
+	output := &dtos.OutboxReplayOutput{}
+	for {
+		filter.Limit = replayBatchSize
+		if remaining := input.Limit - output.Replayed; input.Limit > 0 && remaining < replayBatchSize {
+			filter.Limit = remaining
+		}
+
+		replayed, err := u.replayBatch(ctx, filter)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error replay outbox batch", o11y.Attributes{Key: "error", Value: err})
+			return nil, err
+		}
+
+		output.Replayed += len(replayed)
+		if len(replayed) < filter.Limit || output.Replayed == input.Limit {
+			return output, nil
+		}
+		filter.After = replayed[len(replayed)-1]
+	}
+}
+
+func (u *outboxAdminUseCase) replayBatch(ctx context.Context, filter *interfaces.OutboxFilter) ([]*entities.Outbox, error) {
+	var outboxes []*entities.Outbox
+	err := u.uow.Do(ctx, func(ctx context.Context, tx uow.TX) error {
+		outboxRepository, err := GetOutboxRepository(tx)
+		if err != nil {
+			return err
+		}
+
+		outboxes, err = outboxRepository.List(ctx, filter)
+		if err != nil {
+			return err
+		}
+
+		for _, outbox := range outboxes {
+			if err := produceOutbox(ctx, u.brokerClient, u.config.KafkaConfig.Order, outbox); err != nil {
+				return err
+			}
+
+			if err := outboxRepository.Update(ctx, outbox.MarkAsPublished()); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return outboxes, err
+}
+
+func (u *outboxAdminUseCase) Requeue(ctx context.Context, id vos.UUID) (*dtos.OutboxOutput, error) {
+	ctx, span := u.o11y.Start(ctx, "outbox_admin_usecase.requeue")
+	defer span.End()
+
+	var output *dtos.OutboxOutput
+	err := u.uow.Do(ctx, func(ctx context.Context, tx uow.TX) error {
+		outbox, err := u.find(ctx, tx, id)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error find outbox", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		outboxRepository, err := GetOutboxRepository(tx)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error get outbox repository", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		if err := outboxRepository.Update(ctx, outbox.Requeue()); err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error requeue outbox", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		output = newOutboxOutput(outbox)
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+func (u *outboxAdminUseCase) Skip(ctx context.Context, id vos.UUID) (*dtos.OutboxOutput, error) {
+	ctx, span := u.o11y.Start(ctx, "outbox_admin_usecase.skip")
+	defer span.End()
+
+	var output *dtos.OutboxOutput
+	err := u.uow.Do(ctx, func(ctx context.Context, tx uow.TX) error {
+		outbox, err := u.find(ctx, tx, id)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error find outbox", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		if outbox.WasPublished {
+			return ErrOutboxAlreadyPublished
+		}
+
+		outboxRepository, err := GetOutboxRepository(tx)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error get outbox repository", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		if err := outboxRepository.Update(ctx, outbox.Skip()); err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error skip outbox", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		output = newOutboxOutput(outbox)
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+func (u *outboxAdminUseCase) Lag(ctx context.Context) (*dtos.OutboxLagOutput, error) {
+	ctx, span := u.o11y.Start(ctx, "outbox_admin_usecase.lag")
+	defer span.End()
+
+	output := &dtos.OutboxLagOutput{}
+	err := u.uow.Do(ctx, func(ctx context.Context, tx uow.TX) error {
+		outboxRepository, err := GetOutboxRepository(tx)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error get outbox repository", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		pending, oldest, err := outboxRepository.PendingStats(ctx)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error pending stats outbox", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		output.Pending = pending
+		output.OldestPending = oldest
+		if oldest != nil {
+			output.LagSeconds = time.Since(*oldest).Seconds()
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+func (u *outboxAdminUseCase) find(ctx context.Context, tx uow.TX, id vos.UUID) (*entities.Outbox, error) {
+	outboxRepository, err := GetOutboxRepository(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	outbox, err := outboxRepository.Find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if outbox == nil {
+		return nil, ErrOutboxNotFound
+	}
+	return outbox, nil
+}
+
+func newOutboxFilter(input *dtos.OutboxFilterInput) (*interfaces.OutboxFilter, error) {
+	filter := &interfaces.OutboxFilter{
+		EventName: input.EventName,
+		Since:     input.Since,
+		Limit:     input.Limit,
+	}
+
+	switch input.Status {
+	case "", OutboxStatusAll:
+	case OutboxStatusPending:
+		wasPublished, skipped := false, false
+		filter.WasPublished = &wasPublished
+		filter.Skipped = &skipped
+	case OutboxStatusPublished:
+		wasPublished := true
+		filter.WasPublished = &wasPublished
+	case OutboxStatusSkipped:
+		skipped := true
+		filter.Skipped = &skipped
+	default:
+		return nil, ErrInvalidOutboxStatus
+	}
+	return filter, nil
+}
+
+func newOutboxOutput(outbox *entities.Outbox) *dtos.OutboxOutput {
+	output := &dtos.OutboxOutput{
+		ID:           outbox.ID.String(),
+		EventName:    outbox.EventName,
+		WasPublished: outbox.WasPublished,
+		PublishedAt:  outbox.PublishedAt.Time,
+		SkippedAt:    outbox.SkippedAt.Time,
+		Payload:      json.RawMessage(outbox.Payload),
+		CreatedAt:    outbox.CreatedAt,
+	}
+
+	if !outbox.AggregateID.IsEmpty() {
+		output.AggregateID = outbox.AggregateID.String()
+	}
+	return output
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/jailtonjunior94/order/configs"
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
 	"github.com/jailtonjunior94/order/pkg/database/uow"
 	"github.com/jailtonjunior94/order/pkg/messaging/kafka"
 	"github.com/jailtonjunior94/order/pkg/o11y"
@@ -54,13 +55,7 @@ func (c *publishEventUseCase) Execute(ctx context.Context) error {
 		}
 
 		for _, event := range eventsToPublish {
-			headers := map[string]string{"event_name": event.EventName}
-			message := &kafka.Message{
-				Key:   []byte(event.ID.String()),
-				Value: []byte(event.Payload),
-			}
-
-			if err := c.brokerClient.Produce(ctx, c.config.KafkaConfig.Order, headers, message); err != nil {
+			if err := produceOutbox(ctx, c.brokerClient, c.config.KafkaConfig.Order, event); err != nil {
 				span.AddAttributes(ctx, o11y.Error, "error produce event", o11y.Attributes{Key: "error", Value: err})
 				return err
 			}
@@ -73,3 +68,12 @@ func (c *publishEventUseCase) Execute(ctx context.Context) error {
 		return nil
 	})
 }
+
+func produceOutbox(ctx context.Context, brokerClient kafka.KafkaClient, topic string, event *entities.Outbox) error {
+	headers := map[string]string{"event_name": event.EventName}
+	message := &kafka.Message{
+		Key:   event.Key(),
+		Value: []byte(event.Payload),
+	}
+	return brokerClient.Produce(ctx, topic, headers, message)
+}
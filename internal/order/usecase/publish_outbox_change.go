@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/jailtonjunior94/order/configs"
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	"github.com/jailtonjunior94/order/pkg/database/uow"
+	"github.com/jailtonjunior94/order/pkg/messaging/kafka"
+	"github.com/jailtonjunior94/order/pkg/o11y"
+)
+
+type (
+	PublishOutboxChangeUseCase interface {
+		Execute(ctx context.Context, event *entities.Outbox) error
+	}
+
+	publishOutboxChangeUseCase struct {
+		config       *configs.Config
+		uow          uow.UnitOfWork
+		brokerClient kafka.KafkaClient
+		o11y         o11y.Observability
+	}
+)
+
+func NewPublishOutboxChangeUseCase(
+	config *configs.Config,
+	uow uow.UnitOfWork,
+	brokerClient kafka.KafkaClient,
+	o11y o11y.Observability,
+) PublishOutboxChangeUseCase {
+	return &publishOutboxChangeUseCase{
+		uow:          uow,
+		o11y:         o11y,
+		config:       config,
+		brokerClient: brokerClient,
+	}
+}
+
+func (c *publishOutboxChangeUseCase) Execute(ctx context.Context, event *entities.Outbox) error {
+	ctx, span := c.o11y.Start(ctx, "publish_outbox_change_usecase.execute")
+	defer span.End()
+
+	if err := produceOutbox(ctx, c.brokerClient, c.config.KafkaConfig.Order, event); err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error produce event", o11y.Attributes{Key: "error", Value: err})
+		return err
+	}
+
+	return c.uow.Do(ctx, func(ctx context.Context, tx uow.TX) error {
+		outboxRepository, err := GetOutboxRepository(tx)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error get outbox repository", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		if err := outboxRepository.Update(ctx, event.MarkAsPublished()); err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error update status event", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+		return nil
+	})
+}
@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/jailtonjunior94/order/configs"
+	"github.com/jailtonjunior94/order/pkg/database/uow"
+	"github.com/jailtonjunior94/order/pkg/o11y"
+)
+
+const (
+	defaultPurgeBatchSize = 500
+	// defaultOutboxRetention keeps published and skipped rows around for
+	// inspection and replay when OUTBOX_RETENTION is not set.
+	defaultOutboxRetention = 7 * 24 * time.Hour
+)
+
+type (
+	PurgeOutboxUseCase interface {
+		Execute(ctx context.Context) (int64, error)
+	}
+
+	purgeOutboxUseCase struct {
+		config *configs.Config
+		uow    uow.UnitOfWork
+		o11y   o11y.Observability
+	}
+)
+
+func NewPurgeOutboxUseCase(
+	config *configs.Config,
+	uow uow.UnitOfWork,
+	o11y o11y.Observability,
+) PurgeOutboxUseCase {
+	return &purgeOutboxUseCase{
+		uow:    uow,
+		o11y:   o11y,
+		config: config,
+	}
+}
+
+func (c *purgeOutboxUseCase) Execute(ctx context.Context) (int64, error) {
+	ctx, span := c.o11y.Start(ctx, "purge_outbox_usecase.execute")
+	defer span.End()
+
+	batchSize := c.config.OutboxConfig.PurgeBatchSize
+	if batchSize <= 0 {
+		batchSize = defaultPurgeBatchSize
+	}
+
+	retention := c.config.OutboxConfig.Retention
+	if retention <= 0 {
+		retention = defaultOutboxRetention
+	}
+	before := time.Now().UTC().Add(-retention)
+
+	var purged int64
+	for {
+		var affected int64
+		err := c.uow.Do(ctx, func(ctx context.Context, tx uow.TX) error {
+			outboxRepository, err := GetOutboxRepository(tx)
+			if err != nil {
+				span.AddAttributes(ctx, o11y.Error, "error get outbox repository", o11y.Attributes{Key: "error", Value: err})
+				return err
+			}
+
+			if c.config.OutboxConfig.Archive {
+				affected, err = outboxRepository.ArchiveSettled(ctx, before, batchSize)
+			} else {
+				affected, err = outboxRepository.DeleteSettled(ctx, before, batchSize)
+			}
+			if err != nil {
+				span.AddAttributes(ctx, o11y.Error, "error purge outbox batch", o11y.Attributes{Key: "error", Value: err})
+				return err
+			}
+			return nil
+		})
+
+		if err != nil {
+			return purged, err
+		}
+
+		purged += affected
+		if affected < int64(batchSize) {
+			return purged, nil
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jailtonjunior94/order/configs"
+	"github.com/jailtonjunior94/order/internal/order/domain/dtos"
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	"github.com/jailtonjunior94/order/internal/order/domain/factories"
+	"github.com/jailtonjunior94/order/internal/order/domain/interfaces"
+	"github.com/jailtonjunior94/order/pkg/o11y"
+)
+
+type (
+	QuoteShippingUseCase interface {
+		Execute(ctx context.Context, input *dtos.ShippingQuoteInput) ([]*dtos.ShippingOptionOutput, error)
+	}
+
+	quoteShippingUseCase struct {
+		config  *configs.Config
+		catalog interfaces.CatalogClient
+		rates   interfaces.ShippingRateProvider
+		o11y    o11y.Observability
+	}
+)
+
+func NewQuoteShippingUseCase(
+	config *configs.Config,
+	catalog interfaces.CatalogClient,
+	rates interfaces.ShippingRateProvider,
+	o11y o11y.Observability,
+) QuoteShippingUseCase {
+	return &quoteShippingUseCase{
+		config:  config,
+		catalog: catalog,
+		rates:   rates,
+		o11y:    o11y,
+	}
+}
+
+// Execute lists the options a customer can choose from before placing the
+// order; without a rate provider there are none and orders ship for free.
+func (u *quoteShippingUseCase) Execute(ctx context.Context, input *dtos.ShippingQuoteInput) ([]*dtos.ShippingOptionOutput, error) {
+	ctx, span := u.o11y.Start(ctx, "quote_shipping_usecase.execute")
+	defer span.End()
+
+	if err := factories.ValidateShippingQuoteInput(input); err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error validate shipping quote", o11y.Attributes{Key: "error", Value: err})
+		return nil, err
+	}
+
+	products, err := u.catalog.FindProducts(ctx, factories.ItemSKUs(input.Items))
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error find products", o11y.Attributes{Key: "error", Value: err})
+		return nil, err
+	}
+
+	request, err := factories.CreateShippingRequest(input, products, defaultCurrency(u.config))
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error create shipping request", o11y.Attributes{Key: "error", Value: err})
+		return nil, err
+	}
+
+	output := make([]*dtos.ShippingOptionOutput, 0)
+	if u.rates == nil {
+		return output, nil
+	}
+
+	options, err := u.rates.Quote(ctx, request)
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error quote shipping", o11y.Attributes{Key: "error", Value: err})
+		return nil, err
+	}
+
+	for _, option := range options {
+		if option.Currency != request.Currency {
+			continue
+		}
+
+		output = append(output, &dtos.ShippingOptionOutput{
+			Code:          option.Code,
+			Carrier:       option.Carrier,
+			Service:       option.Service,
+			Amount:        option.Amount,
+			Currency:      option.Currency.String(),
+			EstimatedDays: option.EstimatedDays,
+		})
+	}
+	return output, nil
+}
+
+// applyShipping charges the option chosen under code, quoted again for the
+// order as it stands so the amount follows its items. Orders ship for free
+// only when no rate provider is configured.
+func applyShipping(
+	ctx context.Context,
+	catalog interfaces.CatalogClient,
+	rates interfaces.ShippingRateProvider,
+	order *entities.Order,
+	products map[string]*entities.Product,
+	code string,
+) error {
+	code = strings.TrimSpace(code)
+	if rates == nil {
+		if code != "" {
+			return entities.ErrShippingOptionNotFound
+		}
+		return nil
+	}
+
+	if code == "" {
+		return entities.ErrShippingOptionRequired
+	}
+
+	if products == nil {
+		found, err := catalog.FindProducts(ctx, factories.OrderSKUs(order))
+		if err != nil {
+			return err
+		}
+		products = found
+	}
+
+	request, err := factories.CreateOrderShippingRequest(order, products)
+	if err != nil {
+		return err
+	}
+
+	options, err := rates.Quote(ctx, request)
+	if err != nil {
+		return err
+	}
+
+	option := entities.FindShippingOption(options, code, order.Currency)
+	if option == nil {
+		return entities.ErrShippingOptionNotFound
+	}
+
+	order.SetShipping(option)
+	return nil
+}
@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	"github.com/jailtonjunior94/order/internal/order/domain/interfaces"
+	"github.com/jailtonjunior94/order/internal/order/domain/vos"
+)
+
+type (
+	fakeCatalogClient struct {
+		products map[string]*entities.Product
+	}
+
+	fakeShippingRateProvider struct {
+		options  []*entities.ShippingOption
+		requests []*entities.ShippingRequest
+	}
+)
+
+func (c fakeCatalogClient) FindProducts(context.Context, []string) (map[string]*entities.Product, error) {
+	return c.products, nil
+}
+
+func (p *fakeShippingRateProvider) Quote(_ context.Context, request *entities.ShippingRequest) ([]*entities.ShippingOption, error) {
+	p.requests = append(p.requests, request)
+	return p.options, nil
+}
+
+func TestApplyShipping(t *testing.T) {
+	rates := &fakeShippingRateProvider{options: []*entities.ShippingOption{
+		{Code: "standard", Carrier: "Correios", Amount: 15, Currency: vos.CurrencyBRL},
+		{Code: "express", Carrier: "Loggi", Amount: 40, Currency: vos.CurrencyUSD},
+	}}
+
+	tests := []struct {
+		name           string
+		rates          interfaces.ShippingRateProvider
+		code           string
+		expectedAmount float64
+		expectedErr    error
+	}{
+		{name: "chosen option", rates: rates, code: " standard ", expectedAmount: 15},
+		{name: "option in another currency", rates: rates, code: "express", expectedErr: entities.ErrShippingOptionNotFound},
+		{name: "no option while rates are configured", rates: rates, expectedErr: entities.ErrShippingOptionRequired},
+		{name: "free shipping without rates", expectedAmount: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order, _ := newSagaFixture(t)
+			order.SetAddresses(&vos.Address{Street: "Av. Paulista", City: "São Paulo", PostalCode: "01310-100", Country: "BR"}, nil)
+			catalog := fakeCatalogClient{products: map[string]*entities.Product{
+				"SKU-1": {SKU: "SKU-1", Price: 100, Weight: 1},
+				"SKU-2": {SKU: "SKU-2", Price: 50, Weight: 0.2},
+			}}
+
+			err := applyShipping(context.Background(), catalog, tt.rates, order, nil, tt.code)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("applyShipping() error = %v, want %v", err, tt.expectedErr)
+			}
+
+			if order.ShippingAmount != tt.expectedAmount {
+				t.Errorf("shipping = %v, want %v", order.ShippingAmount, tt.expectedAmount)
+			}
+		})
+	}
+}
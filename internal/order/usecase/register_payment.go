@@ -0,0 +1,139 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/dtos"
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	"github.com/jailtonjunior94/order/internal/order/domain/factories"
+	"github.com/jailtonjunior94/order/pkg/database/uow"
+	"github.com/jailtonjunior94/order/pkg/o11y"
+	sharedVos "github.com/jailtonjunior94/order/pkg/vos"
+)
+
+type (
+	RegisterPaymentUseCase interface {
+		Execute(ctx context.Context, orderID sharedVos.UUID, input *dtos.PaymentInput) (*dtos.OrderPaymentOutput, error)
+	}
+
+	registerPaymentUseCase struct {
+		uow  uow.UnitOfWork
+		o11y o11y.Observability
+	}
+)
+
+func NewRegisterPaymentUseCase(
+	uow uow.UnitOfWork,
+	o11y o11y.Observability,
+) RegisterPaymentUseCase {
+	return &registerPaymentUseCase{
+		uow:  uow,
+		o11y: o11y,
+	}
+}
+
+// Execute registers at most one payment per provider reference; a repeated
+// reference returns the payment already recorded without changing the order.
+func (u *registerPaymentUseCase) Execute(ctx context.Context, orderID sharedVos.UUID, input *dtos.PaymentInput) (*dtos.OrderPaymentOutput, error) {
+	ctx, span := u.o11y.Start(ctx, "register_payment_usecase.execute")
+	defer span.End()
+
+	var (
+		order   *entities.Order
+		payment *entities.Payment
+	)
+
+	err := u.uow.Do(ctx, func(ctx context.Context, tx uow.TX) error {
+		orderRepository, err := GetOrderRepository(tx)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error get order repository", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		order, err = orderRepository.Find(ctx, orderID)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error find order", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		if order == nil {
+			return ErrOrderNotFound
+		}
+
+		payment, err = factories.CreatePayment(order, input)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error create payment", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		if existing := order.FindPayment(payment.ProviderReference); existing != nil {
+			payment = existing
+			return nil
+		}
+
+		if err := order.RegisterPayment(payment); err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error register payment", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		if err := orderRepository.InsertPayment(ctx, payment); err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error insert payment", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		if err := orderRepository.Update(ctx, order); err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error update order", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+		return nil
+	})
+
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error register payment", o11y.Attributes{Key: "error", Value: err})
+		return nil, err
+	}
+
+	return &dtos.OrderPaymentOutput{
+		OrderID:     order.ID.String(),
+		OrderStatus: order.Status.String(),
+		PaidAmount:  order.PaidAmount(),
+		Balance:     order.Balance(),
+		Payment:     toPaymentOutput(payment),
+	}, nil
+}
+
+// capturePayment registers an approved payment for amount captured outside
+// the API, zero meaning the balance; a payment already recorded under
+// providerReference is kept as is. The caller updates the order.
+func capturePayment(ctx context.Context, tx uow.TX, order *entities.Order, amount float64, providerReference string) error {
+	if order.FindPayment(providerReference) != nil {
+		return nil
+	}
+
+	payment, err := factories.CreateCapturedPayment(order, amount, providerReference)
+	if err != nil {
+		return err
+	}
+
+	if err := order.RegisterPayment(payment); err != nil {
+		return err
+	}
+
+	orderRepository, err := GetOrderRepository(tx)
+	if err != nil {
+		return err
+	}
+	return orderRepository.InsertPayment(ctx, payment)
+}
+
+func toPaymentOutput(payment *entities.Payment) *dtos.PaymentOutput {
+	return &dtos.PaymentOutput{
+		ID:                payment.ID.String(),
+		Amount:            payment.Amount,
+		Currency:          payment.Currency.String(),
+		Method:            payment.Method.String(),
+		ProviderReference: payment.ProviderReference,
+		Status:            payment.Status.String(),
+		CreatedAt:         payment.CreatedAt,
+	}
+}
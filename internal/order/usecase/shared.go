@@ -1,18 +1,31 @@
 package usecase
 
 import (
+	"context"
 	"errors"
 
 	"github.com/jailtonjunior94/order/internal/order/domain/interfaces"
+	"github.com/jailtonjunior94/order/internal/order/domain/vos"
 	"github.com/jailtonjunior94/order/pkg/database/uow"
 )
 
 const (
-	OrderRepository = "OrderRepository"
+	OrderRepository            = "OrderRepository"
+	OutboxRepository           = "OutboxRepository"
+	ProcessedMessageRepository = "ProcessedMessageRepository"
+	OrderSagaRepository        = "OrderSagaRepository"
+	CouponRepository           = "CouponRepository"
+	OrderReturnRepository      = "OrderReturnRepository"
+	ShipmentRepository         = "ShipmentRepository"
 )
 
 var (
 	ErrInvalidRepositoryType = errors.New("invalid repository type")
+	ErrOrderNotFound         = errors.New("order not found")
+	ErrSagaNotFound          = errors.New("order saga not found")
+	ErrCouponNotFound        = errors.New("coupon not found")
+	ErrReturnNotFound        = errors.New("order return not found")
+	ErrShipmentNotFound      = errors.New("shipment not found")
 )
 
 func GetOrderRepository(tx uow.TX) (interfaces.OrderRepository, error) {
@@ -40,3 +53,82 @@ func GetOutboxRepository(tx uow.TX) (interfaces.OutboxRepository, error) {
 	}
 	return outboxRepository, nil
 }
+
+func GetProcessedMessageRepository(tx uow.TX) (interfaces.ProcessedMessageRepository, error) {
+	repository, err := tx.Get(ProcessedMessageRepository)
+	if err != nil {
+		return nil, err
+	}
+
+	processedMessageRepository, ok := repository.(interfaces.ProcessedMessageRepository)
+	if !ok {
+		return nil, ErrInvalidRepositoryType
+	}
+	return processedMessageRepository, nil
+}
+
+func GetOrderSagaRepository(tx uow.TX) (interfaces.OrderSagaRepository, error) {
+	repository, err := tx.Get(OrderSagaRepository)
+	if err != nil {
+		return nil, err
+	}
+
+	orderSagaRepository, ok := repository.(interfaces.OrderSagaRepository)
+	if !ok {
+		return nil, ErrInvalidRepositoryType
+	}
+	return orderSagaRepository, nil
+}
+
+func GetCouponRepository(tx uow.TX) (interfaces.CouponRepository, error) {
+	repository, err := tx.Get(CouponRepository)
+	if err != nil {
+		return nil, err
+	}
+
+	couponRepository, ok := repository.(interfaces.CouponRepository)
+	if !ok {
+		return nil, ErrInvalidRepositoryType
+	}
+	return couponRepository, nil
+}
+
+func GetOrderReturnRepository(tx uow.TX) (interfaces.OrderReturnRepository, error) {
+	repository, err := tx.Get(OrderReturnRepository)
+	if err != nil {
+		return nil, err
+	}
+
+	orderReturnRepository, ok := repository.(interfaces.OrderReturnRepository)
+	if !ok {
+		return nil, ErrInvalidRepositoryType
+	}
+	return orderReturnRepository, nil
+}
+
+func GetShipmentRepository(tx uow.TX) (interfaces.ShipmentRepository, error) {
+	repository, err := tx.Get(ShipmentRepository)
+	if err != nil {
+		return nil, err
+	}
+
+	shipmentRepository, ok := repository.(interfaces.ShipmentRepository)
+	if !ok {
+		return nil, ErrInvalidRepositoryType
+	}
+	return shipmentRepository, nil
+}
+
+func registerProcessedMessage(ctx context.Context, tx uow.TX, consumer, messageID string) (bool, error) {
+	processedMessageRepository, err := GetProcessedMessageRepository(tx)
+	if err != nil {
+		return false, err
+	}
+	return processedMessageRepository.Register(ctx, consumer, messageID)
+}
+
+// paymentMessageID keys payment idempotency on the payment and its outcome, so
+// an approval that follows a decline for the same payment is still applied.
+func paymentMessageID(paymentID string, status vos.PaymentStatus) string {
+	return paymentID + ":" + status.String()
+}
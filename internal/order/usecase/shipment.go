@@ -0,0 +1,259 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/jailtonjunior94/order/internal/order/domain/dtos"
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	"github.com/jailtonjunior94/order/internal/order/domain/factories"
+	"github.com/jailtonjunior94/order/internal/order/domain/vos"
+	"github.com/jailtonjunior94/order/pkg/database/uow"
+	"github.com/jailtonjunior94/order/pkg/o11y"
+	sharedVos "github.com/jailtonjunior94/order/pkg/vos"
+)
+
+const maxStatusDescriptionLength = 255
+
+type (
+	ShipmentUseCase interface {
+		Create(ctx context.Context, orderID sharedVos.UUID, input *dtos.ShipmentInput) (*dtos.ShipmentOutput, error)
+		List(ctx context.Context, orderID sharedVos.UUID) ([]*dtos.ShipmentOutput, error)
+		Show(ctx context.Context, shipmentID sharedVos.UUID) (*dtos.ShipmentOutput, error)
+		CarrierUpdate(ctx context.Context, input *dtos.CarrierUpdateInput) (*dtos.ShipmentOutput, error)
+	}
+
+	shipmentUseCase struct {
+		uow  uow.UnitOfWork
+		o11y o11y.Observability
+	}
+)
+
+func NewShipmentUseCase(
+	uow uow.UnitOfWork,
+	o11y o11y.Observability,
+) ShipmentUseCase {
+	return &shipmentUseCase{
+		uow:  uow,
+		o11y: o11y,
+	}
+}
+
+func (u *shipmentUseCase) Create(ctx context.Context, orderID sharedVos.UUID, input *dtos.ShipmentInput) (*dtos.ShipmentOutput, error) {
+	ctx, span := u.o11y.Start(ctx, "shipment_usecase.create")
+	defer span.End()
+
+	var shipment *entities.Shipment
+	err := u.uow.Do(ctx, func(ctx context.Context, tx uow.TX) error {
+		order, err := findOrder(ctx, tx, orderID)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error find order", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		shipmentRepository, err := GetShipmentRepository(tx)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error get shipment repository", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		shipments, err := shipmentRepository.FindByOrder(ctx, order.ID)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error find shipments", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		shipment, err = factories.CreateShipment(order, shipments, input)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error create shipment", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		if err := shipmentRepository.Insert(ctx, shipment); err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error insert shipment", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+		return updateFulfillment(ctx, tx, order, append(shipments, shipment))
+	})
+
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error create shipment", o11y.Attributes{Key: "error", Value: err})
+		return nil, err
+	}
+	return toShipmentOutput(shipment), nil
+}
+
+func (u *shipmentUseCase) List(ctx context.Context, orderID sharedVos.UUID) ([]*dtos.ShipmentOutput, error) {
+	ctx, span := u.o11y.Start(ctx, "shipment_usecase.list")
+	defer span.End()
+
+	output := make([]*dtos.ShipmentOutput, 0)
+	err := u.uow.Do(ctx, func(ctx context.Context, tx uow.TX) error {
+		if _, err := findOrder(ctx, tx, orderID); err != nil {
+			return err
+		}
+
+		shipmentRepository, err := GetShipmentRepository(tx)
+		if err != nil {
+			return err
+		}
+
+		shipments, err := shipmentRepository.FindByOrder(ctx, orderID)
+		if err != nil {
+			return err
+		}
+
+		for _, shipment := range shipments {
+			output = append(output, toShipmentOutput(shipment))
+		}
+		return nil
+	})
+
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error list shipments", o11y.Attributes{Key: "error", Value: err})
+		return nil, err
+	}
+	return output, nil
+}
+
+func (u *shipmentUseCase) Show(ctx context.Context, shipmentID sharedVos.UUID) (*dtos.ShipmentOutput, error) {
+	ctx, span := u.o11y.Start(ctx, "shipment_usecase.show")
+	defer span.End()
+
+	var shipment *entities.Shipment
+	err := u.uow.Do(ctx, func(ctx context.Context, tx uow.TX) error {
+		shipmentRepository, err := GetShipmentRepository(tx)
+		if err != nil {
+			return err
+		}
+
+		shipment, err = shipmentRepository.Find(ctx, shipmentID)
+		if err != nil {
+			return err
+		}
+
+		if shipment == nil {
+			return ErrShipmentNotFound
+		}
+		return nil
+	})
+
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error find shipment", o11y.Attributes{Key: "error", Value: err})
+		return nil, err
+	}
+	return toShipmentOutput(shipment), nil
+}
+
+// CarrierUpdate applies a carrier webhook to the shipment it tracks. Repeated
+// and out of order updates leave the shipment as it is, so carriers may
+// redeliver them safely.
+func (u *shipmentUseCase) CarrierUpdate(ctx context.Context, input *dtos.CarrierUpdateInput) (*dtos.ShipmentOutput, error) {
+	ctx, span := u.o11y.Start(ctx, "shipment_usecase.carrier_update")
+	defer span.End()
+
+	if input == nil {
+		return nil, entities.ErrInvalidShipmentStatus
+	}
+
+	occurredAt := time.Now().UTC()
+	if input.OccurredAt != nil {
+		occurredAt = input.OccurredAt.UTC()
+	}
+
+	description := strings.TrimSpace(input.Description)
+	if runes := []rune(description); len(runes) > maxStatusDescriptionLength {
+		description = string(runes[:maxStatusDescriptionLength])
+	}
+
+	var shipment *entities.Shipment
+	err := u.uow.Do(ctx, func(ctx context.Context, tx uow.TX) error {
+		shipmentRepository, err := GetShipmentRepository(tx)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error get shipment repository", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		shipment, err = shipmentRepository.FindByTracking(ctx, factories.NormalizeCarrier(input.Carrier), strings.TrimSpace(input.TrackingNumber))
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error find shipment", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		if shipment == nil {
+			return ErrShipmentNotFound
+		}
+
+		changed, err := shipment.UpdateStatus(vos.ShipmentStatus(strings.ToUpper(strings.TrimSpace(input.Status))), description, occurredAt)
+		if err != nil || !changed {
+			return err
+		}
+
+		if err := shipmentRepository.Update(ctx, shipment); err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error update shipment", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		if !shipment.IsDelivered() {
+			return nil
+		}
+
+		order, err := findOrder(ctx, tx, shipment.OrderID)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error find order", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+
+		shipments, err := shipmentRepository.FindByOrder(ctx, order.ID)
+		if err != nil {
+			span.AddAttributes(ctx, o11y.Error, "error find shipments", o11y.Attributes{Key: "error", Value: err})
+			return err
+		}
+		return updateFulfillment(ctx, tx, order, shipments)
+	})
+
+	if err != nil {
+		span.AddAttributes(ctx, o11y.Error, "error apply carrier update", o11y.Attributes{Key: "error", Value: err})
+		return nil, err
+	}
+	return toShipmentOutput(shipment), nil
+}
+
+// updateFulfillment persists the order only when its shipments moved it to a
+// new status.
+func updateFulfillment(ctx context.Context, tx uow.TX, order *entities.Order, shipments []*entities.Shipment) error {
+	status := order.Status
+	order.UpdateFulfillment(shipments)
+	if order.Status == status {
+		return nil
+	}
+
+	orderRepository, err := GetOrderRepository(tx)
+	if err != nil {
+		return err
+	}
+	return orderRepository.Update(ctx, order)
+}
+
+func toShipmentOutput(shipment *entities.Shipment) *dtos.ShipmentOutput {
+	output := &dtos.ShipmentOutput{
+		ID:                shipment.ID.String(),
+		OrderID:           shipment.OrderID.String(),
+		Carrier:           shipment.Carrier,
+		TrackingNumber:    shipment.TrackingNumber,
+		Status:            shipment.Status.String(),
+		StatusDescription: shipment.StatusDescription,
+		Lines:             make([]*dtos.ShipmentLineOutput, 0, len(shipment.Lines)),
+		DeliveredAt:       shipment.DeliveredAt.Time,
+		CreatedAt:         shipment.CreatedAt,
+	}
+
+	for _, line := range shipment.Lines {
+		output.Lines = append(output.Lines, &dtos.ShipmentLineOutput{
+			OrderItemID: line.OrderItemID.String(),
+			Quantity:    line.Quantity,
+		})
+	}
+	return output
+}
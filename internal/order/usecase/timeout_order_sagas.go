@@ -0,0 +1,139 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/jailtonjunior94/order/configs"
+	"github.com/jailtonjunior94/order/internal/order/domain/entities"
+	"github.com/jailtonjunior94/order/internal/order/domain/interfaces"
+	"github.com/jailtonjunior94/order/internal/order/domain/vos"
+	"github.com/jailtonjunior94/order/pkg/database/uow"
+	"github.com/jailtonjunior94/order/pkg/o11y"
+)
+
+const defaultSagaTimeoutBatchSize = 100
+
+type (
+	TimeoutOrderSagasUseCase interface {
+		Execute(ctx context.Context) (int64, error)
+	}
+
+	timeoutOrderSagasUseCase struct {
+		config    *configs.Config
+		uow       uow.UnitOfWork
+		inventory interfaces.InventoryClient
+		o11y      o11y.Observability
+	}
+)
+
+func NewTimeoutOrderSagasUseCase(
+	config *configs.Config,
+	uow uow.UnitOfWork,
+	inventory interfaces.InventoryClient,
+	o11y o11y.Observability,
+) TimeoutOrderSagasUseCase {
+	return &timeoutOrderSagasUseCase{
+		uow:       uow,
+		o11y:      o11y,
+		config:    config,
+		inventory: inventory,
+	}
+}
+
+func (u *timeoutOrderSagasUseCase) Execute(ctx context.Context) (int64, error) {
+	ctx, span := u.o11y.Start(ctx, "timeout_order_sagas_usecase.execute")
+	defer span.End()
+
+	now := time.Now().UTC()
+
+	var timedOut int64
+	for {
+		var (
+			handled        int
+			reservationIDs []string
+		)
+		err := u.uow.Do(ctx, func(ctx context.Context, tx uow.TX) error {
+			orderSagaRepository, err := GetOrderSagaRepository(tx)
+			if err != nil {
+				span.AddAttributes(ctx, o11y.Error, "error get order saga repository", o11y.Attributes{Key: "error", Value: err})
+				return err
+			}
+
+			orderRepository, err := GetOrderRepository(tx)
+			if err != nil {
+				span.AddAttributes(ctx, o11y.Error, "error get order repository", o11y.Attributes{Key: "error", Value: err})
+				return err
+			}
+
+			sagas, err := orderSagaRepository.FindTimedOut(ctx, now, defaultSagaTimeoutBatchSize)
+			if err != nil {
+				span.AddAttributes(ctx, o11y.Error, "error find timed out sagas", o11y.Attributes{Key: "error", Value: err})
+				return err
+			}
+
+			for _, saga := range sagas {
+				compensating := saga.Status == vos.SagaCompensating
+				if compensating {
+					err = saga.TimeOut(sagaDeadline(u.config))
+				} else {
+					err = compensateSaga(u.inventory, saga, entities.SagaTimeoutReason, sagaDeadline(u.config))
+				}
+
+				if err != nil {
+					span.AddAttributes(ctx, o11y.Error, "error time out saga", o11y.Attributes{Key: "error", Value: err})
+					return err
+				}
+
+				if err := orderSagaRepository.Update(ctx, saga); err != nil {
+					span.AddAttributes(ctx, o11y.Error, "error update order saga", o11y.Attributes{Key: "error", Value: err})
+					return err
+				}
+
+				if compensating {
+					continue
+				}
+				reservationIDs = append(reservationIDs, pendingRelease(u.inventory, saga))
+
+				order, err := orderRepository.Find(ctx, saga.OrderID)
+				if err != nil {
+					span.AddAttributes(ctx, o11y.Error, "error find order", o11y.Attributes{Key: "error", Value: err})
+					return err
+				}
+
+				if order == nil || order.Status != vos.StatusPending {
+					continue
+				}
+
+				if err := cancelOrder(order, entities.SagaTimeoutReason); err != nil {
+					span.AddAttributes(ctx, o11y.Error, "error cancel order", o11y.Attributes{Key: "error", Value: err})
+					return err
+				}
+
+				if err := orderRepository.Update(ctx, order); err != nil {
+					span.AddAttributes(ctx, o11y.Error, "error update order", o11y.Attributes{Key: "error", Value: err})
+					return err
+				}
+
+				if err := returnCoupon(ctx, tx, order); err != nil {
+					span.AddAttributes(ctx, o11y.Error, "error return coupon", o11y.Attributes{Key: "error", Value: err})
+					return err
+				}
+			}
+
+			handled = len(sagas)
+			return nil
+		})
+
+		if err != nil {
+			return timedOut, err
+		}
+		releaseStock(ctx, u.inventory, u.o11y, reservationIDs...)
+
+		timedOut += int64(handled)
+		if handled < defaultSagaTimeoutBatchSize {
+			span.AddAttributes(ctx, o11y.Ok, "", o11y.Attributes{Key: "timed_out", Value: timedOut})
+			return timedOut, nil
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package audit
+
+import "context"
+
+// SystemActor is recorded for changes made outside a request, such as jobs and
+// message consumers.
+const SystemActor = "system"
+
+type (
+	metadataKey struct{}
+
+	Metadata struct {
+		Actor     string
+		RequestID string
+	}
+)
+
+func WithMetadata(ctx context.Context, metadata Metadata) context.Context {
+	return context.WithValue(ctx, metadataKey{}, metadata)
+}
+
+// FromContext returns who is acting in ctx, defaulting the actor to
+// SystemActor.
+func FromContext(ctx context.Context) Metadata {
+	metadata, _ := ctx.Value(metadataKey{}).(Metadata)
+	if metadata.Actor == "" {
+		metadata.Actor = SystemActor
+	}
+	return metadata
+}
@@ -0,0 +1,62 @@
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+type (
+	TTLCache[K comparable, V any] struct {
+		mu      sync.RWMutex
+		ttl     time.Duration
+		now     func() time.Time
+		entries map[K]entry[V]
+	}
+
+	entry[V any] struct {
+		value     V
+		expiresAt time.Time
+	}
+)
+
+func NewTTLCache[K comparable, V any](ttl time.Duration) *TTLCache[K, V] {
+	return &TTLCache[K, V]{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[K]entry[V]),
+	}
+}
+
+func (c *TTLCache[K, V]) Get(key K) (V, bool) {
+	c.mu.RLock()
+	cached, ok := c.entries[key]
+	c.mu.RUnlock()
+
+	if !ok || !c.now().Before(cached.expiresAt) {
+		var zero V
+		return zero, false
+	}
+	return cached.value, true
+}
+
+// Set stores value and opportunistically evicts expired entries so the map
+// does not grow with keys that are never read again.
+func (c *TTLCache[K, V]) Set(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	for cachedKey, cached := range c.entries {
+		if !now.Before(cached.expiresAt) {
+			delete(c.entries, cachedKey)
+		}
+	}
+	c.entries[key] = entry[V]{value: value, expiresAt: now.Add(c.ttl)}
+}
+
+func (c *TTLCache[K, V]) Delete(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, key)
+}
@@ -0,0 +1,36 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTTLCache(t *testing.T) {
+	now := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+	cache := NewTTLCache[string, int](time.Minute)
+	cache.now = func() time.Time { return now }
+
+	cache.Set("fresh", 1)
+	if value, ok := cache.Get("fresh"); !ok || value != 1 {
+		t.Fatalf("Get() = %v, %v; want 1, true", value, ok)
+	}
+
+	if _, ok := cache.Get("unknown"); ok {
+		t.Error("expected an unknown key to miss")
+	}
+
+	now = now.Add(time.Minute)
+	if _, ok := cache.Get("fresh"); ok {
+		t.Error("expected the entry to expire after the ttl")
+	}
+
+	cache.Set("other", 2)
+	if _, ok := cache.entries["fresh"]; ok {
+		t.Error("expected Set to evict expired entries")
+	}
+
+	cache.Delete("other")
+	if _, ok := cache.Get("other"); ok {
+		t.Error("expected a deleted entry to miss")
+	}
+}
@@ -0,0 +1,217 @@
+package lease
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+var ErrLeaseLost = errors.New("lease lost")
+
+const DefaultTTL = 30 * time.Second
+
+type (
+	Locker interface {
+		Holder() string
+		Acquire(ctx context.Context, name string, ttl time.Duration) (*Lease, error)
+		Renew(ctx context.Context, lease *Lease, ttl time.Duration) error
+		Release(ctx context.Context, lease *Lease) error
+	}
+
+	// Lease is held until it expires or is released and is not reentrant.
+	// Token is a fencing token that strictly increases on every acquisition;
+	// Fence checks it inside the transactions made under the lease.
+	Lease struct {
+		Name   string
+		Holder string
+		Token  int64
+	}
+
+	locker struct {
+		db     *sql.DB
+		holder string
+	}
+
+	leaseKey struct{}
+)
+
+func NewLocker(db *sql.DB) Locker {
+	return &locker{db: db, holder: newHolder()}
+}
+
+func (l *locker) Holder() string {
+	return l.holder
+}
+
+func (l *locker) Acquire(ctx context.Context, name string, ttl time.Duration) (*Lease, error) {
+	query := `insert into
+				leases (name, holder, token, expires_at)
+			  values
+				($1, $2, 1, now() + $3 * interval '1 millisecond')
+			  on conflict (name) do update set
+				holder = excluded.holder,
+				token = leases.token + 1,
+				expires_at = excluded.expires_at
+			  where
+				leases.expires_at < now()
+			  returning
+				token`
+
+	var token int64
+	err := l.db.QueryRowContext(ctx, query, name, l.holder, ttl.Milliseconds()).Scan(&token)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &Lease{Name: name, Holder: l.holder, Token: token}, nil
+}
+
+func (l *locker) Renew(ctx context.Context, lease *Lease, ttl time.Duration) error {
+	query := `update
+				leases
+			  set
+				expires_at = now() + $1 * interval '1 millisecond'
+			  where
+				name = $2
+				and holder = $3
+				and token = $4`
+
+	result, err := l.db.ExecContext(ctx, query, ttl.Milliseconds(), lease.Name, lease.Holder, lease.Token)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ErrLeaseLost
+	}
+	return nil
+}
+
+// Release expires the lease instead of deleting it so the fencing token keeps
+// increasing for the next holder.
+func (l *locker) Release(ctx context.Context, lease *Lease) error {
+	query := `update
+				leases
+			  set
+				expires_at = now()
+			  where
+				name = $1
+				and holder = $2
+				and token = $3`
+
+	_, err := l.db.ExecContext(ctx, query, lease.Name, lease.Holder, lease.Token)
+	return err
+}
+
+// WithLease runs fn only if the named lease can be acquired, renewing it every
+// ttl/3 while fn runs. The context passed to fn is canceled if the lease is
+// lost and carries the lease for Fence. It reports whether fn was executed.
+func WithLease(ctx context.Context, locker Locker, name string, ttl time.Duration, fn func(ctx context.Context)) (bool, error) {
+	lease, err := locker.Acquire(ctx, name, ttl)
+	if err != nil {
+		return false, err
+	}
+
+	if lease == nil {
+		return false, nil
+	}
+
+	ctx, cancel := context.WithCancel(ContextWithLease(ctx, lease))
+	defer cancel()
+
+	heartbeat := make(chan struct{})
+	go func() {
+		defer close(heartbeat)
+		ticker := time.NewTicker(ttl / 3)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := locker.Renew(ctx, lease, ttl); err != nil {
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+
+	fn(ctx)
+	cancel()
+	<-heartbeat
+
+	return true, locker.Release(context.WithoutCancel(ctx), lease)
+}
+
+// RunAsLeader keeps trying to acquire the named lease and runs fn while it is
+// held, until ctx is canceled. It is meant for long-running singleton loops.
+func RunAsLeader(ctx context.Context, locker Locker, name string, ttl time.Duration, fn func(ctx context.Context)) {
+	ticker := time.NewTicker(ttl / 3)
+	defer ticker.Stop()
+
+	for {
+		_, _ = WithLease(ctx, locker, name, ttl, fn)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func ContextWithLease(ctx context.Context, lease *Lease) context.Context {
+	return context.WithValue(ctx, leaseKey{}, lease)
+}
+
+func FromContext(ctx context.Context) (*Lease, bool) {
+	lease, ok := ctx.Value(leaseKey{}).(*Lease)
+	return lease, ok && lease != nil
+}
+
+// Fence fails with ErrLeaseLost when the lease carried by ctx has been taken
+// over since it was acquired, so a paused former holder cannot write. It
+// locks the lease row until tx ends, which keeps a new holder from acquiring
+// it while the writes are in flight. Without a lease in ctx it does nothing.
+func Fence(ctx context.Context, tx *sql.Tx) error {
+	lease, ok := FromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	query := `select
+				token
+			  from
+				leases
+			  where
+				name = $1
+			  for update`
+
+	var token int64
+	err := tx.QueryRowContext(ctx, query, lease.Name).Scan(&token)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && token != lease.Token) {
+		return ErrLeaseLost
+	}
+	return err
+}
+
+func newHolder() string {
+	host, _ := os.Hostname()
+	suffix := make([]byte, 4)
+	_, _ = rand.Read(suffix)
+	return fmt.Sprintf("%s-%d-%s", host, os.Getpid(), hex.EncodeToString(suffix))
+}
@@ -0,0 +1,249 @@
+package lease
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type (
+	// leaseTable plays the leases table behind a database/sql driver, with a
+	// clock the tests move forward to expire leases.
+	leaseTable struct {
+		mu   sync.Mutex
+		now  time.Time
+		rows map[string]*leaseRow
+	}
+
+	leaseRow struct {
+		holder    string
+		token     int64
+		expiresAt time.Time
+	}
+
+	leaseDriver struct{}
+
+	leaseConn struct {
+		table *leaseTable
+	}
+
+	tokenRows struct {
+		tokens []int64
+	}
+)
+
+var (
+	tablesMu sync.Mutex
+	tables   = make(map[string]*leaseTable)
+)
+
+func init() {
+	sql.Register("leases", leaseDriver{})
+}
+
+func (leaseDriver) Open(name string) (driver.Conn, error) {
+	tablesMu.Lock()
+	defer tablesMu.Unlock()
+	return &leaseConn{table: tables[name]}, nil
+}
+
+func (c *leaseConn) Prepare(string) (driver.Stmt, error) {
+	return nil, driver.ErrSkip
+}
+
+func (c *leaseConn) Close() error {
+	return nil
+}
+
+func (c *leaseConn) Begin() (driver.Tx, error) {
+	return c, nil
+}
+
+func (c *leaseConn) Commit() error {
+	return nil
+}
+
+func (c *leaseConn) Rollback() error {
+	return nil
+}
+
+func (c *leaseConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	t := c.table
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// Renew sends the ttl first, Release expires the lease right away.
+	expiresAt := t.now
+	if strings.Contains(strings.Join(strings.Fields(query), " "), "expires_at = now() + ") {
+		expiresAt = t.now.Add(time.Duration(args[0].Value.(int64)) * time.Millisecond)
+		args = args[1:]
+	}
+
+	row, ok := t.rows[args[0].Value.(string)]
+	if !ok || row.holder != args[1].Value.(string) || row.token != args[2].Value.(int64) {
+		return driver.RowsAffected(0), nil
+	}
+
+	row.expiresAt = expiresAt
+	return driver.RowsAffected(1), nil
+}
+
+func (c *leaseConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	t := c.table
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	name := args[0].Value.(string)
+	row, ok := t.rows[name]
+	if strings.HasPrefix(strings.TrimSpace(query), "select") {
+		if !ok {
+			return &tokenRows{}, nil
+		}
+		return &tokenRows{tokens: []int64{row.token}}, nil
+	}
+
+	if ok && !row.expiresAt.Before(t.now) {
+		return &tokenRows{}, nil
+	}
+
+	if !ok {
+		row = &leaseRow{}
+		t.rows[name] = row
+	}
+	row.holder = args[1].Value.(string)
+	row.token++
+	row.expiresAt = t.now.Add(time.Duration(args[2].Value.(int64)) * time.Millisecond)
+	return &tokenRows{tokens: []int64{row.token}}, nil
+}
+
+func (r *tokenRows) Columns() []string {
+	return []string{"token"}
+}
+
+func (r *tokenRows) Close() error {
+	return nil
+}
+
+func (r *tokenRows) Next(dest []driver.Value) error {
+	if len(r.tokens) == 0 {
+		return io.EOF
+	}
+	dest[0], r.tokens = r.tokens[0], r.tokens[1:]
+	return nil
+}
+
+func newLeaseTable(t *testing.T) (*sql.DB, *leaseTable) {
+	t.Helper()
+
+	table := &leaseTable{now: time.Now(), rows: make(map[string]*leaseRow)}
+	tablesMu.Lock()
+	tables[t.Name()] = table
+	tablesMu.Unlock()
+
+	db, err := sql.Open("leases", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	return db, table
+}
+
+func (t *leaseTable) advance(d time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.now = t.now.Add(d)
+}
+
+func TestLockerAcquireRenewAndSteal(t *testing.T) {
+	ctx := context.Background()
+	db, table := newLeaseTable(t)
+	first, second := NewLocker(db), NewLocker(db)
+
+	held, err := first.Acquire(ctx, "job:expire_orders", time.Minute)
+	if err != nil || held == nil {
+		t.Fatalf("Acquire() = %v, %v, want a lease", held, err)
+	}
+
+	if lease, err := second.Acquire(ctx, "job:expire_orders", time.Minute); err != nil || lease != nil {
+		t.Fatalf("Acquire() while held = %v, %v, want no lease", lease, err)
+	}
+
+	table.advance(45 * time.Second)
+	if err := first.Renew(ctx, held, time.Minute); err != nil {
+		t.Fatalf("Renew() error = %v", err)
+	}
+
+	table.advance(45 * time.Second)
+	if lease, _ := second.Acquire(ctx, "job:expire_orders", time.Minute); lease != nil {
+		t.Fatal("expected the renewed lease to still be held")
+	}
+
+	table.advance(time.Minute)
+	stolen, err := second.Acquire(ctx, "job:expire_orders", time.Minute)
+	if err != nil || stolen == nil {
+		t.Fatalf("Acquire() after expiry = %v, %v, want a lease", stolen, err)
+	}
+
+	if stolen.Token <= held.Token {
+		t.Errorf("token = %d, want more than %d", stolen.Token, held.Token)
+	}
+
+	if err := first.Renew(ctx, held, time.Minute); !errors.Is(err, ErrLeaseLost) {
+		t.Errorf("Renew() by the former holder error = %v, want %v", err, ErrLeaseLost)
+	}
+
+	if err := first.Release(ctx, held); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := second.Renew(ctx, stolen, time.Minute); err != nil {
+		t.Errorf("expected the former holder release to leave the new lease, Renew() error = %v", err)
+	}
+}
+
+func TestFence(t *testing.T) {
+	ctx := context.Background()
+	db, table := newLeaseTable(t)
+
+	stale, err := NewLocker(db).Acquire(ctx, "job:expire_orders", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	table.advance(2 * time.Minute)
+	current, err := NewLocker(db).Acquire(ctx, "job:expire_orders", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		expectedErr error
+	}{
+		{name: "current token", ctx: ContextWithLease(ctx, current)},
+		{name: "stale token", ctx: ContextWithLease(ctx, stale), expectedErr: ErrLeaseLost},
+		{name: "unknown lease", ctx: ContextWithLease(ctx, &Lease{Name: "job:other", Token: 1}), expectedErr: ErrLeaseLost},
+		{name: "without a lease", ctx: ctx},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, err := db.Begin()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer func() { _ = tx.Rollback() }()
+
+			if err := Fence(tt.ctx, tx); !errors.Is(err, tt.expectedErr) {
+				t.Errorf("Fence() error = %v, want %v", err, tt.expectedErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package migrate
+
+import (
+	"database/sql"
+
+	"github.com/golang-migrate/migrate/v4"
+	postgresMigrate "github.com/golang-migrate/migrate/v4/database/postgres"
+)
+
+// postgresMigrationsTable keeps the PostgreSQL-only migrations apart from the
+// versions of the shared ones.
+const postgresMigrationsTable = "schema_migrations_postgres"
+
+func NewMigratePostgres(db *sql.DB, migratePath, dbName string) (Migrate, error) {
+	if db == nil {
+		return nil, ErrDatabaseConnection
+	}
+
+	driver, err := postgresMigrate.WithInstance(db, &postgresMigrate.Config{MigrationsTable: postgresMigrationsTable})
+	if err != nil {
+		return nil, ErrUnableToCreateDriver
+	}
+
+	migrateInstance, err := migrate.NewWithDatabaseInstance(migratePath, dbName, driver)
+	if err != nil {
+		return nil, err
+	}
+	return &migration{migrate: migrateInstance}, nil
+}
@@ -0,0 +1,48 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"time"
+
+	"github.com/jailtonjunior94/order/configs"
+
+	"github.com/lib/pq"
+)
+
+func NewListener(config *configs.Config, channel string) (*pq.Listener, error) {
+	listener := pq.NewListener(dsn(config), 10*time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Printf("listener %s: %v", channel, err)
+		}
+	})
+
+	if err := listener.Listen(channel); err != nil {
+		listener.Close()
+		return nil, err
+	}
+	return listener, nil
+}
+
+// HasTrigger reports whether trigger is installed on table. Triggers are
+// created by the PostgreSQL-only migrations, never at runtime.
+func HasTrigger(ctx context.Context, db *sql.DB, table, trigger string) (bool, error) {
+	query := `select
+				exists (
+					select
+						1
+					from
+						pg_trigger t
+						inner join pg_class c on c.oid = t.tgrelid
+					where
+						c.relname = $1
+						and t.tgname = $2
+				)`
+
+	var exists bool
+	if err := db.QueryRowContext(ctx, query, table, trigger).Scan(&exists); err != nil {
+		return false, err
+	}
+	return exists, nil
+}
@@ -7,9 +7,16 @@ import (
 
 	"github.com/jailtonjunior94/order/configs"
 
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 )
 
+// uniqueViolation is the SQLSTATE both engines report for duplicate keys.
+const uniqueViolation = "23505"
+
+// EnginePostgres marks a PostgreSQL server; CockroachDB, the default engine,
+// speaks the same wire protocol but has no LISTEN/NOTIFY or triggers.
+const EnginePostgres = "postgres"
+
 var (
 	ErrSQLOpenConn = errors.New("unable to open connection with SQL database")
 )
@@ -38,3 +45,9 @@ func dsn(config *configs.Config) string {
 		config.DBConfig.Name,
 	)
 }
+
+// IsUniqueViolation reports whether err was caused by a duplicate key.
+func IsUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == uniqueViolation
+}
@@ -0,0 +1,37 @@
+package uow
+
+import (
+	"context"
+
+	"github.com/jailtonjunior94/order/pkg/events"
+)
+
+type (
+	Aggregate interface {
+		Events() []events.Event
+		ClearEvents()
+	}
+
+	Tracker interface {
+		Tracked() []Aggregate
+	}
+
+	EventFlusher func(ctx context.Context, tx TX, events []events.Event) error
+
+	AggregateTracker struct {
+		aggregates []Aggregate
+	}
+)
+
+func (t *AggregateTracker) Track(aggregate Aggregate) {
+	for _, tracked := range t.aggregates {
+		if tracked == aggregate {
+			return
+		}
+	}
+	t.aggregates = append(t.aggregates, aggregate)
+}
+
+func (t *AggregateTracker) Tracked() []Aggregate {
+	return t.aggregates
+}
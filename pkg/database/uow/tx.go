@@ -5,18 +5,38 @@ import "database/sql"
 type transaction struct {
 	tx           *sql.Tx
 	repositories map[RepositoryName]RepositoryFactory
+	instances    map[RepositoryName]Repository
+	resolved     []RepositoryName
 }
 
 func NewTransaction(tx *sql.Tx, repositories map[RepositoryName]RepositoryFactory) *transaction {
 	return &transaction{
 		tx:           tx,
 		repositories: repositories,
+		instances:    make(map[RepositoryName]Repository),
 	}
 }
 
 func (t *transaction) Get(name RepositoryName) (Repository, error) {
+	if instance, ok := t.instances[name]; ok {
+		return instance, nil
+	}
+
 	if repository, ok := t.repositories[name]; ok {
-		return repository(t.tx), nil
+		instance := repository(t.tx)
+		t.instances[name] = instance
+		t.resolved = append(t.resolved, name)
+		return instance, nil
 	}
 	return nil, ErrRepositoryNotRegistered
 }
+
+func (t *transaction) aggregates() []Aggregate {
+	var aggregates []Aggregate
+	for _, name := range t.resolved {
+		if tracker, ok := t.instances[name].(Tracker); ok {
+			aggregates = append(aggregates, tracker.Tracked()...)
+		}
+	}
+	return aggregates
+}
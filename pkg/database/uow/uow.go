@@ -4,11 +4,15 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+
+	"github.com/jailtonjunior94/order/pkg/database/lease"
+	"github.com/jailtonjunior94/order/pkg/events"
 )
 
 var (
 	ErrRepositoryNotRegistered     = errors.New("repository not registered")
 	ErrRepositoryAlreadyRegistered = errors.New("repository already registered")
+	ErrEventFlusherNotRegistered   = errors.New("event flusher not registered")
 )
 
 type Repository any
@@ -24,12 +28,14 @@ type UnitOfWork interface {
 	Remove(name RepositoryName) error
 	Has(name RepositoryName) bool
 	Clear()
+	RegisterEventFlusher(flusher EventFlusher)
 	Do(ctx context.Context, fn func(ctx context.Context, tx TX) error) error
 }
 
 type unitOfWork struct {
 	db           *sql.DB
 	repositories map[RepositoryName]RepositoryFactory
+	flusher      EventFlusher
 }
 
 func NewUnitOfWork(db *sql.DB) *unitOfWork {
@@ -66,6 +72,10 @@ func (u *unitOfWork) Clear() {
 	u.repositories = make(map[RepositoryName]RepositoryFactory)
 }
 
+func (u *unitOfWork) RegisterEventFlusher(flusher EventFlusher) {
+	u.flusher = flusher
+}
+
 func (u *unitOfWork) Do(ctx context.Context, fn func(ctx context.Context, tx TX) error) error {
 	tx, err := u.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -73,11 +83,44 @@ func (u *unitOfWork) Do(ctx context.Context, fn func(ctx context.Context, tx TX)
 	}
 	defer tx.Rollback()
 
-	err = fn(ctx, NewTransaction(tx, u.repositories))
+	if err := lease.Fence(ctx, tx); err != nil {
+		return err
+	}
+
+	transaction := NewTransaction(tx, u.repositories)
+	err = fn(ctx, transaction)
 	if err != nil {
 		return err
 	}
 
+	aggregates := transaction.aggregates()
+	if err := u.flush(ctx, transaction, aggregates); err != nil {
+		return err
+	}
+
 	err = tx.Commit()
-	return err
+	if err != nil {
+		return err
+	}
+
+	for _, aggregate := range aggregates {
+		aggregate.ClearEvents()
+	}
+	return nil
+}
+
+func (u *unitOfWork) flush(ctx context.Context, tx TX, aggregates []Aggregate) error {
+	var pending []events.Event
+	for _, aggregate := range aggregates {
+		pending = append(pending, aggregate.Events()...)
+	}
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	if u.flusher == nil {
+		return ErrEventFlusherNotRegistered
+	}
+	return u.flusher(ctx, tx, pending)
 }
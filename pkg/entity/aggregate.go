@@ -0,0 +1,19 @@
+package entity
+
+import "github.com/jailtonjunior94/order/pkg/events"
+
+type AggregateRoot struct {
+	events []events.Event
+}
+
+func (a *AggregateRoot) AddEvent(event events.Event) {
+	a.events = append(a.events, event)
+}
+
+func (a *AggregateRoot) Events() []events.Event {
+	return a.events
+}
+
+func (a *AggregateRoot) ClearEvents() {
+	a.events = nil
+}
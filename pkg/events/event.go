@@ -0,0 +1,47 @@
+package events
+
+import (
+	"time"
+
+	"github.com/jailtonjunior94/order/pkg/vos"
+)
+
+type event struct {
+	eventType string
+	key       vos.UUID
+	dateTime  time.Time
+	payload   any
+}
+
+func NewEvent(eventType string, key vos.UUID, payload any) Event {
+	return &event{
+		eventType: eventType,
+		key:       key,
+		dateTime:  time.Now().UTC(),
+		payload:   payload,
+	}
+}
+
+func (e *event) GetEventType() string {
+	return e.eventType
+}
+
+func (e *event) GetDateTime() time.Time {
+	return e.dateTime
+}
+
+func (e *event) GetPayload() any {
+	return e.payload
+}
+
+func (e *event) SetPayload(payload any) {
+	e.payload = payload
+}
+
+func (e *event) SetKey(key vos.UUID) {
+	e.key = key
+}
+
+func (e *event) GetKey() []byte {
+	return []byte(e.key.String())
+}
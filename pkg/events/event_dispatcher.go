@@ -3,67 +3,253 @@ package events
 import (
 	"context"
 	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/jailtonjunior94/order/pkg/o11y"
+)
+
+var (
+	ErrHandlerAlreadyRegistered = errors.New("handler already registered")
+	ErrHandlerPanic             = errors.New("handler panic")
+	ErrHandlerNotComparable     = errors.New("handler is not comparable, wrap functions with NewFuncHandler")
+)
+
+type DispatchMode int
+
+const (
+	// ModeFailFast runs handlers in priority order and stops at the first error.
+	ModeFailFast DispatchMode = iota
+	// ModeCollect runs every handler in priority order and joins their errors.
+	ModeCollect
+	// ModeAsync runs handlers in the background, at most workers at a time
+	// across every dispatched event, and reports their errors from Wait.
+	ModeAsync
+)
+
+const defaultWorkers = 10
+
+type (
+	DispatcherOptions func(dispatcher *eventDispatcher)
+
+	registration struct {
+		handler  EventHandler
+		priority int
+	}
+
+	eventDispatcher struct {
+		mu       sync.RWMutex
+		wg       sync.WaitGroup
+		mode     DispatchMode
+		workers  int
+		pool     chan struct{}
+		o11y     o11y.Observability
+		handlers map[string][]registration
+		errsMu   sync.Mutex
+		errs     []error
+	}
 )
 
-var ErrHandlerAlreadyRegistered = errors.New("handler already registered")
+func NewEventDispatcher(options ...DispatcherOptions) EventDispatcher {
+	dispatcher := &eventDispatcher{
+		mode:     ModeFailFast,
+		workers:  defaultWorkers,
+		handlers: make(map[string][]registration),
+	}
+	for _, option := range options {
+		option(dispatcher)
+	}
+	dispatcher.pool = make(chan struct{}, dispatcher.workers)
+	return dispatcher
+}
+
+func WithMode(mode DispatchMode) DispatcherOptions {
+	return func(dispatcher *eventDispatcher) {
+		dispatcher.mode = mode
+	}
+}
 
-type eventDispatcher struct {
-	handlers map[string][]EventHandler
+func WithWorkers(workers int) DispatcherOptions {
+	return func(dispatcher *eventDispatcher) {
+		if workers > 0 {
+			dispatcher.workers = workers
+		}
+	}
 }
 
-func NewEventDispatcher() EventDispatcher {
-	return &eventDispatcher{
-		handlers: make(map[string][]EventHandler),
+func WithObservability(o11y o11y.Observability) DispatcherOptions {
+	return func(dispatcher *eventDispatcher) {
+		dispatcher.o11y = o11y
 	}
 }
 
-func (ev *eventDispatcher) Dispatch(ctx context.Context, event Event) error {
-	if handlers, ok := ev.handlers[event.GetEventType()]; ok {
+func (ed *eventDispatcher) Dispatch(ctx context.Context, event Event) error {
+	handlers := ed.snapshot(event.GetEventType())
+	if len(handlers) == 0 {
+		return nil
+	}
+
+	switch ed.mode {
+	case ModeAsync:
+		ed.dispatchAsync(ctx, event, handlers)
+		return nil
+	case ModeCollect:
+		var errs []error
+		for _, handler := range handlers {
+			if err := ed.handle(ctx, handler, event); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		return errors.Join(errs...)
+	default:
 		for _, handler := range handlers {
-			if err := handler.Handle(ctx, event); err != nil {
+			if err := ed.handle(ctx, handler, event); err != nil {
 				return err
 			}
-			continue
 		}
+		return nil
 	}
-	return nil
 }
 
-func (ed *eventDispatcher) Register(eventName string, handler EventHandler) error {
-	if _, ok := ed.handlers[eventName]; ok {
-		for _, h := range ed.handlers[eventName] {
-			if h == handler {
-				return ErrHandlerAlreadyRegistered
+// dispatchAsync never blocks the caller; every handler runs even when another
+// fails, and the failures are kept until the next Wait. Handlers of every event
+// share the dispatcher pool, so no more than workers run at once.
+func (ed *eventDispatcher) dispatchAsync(ctx context.Context, event Event, handlers []EventHandler) {
+	ctx = context.WithoutCancel(ctx)
+	for _, handler := range handlers {
+		ed.wg.Add(1)
+		go func() {
+			defer ed.wg.Done()
+
+			ed.pool <- struct{}{}
+			defer func() { <-ed.pool }()
+
+			if err := ed.handle(ctx, handler, event); err != nil {
+				ed.errsMu.Lock()
+				ed.errs = append(ed.errs, err)
+				ed.errsMu.Unlock()
 			}
+		}()
+	}
+}
+
+func (ed *eventDispatcher) handle(ctx context.Context, handler EventHandler, event Event) (err error) {
+	var span o11y.Span
+	if ed.o11y != nil {
+		ctx, span = ed.o11y.Start(ctx, "event_dispatcher.handle")
+		defer span.End()
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: %v", ErrHandlerPanic, r)
+		}
+
+		if err != nil && span != nil {
+			span.AddAttributes(ctx, o11y.Error, "error handle event",
+				o11y.Attributes{Key: "event_type", Value: event.GetEventType()},
+				o11y.Attributes{Key: "error", Value: err},
+			)
+		}
+	}()
+	return handler.Handle(ctx, event)
+}
+
+func (ed *eventDispatcher) snapshot(eventName string) []EventHandler {
+	ed.mu.RLock()
+	defer ed.mu.RUnlock()
+
+	registrations := ed.handlers[eventName]
+	handlers := make([]EventHandler, len(registrations))
+	for i, registration := range registrations {
+		handlers[i] = registration.handler
+	}
+	return handlers
+}
+
+func (ed *eventDispatcher) Register(eventName string, handler EventHandler) error {
+	return ed.RegisterWithPriority(eventName, handler, 0)
+}
+
+func (ed *eventDispatcher) RegisterWithPriority(eventName string, handler EventHandler, priority int) error {
+	if !isComparable(handler) {
+		return ErrHandlerNotComparable
+	}
+
+	ed.mu.Lock()
+	defer ed.mu.Unlock()
+
+	for _, registration := range ed.handlers[eventName] {
+		if sameHandler(registration.handler, handler) {
+			return ErrHandlerAlreadyRegistered
 		}
 	}
-	ed.handlers[eventName] = append(ed.handlers[eventName], handler)
+
+	registrations := append(ed.handlers[eventName], registration{handler: handler, priority: priority})
+	sort.SliceStable(registrations, func(i, j int) bool {
+		return registrations[i].priority > registrations[j].priority
+	})
+	ed.handlers[eventName] = registrations
 	return nil
 }
 
 func (ed *eventDispatcher) Has(eventName string, handler EventHandler) bool {
-	if _, ok := ed.handlers[eventName]; ok {
-		for _, h := range ed.handlers[eventName] {
-			if h == handler {
-				return true
-			}
+	ed.mu.RLock()
+	defer ed.mu.RUnlock()
+
+	for _, registration := range ed.handlers[eventName] {
+		if sameHandler(registration.handler, handler) {
+			return true
 		}
 	}
 	return false
 }
 
 func (ed *eventDispatcher) Remove(eventName string, handler EventHandler) error {
-	if _, ok := ed.handlers[eventName]; ok {
-		for i, h := range ed.handlers[eventName] {
-			if h == handler {
-				ed.handlers[eventName] = append(ed.handlers[eventName][:i], ed.handlers[eventName][i+1:]...)
-				return nil
-			}
+	ed.mu.Lock()
+	defer ed.mu.Unlock()
+
+	for i, registration := range ed.handlers[eventName] {
+		if sameHandler(registration.handler, handler) {
+			ed.handlers[eventName] = append(ed.handlers[eventName][:i], ed.handlers[eventName][i+1:]...)
+			return nil
 		}
 	}
 	return nil
 }
 
 func (ed *eventDispatcher) Clear() {
-	ed.handlers = make(map[string][]EventHandler)
+	ed.mu.Lock()
+	defer ed.mu.Unlock()
+
+	ed.handlers = make(map[string][]registration)
+}
+
+// Wait blocks until the handlers started in async mode are done and returns
+// their joined errors, clearing them.
+func (ed *eventDispatcher) Wait() error {
+	ed.wg.Wait()
+
+	ed.errsMu.Lock()
+	defer ed.errsMu.Unlock()
+
+	err := errors.Join(ed.errs...)
+	ed.errs = nil
+	return err
+}
+
+// sameHandler compares handlers by value; only comparable handlers can be
+// registered, so functions are found again through their FuncHandler.
+func sameHandler(a, b EventHandler) bool {
+	return isComparable(a) && isComparable(b) && a == b
+}
+
+// isComparable inspects the handler value rather than its type: a struct with
+// an interface field has a comparable type, yet == panics when that field
+// holds a function, map or slice.
+func isComparable(handler EventHandler) bool {
+	value := reflect.ValueOf(handler)
+	return value.IsValid() && value.Comparable()
 }
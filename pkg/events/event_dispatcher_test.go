@@ -0,0 +1,181 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jailtonjunior94/order/pkg/vos"
+)
+
+type fieldHandler struct {
+	next any
+}
+
+func (h fieldHandler) Handle(context.Context, Event) error {
+	return nil
+}
+
+func TestDispatchRunsHandlersByPriority(t *testing.T) {
+	dispatcher := NewEventDispatcher(WithMode(ModeCollect))
+
+	var order []string
+	for _, handler := range []struct {
+		name     string
+		priority int
+	}{{name: "low", priority: -1}, {name: "default"}, {name: "high", priority: 10}, {name: "default again"}} {
+		err := dispatcher.RegisterWithPriority("order_created", NewFuncHandler(func(context.Context, Event) error {
+			order = append(order, handler.name)
+			return nil
+		}), handler.priority)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := dispatcher.Dispatch(context.Background(), NewEvent("order_created", vos.UUID{}, nil)); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"high", "default", "default again", "low"}
+	for i := range expected {
+		if order[i] != expected[i] {
+			t.Fatalf("order = %v, want %v", order, expected)
+		}
+	}
+}
+
+func TestDispatchRecoversPanickingHandlers(t *testing.T) {
+	tests := []struct {
+		name string
+		mode DispatchMode
+	}{
+		{name: "collect", mode: ModeCollect},
+		{name: "async", mode: ModeAsync},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dispatcher := NewEventDispatcher(WithMode(tt.mode))
+
+			var handled atomic.Bool
+			_ = dispatcher.RegisterWithPriority("order_created", NewFuncHandler(func(context.Context, Event) error {
+				panic("boom")
+			}), 1)
+			_ = dispatcher.Register("order_created", NewFuncHandler(func(context.Context, Event) error {
+				handled.Store(true)
+				return nil
+			}))
+
+			err := dispatcher.Dispatch(context.Background(), NewEvent("order_created", vos.UUID{}, nil))
+			if tt.mode == ModeAsync {
+				err = dispatcher.Wait()
+			}
+
+			if !errors.Is(err, ErrHandlerPanic) {
+				t.Errorf("error = %v, want %v", err, ErrHandlerPanic)
+			}
+
+			if !handled.Load() {
+				t.Error("expected the handler after the panic to run")
+			}
+		})
+	}
+}
+
+func TestWaitDrainsAsyncHandlers(t *testing.T) {
+	dispatcher := NewEventDispatcher(WithMode(ModeAsync))
+
+	var handled atomic.Int32
+	failure := errors.New("failed")
+	_ = dispatcher.Register("order_created", NewFuncHandler(func(context.Context, Event) error {
+		time.Sleep(10 * time.Millisecond)
+		handled.Add(1)
+		return nil
+	}))
+	_ = dispatcher.Register("order_created", NewFuncHandler(func(context.Context, Event) error {
+		return failure
+	}))
+
+	for range 3 {
+		if err := dispatcher.Dispatch(context.Background(), NewEvent("order_created", vos.UUID{}, nil)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := dispatcher.Wait(); !errors.Is(err, failure) {
+		t.Errorf("Wait() error = %v, want %v", err, failure)
+	}
+
+	if handled.Load() != 3 {
+		t.Errorf("handled = %d, want 3", handled.Load())
+	}
+
+	if err := dispatcher.Wait(); err != nil {
+		t.Errorf("second Wait() error = %v, want the errors cleared", err)
+	}
+}
+
+func TestAsyncDispatchSharesTheWorkerPool(t *testing.T) {
+	const workers = 2
+	dispatcher := NewEventDispatcher(WithMode(ModeAsync), WithWorkers(workers))
+
+	var (
+		mu      sync.Mutex
+		running int
+		peak    int
+	)
+	_ = dispatcher.Register("order_created", NewFuncHandler(func(context.Context, Event) error {
+		mu.Lock()
+		running++
+		peak = max(peak, running)
+		mu.Unlock()
+
+		time.Sleep(5 * time.Millisecond)
+
+		mu.Lock()
+		running--
+		mu.Unlock()
+		return nil
+	}))
+
+	for range 10 {
+		_ = dispatcher.Dispatch(context.Background(), NewEvent("order_created", vos.UUID{}, nil))
+	}
+
+	if err := dispatcher.Wait(); err != nil {
+		t.Fatal(err)
+	}
+
+	if peak > workers {
+		t.Errorf("peak concurrency = %d, want at most %d", peak, workers)
+	}
+}
+
+func TestRegisterRejectsUncomparableHandlers(t *testing.T) {
+	tests := []struct {
+		name        string
+		handler     EventHandler
+		expectedErr error
+	}{
+		{name: "function", handler: HandlerFunc(func(context.Context, Event) error { return nil }), expectedErr: ErrHandlerNotComparable},
+		{name: "struct holding a map", handler: fieldHandler{next: map[string]int{}}, expectedErr: ErrHandlerNotComparable},
+		{name: "struct holding a string", handler: fieldHandler{next: "audit"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dispatcher := NewEventDispatcher()
+			if err := dispatcher.Register("order_created", tt.handler); !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("Register() error = %v, want %v", err, tt.expectedErr)
+			}
+
+			if err := dispatcher.Register("order_created", tt.handler); tt.expectedErr == nil && !errors.Is(err, ErrHandlerAlreadyRegistered) {
+				t.Errorf("second Register() error = %v, want %v", err, ErrHandlerAlreadyRegistered)
+			}
+		})
+	}
+}
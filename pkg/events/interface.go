@@ -18,12 +18,34 @@ type Event interface {
 
 type EventDispatcher interface {
 	Register(eventType string, handler EventHandler) error
+	RegisterWithPriority(eventType string, handler EventHandler, priority int) error
 	Dispatch(ctx context.Context, event Event) error
 	Remove(eventType string, handler EventHandler) error
 	Has(eventType string, handler EventHandler) bool
 	Clear()
+	Wait() error
 }
 
 type EventHandler interface {
 	Handle(ctx context.Context, event Event) error
 }
+
+type HandlerFunc func(ctx context.Context, event Event) error
+
+func (f HandlerFunc) Handle(ctx context.Context, event Event) error {
+	return f(ctx, event)
+}
+
+// FuncHandler gives a function an identity, so the same registration can be
+// looked up and removed again; function values themselves are not comparable.
+type FuncHandler struct {
+	fn HandlerFunc
+}
+
+func NewFuncHandler(fn HandlerFunc) *FuncHandler {
+	return &FuncHandler{fn: fn}
+}
+
+func (h *FuncHandler) Handle(ctx context.Context, event Event) error {
+	return h.fn(ctx, event)
+}
@@ -19,23 +19,20 @@ func MakeRequest[TSuccess any, TError any](ctx context.Context, client HTTPClien
 
 	response, err := client.Do(request)
 	if err != nil {
-		return response.StatusCode, nil, nil, err
-	}
-
-	if response != nil {
-		defer response.Body.Close()
+		return http.StatusInternalServerError, nil, nil, err
 	}
+	defer response.Body.Close()
 
 	if response.StatusCode < 200 || response.StatusCode > 299 {
 		var errorResponse *TError
-		if err := json.NewDecoder(response.Body).Decode(&errorResponse); err != nil {
+		if err := json.NewDecoder(response.Body).Decode(&errorResponse); err != nil && err != io.EOF {
 			return http.StatusInternalServerError, nil, nil, err
 		}
 		return response.StatusCode, nil, errorResponse, nil
 	}
 
 	var successResponse *TSuccess
-	if err := json.NewDecoder(response.Body).Decode(&successResponse); err != nil {
+	if err := json.NewDecoder(response.Body).Decode(&successResponse); err != nil && err != io.EOF {
 		return http.StatusInternalServerError, nil, nil, err
 	}
 	return response.StatusCode, successResponse, nil, nil
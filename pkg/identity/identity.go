@@ -0,0 +1,18 @@
+package identity
+
+import "context"
+
+type customerKey struct{}
+
+// WithCustomer records the customer an upstream gateway authenticated for
+// the request.
+func WithCustomer(ctx context.Context, customerID string) context.Context {
+	return context.WithValue(ctx, customerKey{}, customerID)
+}
+
+// CustomerFromContext returns the authenticated customer, or an empty string
+// for anonymous requests.
+func CustomerFromContext(ctx context.Context) string {
+	customerID, _ := ctx.Value(customerKey{}).(string)
+	return customerID
+}
@@ -0,0 +1,50 @@
+package jobs
+
+import (
+	"context"
+	"time"
+)
+
+type (
+	Scope         int
+	OverlapPolicy int
+)
+
+const (
+	// PerReplica jobs run on every worker replica.
+	PerReplica Scope = iota
+	// Singleton jobs run on a single replica at a time, guarded by a lease.
+	Singleton
+)
+
+const (
+	// OverlapSkip drops a run while the previous one is still in flight.
+	OverlapSkip OverlapPolicy = iota
+	// OverlapQueue delays a run until the previous one finishes.
+	OverlapQueue
+)
+
+type (
+	Job struct {
+		Name     string
+		Schedule string
+		Scope    Scope
+		Timeout  time.Duration
+		Overlap  OverlapPolicy
+		Handle   func(ctx context.Context) error
+	}
+
+	// Daemon is a long-running loop started with the scheduler and stopped on
+	// shutdown. Singleton daemons only run on the replica holding their lease.
+	Daemon struct {
+		Name  string
+		Scope Scope
+		Run   func(ctx context.Context)
+	}
+
+	Registry interface {
+		Register(job Job) error
+		RegisterDaemon(daemon Daemon) error
+		Guard(job Job) func(ctx context.Context) error
+	}
+)
@@ -0,0 +1,253 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/jailtonjunior94/order/pkg/database/lease"
+	"github.com/jailtonjunior94/order/pkg/o11y"
+
+	"github.com/robfig/cron/v3"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+var (
+	ErrLockerRequired       = errors.New("locker is required for singleton jobs")
+	ErrJobAlreadyRegistered = errors.New("job already registered")
+	ErrJobSkipped           = errors.New("job skipped, lease held by another replica")
+)
+
+const (
+	statusSuccess = "success"
+	statusError   = "error"
+	statusTimeout = "timeout"
+	statusSkipped = "skipped"
+
+	defaultShutdownTimeout = 30 * time.Second
+)
+
+type (
+	SchedulerOptions func(scheduler *Scheduler)
+
+	Scheduler struct {
+		mu              sync.Mutex
+		cron            *cron.Cron
+		locker          lease.Locker
+		leaseTTL        time.Duration
+		shutdownTimeout time.Duration
+		o11y            o11y.Observability
+		names           map[string]struct{}
+		daemons         []Daemon
+		runs            metric.Int64Counter
+		duration        metric.Float64Histogram
+		ctx             context.Context
+		cancel          context.CancelFunc
+	}
+)
+
+func NewScheduler(o11y o11y.Observability, options ...SchedulerOptions) *Scheduler {
+	ctx, cancel := context.WithCancel(context.Background())
+	scheduler := &Scheduler{
+		cron:            cron.New(),
+		leaseTTL:        lease.DefaultTTL,
+		shutdownTimeout: defaultShutdownTimeout,
+		o11y:            o11y,
+		names:           make(map[string]struct{}),
+		ctx:             ctx,
+		cancel:          cancel,
+	}
+	for _, option := range options {
+		option(scheduler)
+	}
+
+	meter := o11y.MeterProvider().Meter("jobs")
+	runs, err := meter.Int64Counter("job_runs", metric.WithDescription("Number of job runs by job and status"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	duration, err := meter.Float64Histogram("job_duration", metric.WithDescription("Job run duration"), metric.WithUnit("s"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	scheduler.runs = runs
+	scheduler.duration = duration
+	return scheduler
+}
+
+func WithLocker(locker lease.Locker) SchedulerOptions {
+	return func(scheduler *Scheduler) {
+		scheduler.locker = locker
+	}
+}
+
+func WithLeaseTTL(ttl time.Duration) SchedulerOptions {
+	return func(scheduler *Scheduler) {
+		if ttl > 0 {
+			scheduler.leaseTTL = ttl
+		}
+	}
+}
+
+func WithShutdownTimeout(timeout time.Duration) SchedulerOptions {
+	return func(scheduler *Scheduler) {
+		if timeout > 0 {
+			scheduler.shutdownTimeout = timeout
+		}
+	}
+}
+
+func (s *Scheduler) Register(job Job) error {
+	if err := s.reserve(job.Name, job.Scope); err != nil {
+		return err
+	}
+
+	wrappers := []cron.JobWrapper{cron.Recover(cron.DefaultLogger)}
+	switch job.Overlap {
+	case OverlapQueue:
+		wrappers = append(wrappers, cron.DelayIfStillRunning(cron.DefaultLogger))
+	default:
+		wrappers = append(wrappers, cron.SkipIfStillRunning(cron.DefaultLogger))
+	}
+
+	run := s.Guard(job)
+	_, err := s.cron.AddJob(job.Schedule, cron.NewChain(wrappers...).Then(cron.FuncJob(func() {
+		_ = run(s.ctx)
+	})))
+	if err != nil {
+		return fmt.Errorf("job %s: %w", job.Name, err)
+	}
+	return nil
+}
+
+func (s *Scheduler) RegisterDaemon(daemon Daemon) error {
+	if err := s.reserve(daemon.Name, daemon.Scope); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.daemons = append(s.daemons, daemon)
+	return nil
+}
+
+// Guard returns job.Handle wrapped with its scope, timeout, span and metrics,
+// so it can also be triggered outside the cron schedule.
+func (s *Scheduler) Guard(job Job) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		ctx, span := s.o11y.Start(ctx, "job."+job.Name)
+		defer span.End()
+
+		if job.Timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, job.Timeout)
+			defer cancel()
+		}
+
+		start := time.Now()
+		err := s.execute(ctx, job)
+
+		status := statusSuccess
+		switch {
+		case errors.Is(err, ErrJobSkipped):
+			status = statusSkipped
+		case errors.Is(err, context.DeadlineExceeded) || errors.Is(ctx.Err(), context.DeadlineExceeded):
+			status = statusTimeout
+		case err != nil:
+			status = statusError
+		}
+
+		attributes := metric.WithAttributes(attribute.String("job", job.Name), attribute.String("status", status))
+		s.runs.Add(ctx, 1, attributes)
+		s.duration.Record(ctx, time.Since(start).Seconds(), attributes)
+
+		if err != nil && status != statusSkipped {
+			span.AddAttributes(ctx, o11y.Error, "error run job",
+				o11y.Attributes{Key: "job", Value: job.Name},
+				o11y.Attributes{Key: "error", Value: err},
+			)
+			return err
+		}
+		span.AddAttributes(ctx, o11y.Ok, "", o11y.Attributes{Key: "status", Value: status})
+		return err
+	}
+}
+
+func (s *Scheduler) execute(ctx context.Context, job Job) error {
+	if job.Scope == PerReplica {
+		return job.Handle(ctx)
+	}
+
+	var err error
+	executed, leaseErr := lease.WithLease(ctx, s.locker, "job:"+job.Name, s.leaseTTL, func(ctx context.Context) {
+		err = job.Handle(ctx)
+	})
+
+	if leaseErr != nil {
+		return errors.Join(err, leaseErr)
+	}
+
+	if !executed {
+		return ErrJobSkipped
+	}
+	return err
+}
+
+// Run starts every job and daemon and blocks until ctx is canceled. In-flight
+// runs then get the shutdown timeout to finish before their contexts are canceled.
+func (s *Scheduler) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, daemon := range s.daemons {
+		wg.Add(1)
+		go func(daemon Daemon) {
+			defer wg.Done()
+			s.runDaemon(daemon)
+		}(daemon)
+	}
+
+	s.cron.Start()
+	<-ctx.Done()
+
+	stopped := s.cron.Stop()
+	shutdown := time.NewTimer(s.shutdownTimeout)
+	defer shutdown.Stop()
+
+	select {
+	case <-stopped.Done():
+	case <-shutdown.C:
+		log.Printf("jobs still running after %s, canceling", s.shutdownTimeout)
+	}
+
+	s.cancel()
+	wg.Wait()
+}
+
+func (s *Scheduler) runDaemon(daemon Daemon) {
+	if daemon.Scope == PerReplica {
+		daemon.Run(s.ctx)
+		return
+	}
+	lease.RunAsLeader(s.ctx, s.locker, "daemon:"+daemon.Name, s.leaseTTL, daemon.Run)
+}
+
+func (s *Scheduler) reserve(name string, scope Scope) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if scope == Singleton && s.locker == nil {
+		return ErrLockerRequired
+	}
+
+	if _, ok := s.names[name]; ok {
+		return fmt.Errorf("%w: %s", ErrJobAlreadyRegistered, name)
+	}
+	s.names[name] = struct{}{}
+	return nil
+}
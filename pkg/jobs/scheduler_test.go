@@ -0,0 +1,155 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jailtonjunior94/order/pkg/database/lease"
+	"github.com/jailtonjunior94/order/pkg/o11y"
+
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type (
+	fakeObservability struct {
+		o11y.Observability
+		meterProvider *metric.MeterProvider
+	}
+
+	fakeSpan struct {
+		trace.Span
+	}
+
+	// fakeLeases is shared by the lockers of every replica in a test.
+	fakeLeases struct {
+		mu     sync.Mutex
+		tokens int64
+		held   map[string]bool
+	}
+
+	fakeLocker struct {
+		leases *fakeLeases
+		holder string
+	}
+)
+
+func (o fakeObservability) MeterProvider() *metric.MeterProvider {
+	return o.meterProvider
+}
+
+func (fakeObservability) Start(ctx context.Context, _ string, _ ...trace.SpanStartOption) (context.Context, o11y.Span) {
+	return ctx, fakeSpan{Span: trace.SpanFromContext(ctx)}
+}
+
+func (fakeSpan) AddStatus(context.Context, o11y.Code, string) {}
+
+func (fakeSpan) AddAttributes(context.Context, o11y.Code, string, ...o11y.Attributes) {}
+
+func (l *fakeLocker) Holder() string {
+	return l.holder
+}
+
+func (l *fakeLocker) Acquire(_ context.Context, name string, _ time.Duration) (*lease.Lease, error) {
+	l.leases.mu.Lock()
+	defer l.leases.mu.Unlock()
+
+	if l.leases.held[name] {
+		return nil, nil
+	}
+	l.leases.held[name] = true
+	l.leases.tokens++
+	return &lease.Lease{Name: name, Holder: l.holder, Token: l.leases.tokens}, nil
+}
+
+func (l *fakeLocker) Renew(context.Context, *lease.Lease, time.Duration) error {
+	return nil
+}
+
+func (l *fakeLocker) Release(_ context.Context, held *lease.Lease) error {
+	l.leases.mu.Lock()
+	defer l.leases.mu.Unlock()
+
+	delete(l.leases.held, held.Name)
+	return nil
+}
+
+func TestGuardRunsSingletonJobsOnOneReplica(t *testing.T) {
+	tests := []struct {
+		name         string
+		scope        Scope
+		expectedRuns int32
+	}{
+		{name: "singleton", scope: Singleton, expectedRuns: 1},
+		{name: "per replica", scope: PerReplica, expectedRuns: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			leases := &fakeLeases{held: make(map[string]bool)}
+			observability := fakeObservability{meterProvider: metric.NewMeterProvider()}
+
+			var (
+				runs    atomic.Int32
+				started sync.WaitGroup
+				release = make(chan struct{})
+			)
+			job := Job{
+				Name:  "expire_orders",
+				Scope: tt.scope,
+				Handle: func(ctx context.Context) error {
+					runs.Add(1)
+					started.Done()
+					<-release
+					return nil
+				},
+			}
+
+			// Every replica triggers the job while the first run is still
+			// holding the lease.
+			var (
+				replicas sync.WaitGroup
+				skipped  atomic.Int32
+			)
+			started.Add(int(tt.expectedRuns))
+			for i := range 3 {
+				locker := &fakeLocker{leases: leases, holder: string(rune('a' + i))}
+				scheduler := NewScheduler(observability, WithLocker(locker))
+
+				replicas.Add(1)
+				go func() {
+					defer replicas.Done()
+					if err := scheduler.Guard(job)(context.Background()); errors.Is(err, ErrJobSkipped) {
+						skipped.Add(1)
+					} else if err != nil {
+						t.Error(err)
+					}
+				}()
+			}
+
+			started.Wait()
+			for skipped.Load() < 3-tt.expectedRuns {
+				time.Sleep(time.Millisecond)
+			}
+			close(release)
+			replicas.Wait()
+
+			if runs.Load() != tt.expectedRuns {
+				t.Errorf("runs = %d, want %d", runs.Load(), tt.expectedRuns)
+			}
+		})
+	}
+}
+
+func TestRegisterSingletonRequiresALocker(t *testing.T) {
+	scheduler := NewScheduler(fakeObservability{meterProvider: metric.NewMeterProvider()})
+
+	err := scheduler.Register(Job{Name: "expire_orders", Schedule: "@every 1m", Scope: Singleton})
+	if !errors.Is(err, ErrLockerRequired) {
+		t.Errorf("Register() error = %v, want %v", err, ErrLockerRequired)
+	}
+}
@@ -0,0 +1,55 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/jailtonjunior94/order/pkg/audit"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// ActorHeader names the caller on whose behalf a request is made. It is only
+// honored by TrustedAudit, behind a token check; requests without it are
+// attributed to the fallback actor.
+const (
+	ActorHeader    = "X-Actor"
+	maxActorLength = 100
+)
+
+// Audit attributes every request to actor, ignoring ActorHeader, so anonymous
+// callers cannot write someone else's name into the audit trail.
+func Audit(actor string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(withActor(r, actor)))
+		})
+	}
+}
+
+// TrustedAudit attributes requests to the caller named in ActorHeader. It must
+// only be mounted after a middleware that authenticates the caller, such as
+// BearerToken.
+func TrustedAudit(fallbackActor string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			actor := strings.TrimSpace(r.Header.Get(ActorHeader))
+			if actor == "" {
+				actor = fallbackActor
+			}
+
+			if runes := []rune(actor); len(runes) > maxActorLength {
+				actor = string(runes[:maxActorLength])
+			}
+			next.ServeHTTP(w, r.WithContext(withActor(r, actor)))
+		})
+	}
+}
+
+func withActor(r *http.Request, actor string) context.Context {
+	return audit.WithMetadata(r.Context(), audit.Metadata{
+		Actor:     actor,
+		RequestID: middleware.GetReqID(r.Context()),
+	})
+}
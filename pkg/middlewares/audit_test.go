@@ -0,0 +1,43 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jailtonjunior94/order/pkg/audit"
+)
+
+func TestAudit(t *testing.T) {
+	tests := []struct {
+		name       string
+		middleware func(string) func(http.Handler) http.Handler
+		header     string
+		expected   string
+	}{
+		{name: "public request naming an actor", middleware: Audit, header: "admin@example.com", expected: "api"},
+		{name: "trusted request without actor", middleware: TrustedAudit, expected: "api"},
+		{name: "trusted request naming an actor", middleware: TrustedAudit, header: " ops@example.com ", expected: "ops@example.com"},
+		{name: "trusted request with a long actor", middleware: TrustedAudit, header: strings.Repeat("a", 150), expected: strings.Repeat("a", maxActorLength)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var actor string
+			handler := tt.middleware("api")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				actor = audit.FromContext(r.Context()).Actor
+			}))
+
+			request := httptest.NewRequest(http.MethodPost, "/orders", nil)
+			if tt.header != "" {
+				request.Header.Set(ActorHeader, tt.header)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), request)
+
+			if actor != tt.expected {
+				t.Errorf("actor = %q, want %q", actor, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package middlewares
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/jailtonjunior94/order/pkg/responses"
+)
+
+func BearerToken(token string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			authorization := r.Header.Get("Authorization")
+			credential, found := strings.CutPrefix(authorization, "Bearer ")
+			if !found || token == "" || subtle.ConstantTimeCompare([]byte(credential), []byte(token)) != 1 {
+				responses.Error(w, http.StatusUnauthorized, "unauthorized")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
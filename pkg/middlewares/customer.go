@@ -0,0 +1,34 @@
+package middlewares
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/jailtonjunior94/order/pkg/identity"
+)
+
+// CustomerHeader carries the customer authenticated by the API gateway, which
+// proves it forwarded the request by sending GatewayTokenHeader.
+const (
+	CustomerHeader     = "X-Customer-ID"
+	GatewayTokenHeader = "X-Gateway-Token"
+	maxCustomerLength  = 100
+)
+
+// Customer never rejects a request; without a valid gateway token it is
+// treated as anonymous.
+func Customer(gatewayToken string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			customerID := strings.TrimSpace(r.Header.Get(CustomerHeader))
+			credential := r.Header.Get(GatewayTokenHeader)
+			if gatewayToken == "" || customerID == "" || len(customerID) > maxCustomerLength ||
+				subtle.ConstantTimeCompare([]byte(credential), []byte(gatewayToken)) != 1 {
+				next.ServeHTTP(w, r)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(identity.WithCustomer(r.Context(), customerID)))
+		})
+	}
+}